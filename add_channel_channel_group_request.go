@@ -84,6 +84,14 @@ func (b *addChannelToChannelGroupBuilder) Execute() (
 	return newAddChannelToChannelGroupsResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the AddChannelToChannelGroup request asynchronously, the result of the Future is a *AddChannelToChannelGroupResponse.
+func (b *addChannelToChannelGroupBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type addChannelOpts struct {
 	pubnub       *PubNub
 	Channels     []string
@@ -10,6 +10,8 @@ import (
 
 const addChannelsToPushPath = "/v1/push/sub-key/%s/devices/%s"
 
+const addChannelsToPushPathAPNS2 = "/v2/push/sub-key/%s/devices-apns2/%s"
+
 var emptyAddPushNotificationsOnChannelsResponse *AddPushNotificationsOnChannelsResponse
 
 // addPushNotificationsOnChannelsBuilder provides a builder to add Push Notifications on channels
@@ -47,7 +49,7 @@ func (b *addPushNotificationsOnChannelsBuilder) Channels(
 	return b
 }
 
-// PushType set the type of Push: GCM, APNS, MPNS
+// PushType set the type of Push: GCM, APNS, APNS2, MPNS, FCM
 func (b *addPushNotificationsOnChannelsBuilder) PushType(
 	pushType PNPushType) *addPushNotificationsOnChannelsBuilder {
 	b.opts.PushType = pushType
@@ -61,6 +63,18 @@ func (b *addPushNotificationsOnChannelsBuilder) DeviceIDForPush(
 	return b
 }
 
+// Topic sets the APNS topic (bundle ID) of the app, required with PNPushTypeAPNS2.
+func (b *addPushNotificationsOnChannelsBuilder) Topic(topic string) *addPushNotificationsOnChannelsBuilder {
+	b.opts.Topic = topic
+	return b
+}
+
+// Environment sets the APNS environment of the device, development (the default) or production, only used with PNPushTypeAPNS2.
+func (b *addPushNotificationsOnChannelsBuilder) Environment(env PNPushEnvironment) *addPushNotificationsOnChannelsBuilder {
+	b.opts.Environment = env
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *addPushNotificationsOnChannelsBuilder) QueryParam(queryParam map[string]string) *addPushNotificationsOnChannelsBuilder {
 	b.opts.QueryParam = queryParam
@@ -79,10 +93,20 @@ func (b *addPushNotificationsOnChannelsBuilder) Execute() (
 	return emptyAddPushNotificationsOnChannelsResponse, status, nil
 }
 
+// ExecuteAsync runs the add Push Notifications on channels request asynchronously, the result of the Future is a *AddPushNotificationsOnChannelsResponse.
+func (b *addPushNotificationsOnChannelsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type addChannelsToPushOpts struct {
 	pubnub          *PubNub
 	Channels        []string
 	PushType        PNPushType
+	Topic           string
+	Environment     PNPushEnvironment
 	DeviceIDForPush string
 	QueryParam      map[string]string
 	Transport       http.RoundTripper
@@ -118,6 +142,10 @@ func (o *addChannelsToPushOpts) validate() error {
 		return newValidationError(o, StrMissingPushType)
 	}
 
+	if err := validatePushParams(o, o.PushType, o.Topic, o.Environment); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -125,6 +153,12 @@ func (o *addChannelsToPushOpts) validate() error {
 type AddPushNotificationsOnChannelsResponse struct{}
 
 func (o *addChannelsToPushOpts) buildPath() (string, error) {
+	if o.PushType == PNPushTypeAPNS2 {
+		return fmt.Sprintf(addChannelsToPushPathAPNS2,
+			o.pubnub.Config.SubscribeKey,
+			utils.URLEncode(o.DeviceIDForPush)), nil
+	}
+
 	return fmt.Sprintf(addChannelsToPushPath,
 		o.pubnub.Config.SubscribeKey,
 		utils.URLEncode(o.DeviceIDForPush)), nil
@@ -140,7 +174,7 @@ func (o *addChannelsToPushOpts) buildQuery() (*url.Values, error) {
 	}
 
 	q.Set("add", strings.Join(channels, ","))
-	q.Set("type", o.PushType.String())
+	setPushQuery(q, o.PushType, o.Topic, o.Environment)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -158,3 +158,74 @@ func TestAddChannelsToPushValidateSubscribeKey(t *testing.T) {
 
 	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
 }
+
+func TestAddChannelsToPushAPNS2(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newAddPushNotificationsOnChannelsBuilder(pubnub)
+	o.Channels([]string{"ch1", "ch2"})
+	o.DeviceIDForPush("deviceID")
+	o.PushType(PNPushTypeAPNS2)
+	o.Topic("com.example.app")
+	o.Environment(PNPushEnvironmentProduction)
+
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/push/sub-key/sub_key/devices-apns2/deviceID", path)
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("ch1,ch2", u.Get("add"))
+	assert.Equal("com.example.app", u.Get("topic"))
+	assert.Equal("production", u.Get("environment"))
+	assert.Equal("", u.Get("type"))
+}
+
+func TestAddChannelsToPushAPNS2DefaultEnvironment(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &addChannelsToPushOpts{
+		Channels:        []string{"ch1"},
+		DeviceIDForPush: "deviceId",
+		PushType:        PNPushTypeAPNS2,
+		Topic:           "com.example.app",
+		pubnub:          pubnub,
+	}
+
+	u, err := opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("development", u.Get("environment"))
+}
+
+func TestAddChannelsToPushValidatePushParams(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &addChannelsToPushOpts{
+		Channels:        []string{"ch1"},
+		DeviceIDForPush: "deviceId",
+		PushType:        PNPushTypeAPNS2,
+		pubnub:          pubnub,
+	}
+	assert.Contains(opts.validate().Error(), "Missing Push Topic")
+
+	opts.Topic = "com.example.app"
+	opts.Environment = PNPushEnvironment("staging")
+	assert.Contains(opts.validate().Error(), "Invalid Push Environment")
+
+	opts.PushType = PNPushTypeFCM
+	opts.Environment = ""
+	assert.Contains(opts.validate().Error(), "Topic and Environment are only supported by APNS2")
+
+	opts.Topic = ""
+	assert.Nil(opts.validate())
+
+	u, err := opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("fcm", u.Get("type"))
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v1/push/sub-key/sub_key/devices/deviceId", path)
+}
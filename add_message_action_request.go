@@ -0,0 +1,251 @@
+package pubnub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+const addMessageActionPath = "/v1/message-actions/%s/channel/%s/message/%s"
+
+// maxMessageActionTypeLength is the max length of the type of a message action.
+const maxMessageActionTypeLength = 15
+
+var emptyAddMessageActionResponse *PNAddMessageActionResponse
+
+type addMessageActionBuilder struct {
+	opts *addMessageActionOpts
+}
+
+func newAddMessageActionBuilder(pubnub *PubNub) *addMessageActionBuilder {
+	builder := addMessageActionBuilder{
+		opts: &addMessageActionOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newAddMessageActionBuilderWithContext(pubnub *PubNub,
+	context Context) *addMessageActionBuilder {
+	builder := addMessageActionBuilder{
+		opts: &addMessageActionOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// MessageAction is the type and the value of an action added to a message,
+// for ex. a reaction or a read receipt.
+type MessageAction struct {
+	ActionType  string `json:"type"`
+	ActionValue string `json:"value"`
+}
+
+// Channel sets the Channel of the message.
+func (b *addMessageActionBuilder) Channel(ch string) *addMessageActionBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// MessageTimetoken sets the timetoken of the message the action is added to.
+func (b *addMessageActionBuilder) MessageTimetoken(timetoken int64) *addMessageActionBuilder {
+	b.opts.MessageTimetoken = timetoken
+	return b
+}
+
+// Action sets the action added to the message.
+func (b *addMessageActionBuilder) Action(action MessageAction) *addMessageActionBuilder {
+	b.opts.Action = action
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *addMessageActionBuilder) QueryParam(queryParam map[string]string) *addMessageActionBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Add Message Action request.
+func (b *addMessageActionBuilder) Transport(tr http.RoundTripper) *addMessageActionBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Add Message Action request.
+func (b *addMessageActionBuilder) Execute() (*PNAddMessageActionResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyAddMessageActionResponse, status, err
+	}
+
+	return newAddMessageActionResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Add Message Action request asynchronously, the result of the Future is a *PNAddMessageActionResponse.
+func (b *addMessageActionBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type addMessageActionOpts struct {
+	pubnub *PubNub
+
+	Channel          string
+	MessageTimetoken int64
+	Action           MessageAction
+	QueryParam       map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *addMessageActionOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *addMessageActionOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *addMessageActionOpts) context() Context {
+	return o.ctx
+}
+
+func (o *addMessageActionOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if o.MessageTimetoken <= 0 {
+		return newValidationError(o, "Missing Message Timetoken")
+	}
+
+	if o.Action.ActionType == "" {
+		return newValidationError(o, "Missing Action Type")
+	}
+
+	if len(o.Action.ActionType) > maxMessageActionTypeLength {
+		return newValidationError(o, fmt.Sprintf("Action Type longer than %d characters", maxMessageActionTypeLength))
+	}
+
+	if o.Action.ActionValue == "" {
+		return newValidationError(o, "Missing Action Value")
+	}
+
+	return nil
+}
+
+func (o *addMessageActionOpts) buildPath() (string, error) {
+	return fmt.Sprintf(addMessageActionPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel),
+		strconv.FormatInt(o.MessageTimetoken, 10)), nil
+}
+
+func (o *addMessageActionOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *addMessageActionOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *addMessageActionOpts) buildBody() ([]byte, error) {
+	body, err := json.Marshal(o.Action)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	return body, nil
+}
+
+func (o *addMessageActionOpts) httpMethod() string {
+	return "POST"
+}
+
+func (o *addMessageActionOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *addMessageActionOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *addMessageActionOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *addMessageActionOpts) operationType() OperationType {
+	return PNAddMessageActionOperation
+}
+
+func (o *addMessageActionOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNMessageAction is an action added to a message.
+type PNMessageAction struct {
+	ActionType       string
+	ActionValue      string
+	UUID             string
+	ActionTimetoken  int64
+	MessageTimetoken int64
+}
+
+// PNAddMessageActionResponse is the response to the Add Message Action request.
+type PNAddMessageActionResponse struct {
+	Data PNMessageAction
+}
+
+func newMessageAction(data map[string]interface{}) PNMessageAction {
+	action := PNMessageAction{
+		ActionTimetoken:  parseHistoryTimetoken(data["actionTimetoken"]),
+		MessageTimetoken: parseHistoryTimetoken(data["messageTimetoken"]),
+	}
+	action.ActionType, _ = data["type"].(string)
+	action.ActionValue, _ = data["value"].(string)
+	action.UUID, _ = data["uuid"].(string)
+
+	return action
+}
+
+func newAddMessageActionResponse(jsonBytes []byte, status StatusResponse) (
+	*PNAddMessageActionResponse, StatusResponse, error) {
+	var value map[string]interface{}
+
+	err := json.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		e := pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+
+		return emptyAddMessageActionResponse, status, e
+	}
+
+	data, _ := value["data"].(map[string]interface{})
+
+	return &PNAddMessageActionResponse{Data: newMessageAction(data)}, status, nil
+}
@@ -0,0 +1,236 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const auditPath = "/v1/auth/audit/sub-key/%s"
+
+var emptyAuditResponse *AuditResponse
+
+type auditBuilder struct {
+	opts *auditOpts
+}
+
+func newAuditBuilder(pubnub *PubNub) *auditBuilder {
+	builder := auditBuilder{
+		opts: &auditOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newAuditBuilderWithContext(pubnub *PubNub, context Context) *auditBuilder {
+	builder := auditBuilder{
+		opts: &auditOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// Channel sets the Channel for the Audit request.
+func (b *auditBuilder) Channel(channel string) *auditBuilder {
+	b.opts.Channel = channel
+
+	return b
+}
+
+// ChannelGroup sets the ChannelGroup for the Audit request.
+func (b *auditBuilder) ChannelGroup(group string) *auditBuilder {
+	b.opts.ChannelGroup = group
+
+	return b
+}
+
+// AuthKeys sets the AuthKeys for the Audit request, the channel or the channel group is required to audit auth keys.
+func (b *auditBuilder) AuthKeys(authKeys []string) *auditBuilder {
+	b.opts.AuthKeys = authKeys
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *auditBuilder) QueryParam(queryParam map[string]string) *auditBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute runs the Audit request.
+func (b *auditBuilder) Execute() (*AuditResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyAuditResponse, status, err
+	}
+
+	resp, status, err := newAuditResponse(rawJSON, status)
+	if err == nil {
+		b.opts.pubnub.permissionCache.addGrantResponse(&GrantResponse{
+			Level:         resp.Level,
+			SubscribeKey:  resp.SubscribeKey,
+			TTL:           resp.TTL,
+			Channels:      resp.Channels,
+			ChannelGroups: resp.ChannelGroups,
+			ReadEnabled:   resp.ReadEnabled,
+			WriteEnabled:  resp.WriteEnabled,
+			ManageEnabled: resp.ManageEnabled,
+			DeleteEnabled: resp.DeleteEnabled,
+		})
+	}
+
+	return resp, status, err
+}
+
+// ExecuteAsync runs the Audit request asynchronously, the result of the Future is a *AuditResponse.
+func (b *auditBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type auditOpts struct {
+	pubnub *PubNub
+	ctx    Context
+
+	Channel      string
+	ChannelGroup string
+	AuthKeys     []string
+	QueryParam   map[string]string
+}
+
+func (o *auditOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *auditOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *auditOpts) context() Context {
+	return o.ctx
+}
+
+func (o *auditOpts) validate() error {
+	if o.config().PublishKey == "" {
+		return newValidationError(o, StrMissingPubKey)
+	}
+
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.config().SecretKey == "" {
+		return newValidationError(o, StrMissingSecretKey)
+	}
+
+	if len(o.AuthKeys) > 0 && o.Channel == "" && o.ChannelGroup == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	return nil
+}
+
+func (o *auditOpts) buildPath() (string, error) {
+	return fmt.Sprintf(auditPath, o.pubnub.Config.SubscribeKey), nil
+}
+
+func (o *auditOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	if o.Channel != "" {
+		q.Set("channel", o.Channel)
+	}
+
+	if o.ChannelGroup != "" {
+		q.Set("channel-group", o.ChannelGroup)
+	}
+
+	if len(o.AuthKeys) > 0 {
+		q.Set("auth", strings.Join(o.AuthKeys, ","))
+	}
+
+	timestamp := time.Now().Unix()
+	q.Set("timestamp", strconv.Itoa(int(timestamp)))
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *auditOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *auditOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *auditOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *auditOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *auditOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *auditOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *auditOpts) operationType() OperationType {
+	return PNAccessManagerAudit
+}
+
+func (o *auditOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// AuditResponse is the struct returned when the Execute function of Audit is called.
+type AuditResponse struct {
+	Level        string
+	SubscribeKey string
+
+	TTL int
+
+	Channels      map[string]*PNPAMEntityData
+	ChannelGroups map[string]*PNPAMEntityData
+
+	ReadEnabled   bool
+	WriteEnabled  bool
+	ManageEnabled bool
+	DeleteEnabled bool
+}
+
+func newAuditResponse(jsonBytes []byte, status StatusResponse) (
+	*AuditResponse, StatusResponse, error) {
+	grantResp, status, err := newGrantResponse(jsonBytes, status)
+	if err != nil {
+		return emptyAuditResponse, status, err
+	}
+
+	return &AuditResponse{
+		Level:         grantResp.Level,
+		SubscribeKey:  grantResp.SubscribeKey,
+		TTL:           grantResp.TTL,
+		Channels:      grantResp.Channels,
+		ChannelGroups: grantResp.ChannelGroups,
+		ReadEnabled:   grantResp.ReadEnabled,
+		WriteEnabled:  grantResp.WriteEnabled,
+		ManageEnabled: grantResp.ManageEnabled,
+		DeleteEnabled: grantResp.DeleteEnabled,
+	}, status, nil
+}
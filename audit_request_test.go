@@ -0,0 +1,125 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRequestBasic(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &auditOpts{
+		AuthKeys: []string{"key1", "key2"},
+		Channel:  "ch",
+		pubnub:   pubnub,
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	u := &url.URL{
+		Path: path,
+	}
+
+	h.AssertPathsEqual(t,
+		fmt.Sprintf("/v1/auth/audit/sub-key/%s", opts.pubnub.Config.SubscribeKey),
+		u.EscapedPath(), []int{})
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("auth", "key1,key2")
+	expected.Set("channel", "ch")
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+}
+
+func TestNewAuditBuilderContext(t *testing.T) {
+	assert := assert.New(t)
+	o := newAuditBuilderWithContext(pubnub, backgroundContext)
+	o.ChannelGroup("cg")
+	o.QueryParam(map[string]string{
+		"q1": "v1",
+	})
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("channel-group", "cg")
+	expected.Set("q1", "v1")
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+}
+
+func TestAuditOptsValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	opts := &auditOpts{
+		AuthKeys: []string{"key"},
+		pubnub:   pn,
+	}
+
+	assert.Contains(opts.validate().Error(), "Missing Channel")
+
+	opts.Channel = "ch"
+	assert.Nil(opts.validate())
+
+	pn.Config.SecretKey = ""
+	assert.Contains(opts.validate().Error(), "Missing Secret Key")
+}
+
+func TestAuditSignature(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = "sec"
+
+	u, err := buildURL(&auditOpts{
+		Channel: "ch",
+		pubnub:  pn,
+	})
+	assert.Nil(err)
+	assert.NotEmpty(u.Query().Get("signature"))
+}
+
+func TestNewAuditResponseChannelGroups(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"message":"Success","payload":{"level":"channel-group","subscribe_key":"sub-key","channel-groups":{"cg1":{"r":1,"w":0,"m":1,"d":0},"cg2":{"r":0,"w":1,"m":0,"d":0,"auths":{"key":{"r":1,"w":1,"m":0,"d":0}}}}},"service":"Access Manager","status":200}`)
+
+	resp, _, err := newAuditResponse(jsonBytes, StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("channel-group", resp.Level)
+	assert.Equal("sub-key", resp.SubscribeKey)
+	assert.Equal("cg1", resp.ChannelGroups["cg1"].Name)
+	assert.True(resp.ChannelGroups["cg1"].ReadEnabled)
+	assert.True(resp.ChannelGroups["cg1"].ManageEnabled)
+	assert.False(resp.ChannelGroups["cg1"].WriteEnabled)
+	assert.Equal(0, len(resp.ChannelGroups["cg1"].AuthKeys))
+	assert.Equal("cg2", resp.ChannelGroups["cg2"].Name)
+	assert.True(resp.ChannelGroups["cg2"].WriteEnabled)
+	assert.True(resp.ChannelGroups["cg2"].AuthKeys["key"].ReadEnabled)
+}
+
+func TestNewAuditResponseAuthKeys(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"message":"Success","payload":{"level":"user","subscribe_key":"sub-key","channel":"ch1","auths":{"my-pam-key":{"r":1,"w":0,"m":0,"d":0}}},"service":"Access Manager","status":200}`)
+
+	resp, _, err := newAuditResponse(jsonBytes, StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("user", resp.Level)
+	assert.True(resp.Channels["ch1"].AuthKeys["my-pam-key"].ReadEnabled)
+	assert.False(resp.Channels["ch1"].AuthKeys["my-pam-key"].WriteEnabled)
+}
+
+func TestNewAuditResponseErrorUnmarshalling(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := newAuditResponse([]byte(`s`), StatusResponse{})
+	assert.Equal("pubnub/parsing: Error unmarshalling response: {s}", err.Error())
+}
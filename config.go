@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/pubnub/go/utils"
 	"log"
+	"sync"
 )
 
 const (
@@ -14,30 +15,61 @@ const (
 // PubNub client behaviour. Configuration instance contain additional set of
 // properties which allow to perform precise PubNub client configuration.
 type Config struct {
-	PublishKey                 string             // PublishKey you can get it from admin panel (only required if publishing).
-	SubscribeKey               string             // SubscribeKey you can get it from admin panel.
-	SecretKey                  string             // SecretKey (only required for modifying/revealing access permissions).
-	AuthKey                    string             // AuthKey If Access Manager is utilized, client will use this AuthKey in all restricted requests.
-	Origin                     string             // Custom Origin if needed
-	UUID                       string             // UUID to be used as a device identifier, a default uuid is generated if not passed.
-	CipherKey                  string             // If CipherKey is passed, all communications to/from PubNub will be encrypted.
-	Secure                     bool               // True to use TLS
-	ConnectTimeout             int                // net.Dialer.Timeout
-	NonSubscribeRequestTimeout int                // http.Client.Timeout for non-subscribe requests
-	SubscribeRequestTimeout    int                // http.Client.Timeout for subscribe requests only
-	HeartbeatInterval          int                // The frequency of the pings to the server to state that the client is active
-	PresenceTimeout            int                // The time after which the server will send a timeout for the client
-	MaximumReconnectionRetries int                // The config sets how many times to retry to reconnect before giving up.
-	MaximumLatencyDataAge      int                // Max time to store the latency data for telemetry
-	FilterExpression           string             // Feature to subscribe with a custom filter expression.
-	PNReconnectionPolicy       ReconnectionPolicy // Reconnection policy selection
-	Log                        *log.Logger        // Logger instance
-	SuppressLeaveEvents        bool               // When true the SDK doesn't send out the leave requests.
-	DisablePNOtherProcessing   bool               // PNOther processing looks for pn_other in the JSON on the recevied message
-	UseHTTP2                   bool               // HTTP2 Flag
-	MessageQueueOverflowCount  int                // When the limit is exceeded by the number of messages received in a single subscribe request, a status event PNRequestMessageCountExceededCategory is fired.
-	MaxIdleConnsPerHost        int                // Used to set the value of HTTP Transport's MaxIdleConnsPerHost.
-	MaxWorkers                 int                // Number of max workers for Publish and Grant requests
+	PublishKey                 string              // PublishKey you can get it from admin panel (only required if publishing).
+	SubscribeKey               string              // SubscribeKey you can get it from admin panel.
+	SecretKey                  string              // SecretKey (only required for modifying/revealing access permissions).
+	AuthKey                    string              // AuthKey If Access Manager is utilized, client will use this AuthKey in all restricted requests.
+	Origin                     string              // Custom Origin if needed
+	UUID                       string              // UUID to be used as a device identifier, a default uuid is generated if not passed.
+	CipherKey                  string              // If CipherKey is passed, all communications to/from PubNub will be encrypted.
+	CipherKeys                 map[string]string   // Cipher keys of the channels used instead of the CipherKey, an empty key disables the encryption on the channel. Set before the client is used.
+	Secure                     bool                // True to use TLS
+	ConnectTimeout             int                 // net.Dialer.Timeout
+	NonSubscribeRequestTimeout int                 // http.Client.Timeout for non-subscribe requests
+	SubscribeRequestTimeout    int                 // http.Client.Timeout for subscribe requests only
+	HeartbeatInterval          int                 // The frequency of the pings to the server to state that the client is active
+	PresenceTimeout            int                 // The time after which the server will send a timeout for the client
+	MaximumReconnectionRetries int                 // The config sets how many times to retry to reconnect before giving up.
+	MaximumLatencyDataAge      int                 // Max time to store the latency data for telemetry
+	FilterExpression           string              // Feature to subscribe with a custom filter expression.
+	PNReconnectionPolicy       ReconnectionPolicy  // Reconnection policy selection
+	Log                        *log.Logger         // Logger instance
+	SuppressLeaveEvents        bool                // When true the SDK doesn't send out the leave requests.
+	DisablePNOtherProcessing   bool                // PNOther processing looks for pn_other in the JSON on the recevied message
+	UseHTTP2                   bool                // HTTP2 Flag
+	MessageQueueOverflowCount  int                 // When the limit is exceeded by the number of messages received in a single subscribe request, a status event PNRequestMessageCountExceededCategory is fired.
+	MaxIdleConnsPerHost        int                 // Used to set the value of HTTP Transport's MaxIdleConnsPerHost.
+	MaxWorkers                 int                 // Number of max workers for Publish and Grant requests
+	CredentialsProvider        CredentialsProvider // Supplies the auth key of every request instead of the AuthKey, the requests denied by the server are retried once after Refresh.
+	PreflightAccessChecks      bool                // When true the requests denied by the cached Access Manager permissions fail without being sent.
+	MaxMessageSize             int                 // Publish requests of messages larger than MaxMessageSize bytes fail with a MessageTooLargeError without being sent, 0 disables the check.
+	MaxURLLength               int                 // Publish requests with a GET URL longer than MaxURLLength are sent with POST, 0 disables the switch.
+
+	authToken *tokenStore // Access Manager token set with SetToken, used instead of the AuthKey.
+}
+
+// tokenStore keeps the token set with SetToken. The requests read a copy of
+// the Config, the copies share the store.
+type tokenStore struct {
+	sync.RWMutex
+	token string
+}
+
+func (s *tokenStore) get() string {
+	if s == nil {
+		return ""
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.token
+}
+
+func (s *tokenStore) set(token string) {
+	s.Lock()
+	s.token = token
+	s.Unlock()
 }
 
 // NewDemoConfig initiates the config with demo keys, for tests only.
@@ -69,11 +101,40 @@ func NewConfig() *Config {
 		MessageQueueOverflowCount:  100,
 		MaxIdleConnsPerHost:        30,
 		MaxWorkers:                 20,
+		MaxMessageSize:             32768,
+		MaxURLLength:               8192,
+		authToken:                  &tokenStore{},
 	}
 
 	return &c
 }
 
+// currentAuthKey returns the auth key sent with the requests: the key of the
+// CredentialsProvider, the token set with SetToken or the AuthKey.
+func (c *Config) currentAuthKey() string {
+	if c.CredentialsProvider != nil {
+		if v := c.CredentialsProvider.AuthKey(); v != "" {
+			return v
+		}
+	}
+
+	if token := c.authToken.get(); token != "" {
+		return token
+	}
+
+	return c.AuthKey
+}
+
+// cipherKeyForChannel returns the key of the channel in CipherKeys or the
+// CipherKey.
+func (c *Config) cipherKeyForChannel(channel string) string {
+	if key, ok := c.CipherKeys[channel]; ok {
+		return key
+	}
+
+	return c.CipherKey
+}
+
 // SetPresenceTimeoutWithCustomInterval sets the presence timeout and interval.
 // timeout: How long the server will consider the client alive for presence.
 // interval: How often the client will announce itself to server.
@@ -0,0 +1,78 @@
+package pubnub
+
+import (
+	"sync"
+)
+
+// CredentialsProvider supplies the auth key, or the token, of every request.
+// When a request is denied with the auth key returned by the provider,
+// Refresh is called and, if it returns true, the request is sent once more
+// with the new auth key.
+type CredentialsProvider interface {
+	// AuthKey returns the auth key of the next request.
+	AuthKey() string
+	// Refresh is called with the auth key of a request denied by the server,
+	// it blocks until new credentials are available and returns false if
+	// the request should not be retried.
+	Refresh(deniedAuthKey string) bool
+}
+
+// RefreshCredentialsProvider is a CredentialsProvider calling a refresh
+// callback when the current auth key is denied. Concurrent requests denied
+// with the same auth key wait for a single refresh.
+type RefreshCredentialsProvider struct {
+	sync.RWMutex
+	refreshMutex sync.Mutex
+
+	authKey string
+	refresh func(deniedAuthKey string) (string, error)
+}
+
+// NewRefreshCredentialsProvider returns a RefreshCredentialsProvider with the
+// initial auth key and the callback returning the new auth key.
+func NewRefreshCredentialsProvider(authKey string,
+	refresh func(deniedAuthKey string) (string, error)) *RefreshCredentialsProvider {
+	return &RefreshCredentialsProvider{
+		authKey: authKey,
+		refresh: refresh,
+	}
+}
+
+// AuthKey returns the current auth key.
+func (p *RefreshCredentialsProvider) AuthKey() string {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.authKey
+}
+
+// SetAuthKey replaces the current auth key.
+func (p *RefreshCredentialsProvider) SetAuthKey(authKey string) {
+	p.Lock()
+	p.authKey = authKey
+	p.Unlock()
+}
+
+// Refresh calls the refresh callback unless the auth key was already
+// refreshed after the request was sent.
+func (p *RefreshCredentialsProvider) Refresh(deniedAuthKey string) bool {
+	p.refreshMutex.Lock()
+	defer p.refreshMutex.Unlock()
+
+	if p.AuthKey() != deniedAuthKey {
+		return true
+	}
+
+	if p.refresh == nil {
+		return false
+	}
+
+	authKey, err := p.refresh(deniedAuthKey)
+	if err != nil || authKey == "" || authKey == deniedAuthKey {
+		return false
+	}
+
+	p.SetAuthKey(authKey)
+
+	return true
+}
@@ -0,0 +1,122 @@
+package pubnub
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshCredentialsProviderRefreshOnce(t *testing.T) {
+	assert := assert.New(t)
+	var calls int32
+
+	provider := NewRefreshCredentialsProvider("key-1", func(denied string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "key-2", nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(provider.Refresh("key-1"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(int32(1), atomic.LoadInt32(&calls))
+	assert.Equal("key-2", provider.AuthKey())
+}
+
+func TestRefreshCredentialsProviderRefreshFails(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := NewRefreshCredentialsProvider("key-1", func(denied string) (string, error) {
+		return "", errors.New("no token")
+	})
+	assert.False(provider.Refresh("key-1"))
+	assert.Equal("key-1", provider.AuthKey())
+
+	provider = NewRefreshCredentialsProvider("key-1", nil)
+	assert.False(provider.Refresh("key-1"))
+}
+
+func TestCredentialsProviderAuthKey(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.AuthKey = "static"
+	pn.Config.CredentialsProvider = NewRefreshCredentialsProvider("dynamic", nil)
+
+	u, err := buildURL(&timeOpts{
+		pubnub: pn,
+	})
+	assert.Nil(err)
+	assert.Equal("dynamic", u.Query().Get("auth"))
+}
+
+func TestExecuteRequestRetriesWithRefreshedCredentials(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/time/0",
+		Query:              "auth=expired",
+		ResponseBody:       `{"message":"Forbidden","payload":{},"error":true,"service":"Access Manager","status":403}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid"},
+		ResponseStatusCode: 403,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/time/0",
+		Query:              "auth=fresh",
+		ResponseBody:       `[15341234567890000]`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_time"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	denied := []string{}
+	pn.Config.CredentialsProvider = NewRefreshCredentialsProvider("expired", func(deniedAuthKey string) (string, error) {
+		denied = append(denied, deniedAuthKey)
+		return "fresh", nil
+	})
+
+	res, status, err := pn.Time().Execute()
+	assert.Nil(err)
+	assert.Equal(int64(15341234567890000), res.Timetoken)
+	assert.Equal("fresh", status.AuthKey)
+	assert.Equal([]string{"expired"}, denied)
+}
+
+func TestExecuteRequestWithoutRefresh(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/time/0",
+		Query:              "auth=expired",
+		ResponseBody:       `{"message":"Forbidden","status":403}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_time"},
+		ResponseStatusCode: 403,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+	pn.Config.CredentialsProvider = NewRefreshCredentialsProvider("expired", func(deniedAuthKey string) (string, error) {
+		return "", errors.New("refresh failed")
+	})
+
+	_, status, err := pn.Time().Execute()
+	assert.NotNil(err)
+	assert.Equal(403, status.StatusCode)
+	assert.Equal("expired", status.AuthKey)
+}
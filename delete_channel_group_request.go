@@ -64,6 +64,14 @@ func (b *deleteChannelGroupBuilder) Execute() (
 	return emptyDeleteChannelGroupResponse, status, nil
 }
 
+// ExecuteAsync runs the DeleteChannelGroup request asynchronously, the result of the Future is a *DeleteChannelGroupResponse.
+func (b *deleteChannelGroupBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type deleteChannelGroupOpts struct {
 	pubnub       *PubNub
 	ChannelGroup string
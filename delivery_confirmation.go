@@ -0,0 +1,63 @@
+package pubnub
+
+import (
+	"sync"
+)
+
+// deliveryConfirmations matches the messages published with ConfirmDelivery
+// with the messages of the client UUID received through the subscribe loop.
+type deliveryConfirmations struct {
+	sync.Mutex
+
+	waiters map[deliveryKey]chan int64
+}
+
+// deliveryKey is the channel and the sequence number of a published message,
+// the timetoken is only known once the Publish response is received which
+// can be after the message is received back.
+type deliveryKey struct {
+	channel string
+	seqn    int
+}
+
+func newDeliveryConfirmations() *deliveryConfirmations {
+	return &deliveryConfirmations{
+		waiters: make(map[deliveryKey]chan int64),
+	}
+}
+
+// expect registers a published message, the returned channel receives the
+// timetokens of the messages received with the same channel and sequence number.
+func (d *deliveryConfirmations) expect(channel string, seqn int) chan int64 {
+	d.Lock()
+	defer d.Unlock()
+
+	timetokens := make(chan int64, 8)
+	d.waiters[deliveryKey{channel: channel, seqn: seqn}] = timetokens
+
+	return timetokens
+}
+
+func (d *deliveryConfirmations) remove(channel string, seqn int) {
+	d.Lock()
+	defer d.Unlock()
+
+	delete(d.waiters, deliveryKey{channel: channel, seqn: seqn})
+}
+
+// confirm is called for the messages of the client UUID received through the
+// subscribe loop.
+func (d *deliveryConfirmations) confirm(channel string, seqn int, timetoken int64) {
+	d.Lock()
+	defer d.Unlock()
+
+	timetokens, ok := d.waiters[deliveryKey{channel: channel, seqn: seqn}]
+	if !ok {
+		return
+	}
+
+	select {
+	case timetokens <- timetoken:
+	default:
+	}
+}
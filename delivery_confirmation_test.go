@@ -0,0 +1,165 @@
+package pubnub
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoTransport answers the Publish requests, onPublish is called before the
+// response to simulate a message received back before the Publish response.
+type echoTransport struct {
+	onPublish func(req *http.Request)
+}
+
+func (t *echoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.onPublish != nil {
+		t.onPublish(req)
+	}
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`[1,"Sent","15191382475826880"]`)),
+		Request:    req,
+	}, nil
+}
+
+func newDeliveryTestPubNub(transport *echoTransport) *PubNub {
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(&http.Client{Transport: transport})
+	pn.subscriptionManager.stateManager.adaptSubscribeOperation(&SubscribeOperation{
+		Channels: []string{"ch"},
+	})
+
+	return pn
+}
+
+func TestDeliveryConfirmations(t *testing.T) {
+	assert := assert.New(t)
+	d := newDeliveryConfirmations()
+
+	timetokens := d.expect("ch", 1)
+	d.confirm("ch", 2, 10)
+	d.confirm("other", 1, 11)
+	d.confirm("ch", 1, 12)
+
+	assert.Equal(int64(12), <-timetokens)
+	select {
+	case <-timetokens:
+		assert.Fail("unexpected confirmation")
+	default:
+	}
+
+	d.remove("ch", 1)
+	d.confirm("ch", 1, 13)
+	assert.Equal(0, len(d.waiters))
+}
+
+func TestPublishConfirmDeliveryNotSubscribed(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := newPublishBuilder(pubnub).
+		Channel("not-subscribed").
+		Message("hey").
+		ConfirmDelivery(time.Second).
+		Execute()
+
+	assert.Contains(err.Error(), "Delivery confirmation requires a subscription to the channel")
+}
+
+func TestPublishConfirmDeliveryWildcardSubscribed(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.subscriptionManager.stateManager.adaptSubscribeOperation(&SubscribeOperation{
+		Channels: []string{"chat.*"},
+	})
+
+	opts := newPublishBuilder(pn).opts
+	opts.Channel = "chat.room"
+	assert.True(opts.isChannelSubscribed())
+
+	opts.Channel = "news.room"
+	assert.False(opts.isChannelSubscribed())
+}
+
+func TestPublishConfirmDeliveryGroupSubscribed(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.subscriptionManager.stateManager.adaptSubscribeOperation(&SubscribeOperation{
+		ChannelGroups: []string{"cg"},
+	})
+
+	opts := newPublishBuilder(pn).opts
+	opts.Channel = "in-group"
+	assert.True(opts.isChannelSubscribed())
+}
+
+func TestPublishConfirmDelivery(t *testing.T) {
+	assert := assert.New(t)
+	transport := &echoTransport{}
+	pn := newDeliveryTestPubNub(transport)
+
+	transport.onPublish = func(req *http.Request) {
+		seqn := 0
+		if _, err := fmt.Sscan(req.URL.Query().Get("seqn"), &seqn); err != nil {
+			return
+		}
+
+		// a message of another client with the same sequence number
+		processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+			Shard:           "1",
+			Channel:         "ch",
+			IssuingClientID: "other",
+			SequenceNumber:  seqn,
+			Payload:         "hey",
+			PublishMetaData: publishMetadata{PublishTimetoken: "15191382475826879"},
+		})
+		processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+			Shard:           "1",
+			Channel:         "ch",
+			IssuingClientID: pn.Config.UUID,
+			SequenceNumber:  seqn,
+			Payload:         "hey",
+			PublishMetaData: publishMetadata{PublishTimetoken: "15191382475826880"},
+		})
+	}
+
+	resp, _, err := pn.Publish().
+		Channel("ch").
+		Message("hey").
+		ConfirmDelivery(5 * time.Second).
+		Execute()
+
+	assert.Nil(err)
+	assert.True(resp.Confirmed)
+	assert.Equal(int64(15191382475826880), resp.Timestamp)
+	assert.Equal(1, resp.SequenceNumber)
+	assert.Equal(0, len(pn.deliveries.waiters))
+}
+
+func TestPublishConfirmDeliveryTimeout(t *testing.T) {
+	assert := assert.New(t)
+	pn := newDeliveryTestPubNub(&echoTransport{})
+
+	resp, status, err := pn.Publish().
+		Channel("ch").
+		Message("hey").
+		ConfirmDelivery(50 * time.Millisecond).
+		Execute()
+
+	assert.False(resp.Confirmed)
+	assert.Equal(int64(15191382475826880), resp.Timestamp)
+	assert.Equal(PNTimeoutCategory, status.Category)
+
+	e, ok := err.(*pnerr.DeliveryNotConfirmedError)
+	assert.True(ok)
+	assert.Equal("ch", e.Channel)
+	assert.Equal(int64(15191382475826880), e.Timetoken)
+}
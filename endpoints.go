@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pubnub/go/pnerr"
@@ -61,15 +62,20 @@ func buildURL(o endpointOpts) (*url.URL, error) {
 		return &url.URL{}, err
 	}
 
-	if o.config().FilterExpression != "" {
-		query.Set("filter-expr", o.config().FilterExpression)
-	}
-
-	if v := o.config().AuthKey; v != "" && query.Get("auth") == "" {
+	config := o.config()
+	if v := config.currentAuthKey(); v != "" && query.Get("auth") == "" {
 		query.Set("auth", v)
 	}
 
-	if o.config().SecretKey != "" {
+	if o.config().SecretKey != "" && usesSignatureV2(o.operationType()) {
+		timestamp := time.Now().Unix()
+		query.Set("timestamp", strconv.Itoa(int(timestamp)))
+
+		signature, err = signatureV2(o, path, query)
+		if err != nil {
+			return &url.URL{}, err
+		}
+	} else if o.config().SecretKey != "" {
 		timestamp := time.Now().Unix()
 		query.Set("timestamp", strconv.Itoa(int(timestamp)))
 
@@ -78,6 +84,8 @@ func buildURL(o endpointOpts) (*url.URL, error) {
 		if o.operationType() == PNAccessManagerGrant ||
 			o.operationType() == PNAccessManagerRevoke {
 			signedInput += "grant\n"
+		} else if o.operationType() == PNAccessManagerAudit {
+			signedInput += "audit\n"
 		} else {
 			signedInput += fmt.Sprintf("%s\n", path)
 		}
@@ -136,6 +144,41 @@ func buildURL(o endpointOpts) (*url.URL, error) {
 	return retURL, nil
 }
 
+// usesSignatureV2 returns true if the requests of the operation are signed
+// with signatureV2.
+func usesSignatureV2(t OperationType) bool {
+	switch t {
+	case PNAccessManagerGrantToken,
+		PNSetUUIDMetadataOperation, PNGetUUIDMetadataOperation,
+		PNRemoveUUIDMetadataOperation, PNGetAllUUIDMetadataOperation,
+		PNSetChannelMetadataOperation, PNGetChannelMetadataOperation,
+		PNRemoveChannelMetadataOperation, PNGetAllChannelMetadataOperation,
+		PNGetMembershipsOperation, PNSetMembershipsOperation, PNRemoveMembershipsOperation,
+		PNGetChannelMembersOperation, PNSetChannelMembersOperation, PNRemoveChannelMembersOperation:
+		return true
+	}
+
+	return false
+}
+
+// signatureV2 signs the method, the path, the query and the body of the request.
+func signatureV2(o endpointOpts, path string, query *url.Values) (string, error) {
+	body, err := o.buildBody()
+	if err != nil {
+		return "", err
+	}
+
+	signedInput := o.httpMethod() + "\n" +
+		o.config().PublishKey + "\n" +
+		path + "\n" +
+		utils.PreparePamParams(query) + "\n" +
+		string(body)
+
+	signature := utils.GetHmacSha256(o.config().SecretKey, signedInput)
+
+	return "v2." + strings.TrimRight(signature, "="), nil
+}
+
 func newValidationError(o endpointOpts, msg string) error {
 	return pnerr.NewValidationError(string(o.operationType()), msg)
 }
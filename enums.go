@@ -14,6 +14,9 @@ type ReconnectionPolicy int
 // PNPushType is used as an enum to catgorize the available Push Types
 type PNPushType int
 
+// PNPushEnvironment is the APNS environment of the devices registered with PNPushTypeAPNS2
+type PNPushEnvironment string
+
 const (
 	// PNNonePolicy is to be used when selecting the no Reconnection Policy
 	// ReconnectionPolicy is set in the config.
@@ -108,6 +111,46 @@ const (
 	PNDeleteMessagesOperation
 	// PNMessageCountsOperation is the enum used for History with messages operation.
 	PNMessageCountsOperation
+	// PNAccessManagerAudit is the enum used for the Access Manager Audit operation.
+	PNAccessManagerAudit
+	// PNAccessManagerGrantToken is the enum used for the Access Manager Grant Token operation.
+	PNAccessManagerGrantToken
+	// PNAddMessageActionOperation is the enum used for the Add Message Action operation.
+	PNAddMessageActionOperation
+	// PNGetMessageActionsOperation is the enum used for the Get Message Actions operation.
+	PNGetMessageActionsOperation
+	// PNRemoveMessageActionOperation is the enum used for the Remove Message Action operation.
+	PNRemoveMessageActionOperation
+	// PNSignalOperation is the enum used for the Signal operation.
+	PNSignalOperation
+	// PNSetUUIDMetadataOperation is the enum used for the Set UUID Metadata operation.
+	PNSetUUIDMetadataOperation
+	// PNGetUUIDMetadataOperation is the enum used for the Get UUID Metadata operation.
+	PNGetUUIDMetadataOperation
+	// PNRemoveUUIDMetadataOperation is the enum used for the Remove UUID Metadata operation.
+	PNRemoveUUIDMetadataOperation
+	// PNGetAllUUIDMetadataOperation is the enum used for the Get All UUID Metadata operation.
+	PNGetAllUUIDMetadataOperation
+	// PNSetChannelMetadataOperation is the enum used for the Set Channel Metadata operation.
+	PNSetChannelMetadataOperation
+	// PNGetChannelMetadataOperation is the enum used for the Get Channel Metadata operation.
+	PNGetChannelMetadataOperation
+	// PNRemoveChannelMetadataOperation is the enum used for the Remove Channel Metadata operation.
+	PNRemoveChannelMetadataOperation
+	// PNGetAllChannelMetadataOperation is the enum used for the Get All Channel Metadata operation.
+	PNGetAllChannelMetadataOperation
+	// PNGetMembershipsOperation is the enum used for the Get Memberships operation.
+	PNGetMembershipsOperation
+	// PNSetMembershipsOperation is the enum used for the Set Memberships operation.
+	PNSetMembershipsOperation
+	// PNRemoveMembershipsOperation is the enum used for the Remove Memberships operation.
+	PNRemoveMembershipsOperation
+	// PNGetChannelMembersOperation is the enum used for the Get Channel Members operation.
+	PNGetChannelMembersOperation
+	// PNSetChannelMembersOperation is the enum used for the Set Channel Members operation.
+	PNSetChannelMembersOperation
+	// PNRemoveChannelMembersOperation is the enum used for the Remove Channel Members operation.
+	PNRemoveChannelMembersOperation
 )
 
 const (
@@ -119,6 +162,17 @@ const (
 	PNPushTypeAPNS
 	// PNPushTypeMPNS is used as an enum to for selecting `MPNS` as the PNPushType
 	PNPushTypeMPNS
+	// PNPushTypeAPNS2 is used as an enum to for selecting `APNS2` (APNS over HTTP/2) as the PNPushType
+	PNPushTypeAPNS2
+	// PNPushTypeFCM is used as an enum to for selecting `FCM` as the PNPushType
+	PNPushTypeFCM
+)
+
+const (
+	// PNPushEnvironmentDevelopment is the APNS development environment, the default one
+	PNPushEnvironmentDevelopment PNPushEnvironment = "development"
+	// PNPushEnvironmentProduction is the APNS production environment
+	PNPushEnvironmentProduction PNPushEnvironment = "production"
 )
 
 func (p PNPushType) String() string {
@@ -132,6 +186,12 @@ func (p PNPushType) String() string {
 	case PNPushTypeMPNS:
 		return "mpns"
 
+	case PNPushTypeAPNS2:
+		return "apns2"
+
+	case PNPushTypeFCM:
+		return "fcm"
+
 	default:
 		return "none"
 
@@ -275,6 +335,66 @@ func (t OperationType) String() string {
 	case PNAccessManagerRevoke:
 		return "Revoke"
 
+	case PNAccessManagerAudit:
+		return "Audit"
+
+	case PNAccessManagerGrantToken:
+		return "Grant Token"
+
+	case PNAddMessageActionOperation:
+		return "Add Message Action"
+
+	case PNGetMessageActionsOperation:
+		return "Get Message Actions"
+
+	case PNRemoveMessageActionOperation:
+		return "Remove Message Action"
+
+	case PNSignalOperation:
+		return "Signal"
+
+	case PNSetUUIDMetadataOperation:
+		return "Set UUID Metadata"
+
+	case PNGetUUIDMetadataOperation:
+		return "Get UUID Metadata"
+
+	case PNRemoveUUIDMetadataOperation:
+		return "Remove UUID Metadata"
+
+	case PNGetAllUUIDMetadataOperation:
+		return "Get All UUID Metadata"
+
+	case PNSetChannelMetadataOperation:
+		return "Set Channel Metadata"
+
+	case PNGetChannelMetadataOperation:
+		return "Get Channel Metadata"
+
+	case PNRemoveChannelMetadataOperation:
+		return "Remove Channel Metadata"
+
+	case PNGetAllChannelMetadataOperation:
+		return "Get All Channel Metadata"
+
+	case PNGetMembershipsOperation:
+		return "Get Memberships"
+
+	case PNSetMembershipsOperation:
+		return "Set Memberships"
+
+	case PNRemoveMembershipsOperation:
+		return "Remove Memberships"
+
+	case PNGetChannelMembersOperation:
+		return "Get Channel Members"
+
+	case PNSetChannelMembersOperation:
+		return "Set Channel Members"
+
+	case PNRemoveChannelMembersOperation:
+		return "Remove Channel Members"
+
 	case PNDeleteMessagesOperation:
 		return "Delete messages"
 
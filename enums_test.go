@@ -18,6 +18,8 @@ func TestPushString(t *testing.T) {
 	assert.Equal("mpns", pushMPNS.String())
 	assert.Equal("gcm", pushGCM.String())
 	assert.Equal("none", pushNONE.String())
+	assert.Equal("apns2", PNPushTypeAPNS2.String())
+	assert.Equal("fcm", PNPushTypeFCM.String())
 }
 
 func TestStatusCategoryString(t *testing.T) {
@@ -62,5 +64,25 @@ func TestOperationTypeString(t *testing.T) {
 	assert.Equal("Time", PNTimeOperation.String())
 	assert.Equal("Grant", PNAccessManagerGrant.String())
 	assert.Equal("Revoke", PNAccessManagerRevoke.String())
+	assert.Equal("Audit", PNAccessManagerAudit.String())
+	assert.Equal("Grant Token", PNAccessManagerGrantToken.String())
+	assert.Equal("Add Message Action", PNAddMessageActionOperation.String())
+	assert.Equal("Get Message Actions", PNGetMessageActionsOperation.String())
+	assert.Equal("Remove Message Action", PNRemoveMessageActionOperation.String())
+	assert.Equal("Signal", PNSignalOperation.String())
+	assert.Equal("Set UUID Metadata", PNSetUUIDMetadataOperation.String())
+	assert.Equal("Get UUID Metadata", PNGetUUIDMetadataOperation.String())
+	assert.Equal("Remove UUID Metadata", PNRemoveUUIDMetadataOperation.String())
+	assert.Equal("Get All UUID Metadata", PNGetAllUUIDMetadataOperation.String())
+	assert.Equal("Set Channel Metadata", PNSetChannelMetadataOperation.String())
+	assert.Equal("Get Channel Metadata", PNGetChannelMetadataOperation.String())
+	assert.Equal("Remove Channel Metadata", PNRemoveChannelMetadataOperation.String())
+	assert.Equal("Get All Channel Metadata", PNGetAllChannelMetadataOperation.String())
+	assert.Equal("Get Memberships", PNGetMembershipsOperation.String())
+	assert.Equal("Set Memberships", PNSetMembershipsOperation.String())
+	assert.Equal("Remove Memberships", PNRemoveMembershipsOperation.String())
+	assert.Equal("Get Channel Members", PNGetChannelMembersOperation.String())
+	assert.Equal("Set Channel Members", PNSetChannelMembersOperation.String())
+	assert.Equal("Remove Channel Members", PNRemoveChannelMembersOperation.String())
 	assert.Equal("Delete messages", PNDeleteMessagesOperation.String())
 }
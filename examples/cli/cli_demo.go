@@ -68,6 +68,8 @@ func connect() {
 
 	// for subscribe event
 	listener := pubnub.NewListener()
+	signalListener := pubnub.NewSignalListener()
+	objectsListener := pubnub.NewObjectsListener()
 
 	go func() {
 		for {
@@ -92,6 +94,23 @@ func connect() {
 				fmt.Println(fmt.Sprintf("%s msg.Timetoken: %d", outputPrefix, msg.Timetoken))
 				fmt.Println("")
 				fmt.Println(fmt.Sprintf("%s", outputSuffix))
+			case signal := <-signalListener.Signal:
+				fmt.Print(fmt.Sprintf("%s Subscribe Response:", outputPrefix))
+				fmt.Println(" --- SIGNAL: ")
+				fmt.Println(fmt.Sprintf("%s signal.Channel: %s", outputPrefix, signal.Channel))
+				fmt.Println(fmt.Sprintf("%s signal.Message: %s", outputPrefix, signal.Message))
+				fmt.Println(fmt.Sprintf("%s signal.Publisher: %s", outputPrefix, signal.Publisher))
+				fmt.Println(fmt.Sprintf("%s signal.Timetoken: %d", outputPrefix, signal.Timetoken))
+				fmt.Println("")
+				fmt.Println(fmt.Sprintf("%s", outputSuffix))
+			case event := <-objectsListener.Objects:
+				fmt.Print(fmt.Sprintf("%s Subscribe Response:", outputPrefix))
+				fmt.Println(" --- OBJECTS: ")
+				fmt.Println(fmt.Sprintf("%s event.Event: %s", outputPrefix, event.Event))
+				fmt.Println(fmt.Sprintf("%s event.Type: %s", outputPrefix, event.Type))
+				fmt.Println(fmt.Sprintf("%s event.Channel: %s", outputPrefix, event.Channel))
+				fmt.Println("")
+				fmt.Println(fmt.Sprintf("%s", outputSuffix))
 			case presence := <-listener.Presence:
 				fmt.Print(fmt.Sprintf("%s Subscribe Response:", outputPrefix))
 				fmt.Println(" --- PRESENCE: ")
@@ -103,6 +122,8 @@ func connect() {
 	}()
 
 	pn.AddListener(listener)
+	pn.AddSignalListener(signalListener)
+	pn.AddObjectsListener(objectsListener)
 	showHelp()
 
 	/*config2 := pubnub.NewConfig()
@@ -152,6 +173,7 @@ func showHelp() {
 	showUnsubscribeHelp()
 	showFetchHelp()
 	showFireHelp()
+	showSignalHelp()
 	showSetStateHelp()
 	showGetStateHelp()
 	showAddToCgHelp()
@@ -202,6 +224,11 @@ func showFireHelp() {
 	fmt.Println("	fire false \"my-message\" my-channel")
 }
 
+func showSignalHelp() {
+	fmt.Println(" SIGNAL EXAMPLE: ")
+	fmt.Println("	signal \"my-signal\" my-channel")
+}
+
 func showPublishHelp() {
 	fmt.Println(" PUBLISH EXAMPLE: ")
 	fmt.Println("	pub usePost store noreplicate \"my-message\" my-channel")
@@ -306,6 +333,8 @@ func readCommand(cmd string) {
 		publishRequest(command[1:])
 	case "fire":
 		fireRequest(command[1:])
+	case "signal":
+		signalRequest(command[1:])
 	case "sub":
 		subscribeRequest(command[1:])
 	case "time":
@@ -1108,6 +1137,42 @@ func fireRequest(args []string) {
 	}
 }
 
+func signalRequest(args []string) {
+	if len(args) < 2 {
+		showErr("channels or message not found")
+		showSignalHelp()
+		return
+	}
+
+	message := args[0]
+	reg := regexp.MustCompile(`"([^"]*)"`)
+	res := reg.ReplaceAllString(message, "${1}")
+
+	if res == "" {
+		showErr("Empty message!")
+		return
+	}
+
+	channels := strings.Split(args[1], ",")
+
+	for _, ch := range channels {
+		fmt.Println(fmt.Sprintf("%s Sending signal to channel: %s", outputPrefix, ch))
+		res, status, err := pn.Signal().
+			Channel(ch).
+			Message(res).
+			Execute()
+
+		if err != nil {
+			showErr("Error while sending signal: " + err.Error())
+		}
+
+		fmt.Println(fmt.Sprintf("%s Signal Response:", outputPrefix))
+
+		fmt.Println(fmt.Sprintf("%s %s", res, status))
+		fmt.Println(fmt.Sprintf("%s", outputSuffix))
+	}
+}
+
 func unsubscribeRequest(args []string) {
 	if len(args) == 0 {
 		showUnsubscribeHelp()
@@ -2,61 +2,12 @@ package main
 
 import (
 	"fmt"
-	"time"
 
 	pubnub "github.com/pubnub/go"
 )
 
-type Lists struct {
-	message_list map[string]interface{}
-	deleted_ids  map[string]interface{}
-	message_ids  map[string]interface{}
-}
-
 var my_channel string
 
-func displayMessages(message interface{}, channel string, lists *Lists) {
-	if _, ok := lists.message_list[channel]; !ok {
-		lists.message_list[channel] = map[string]interface{}{}
-	}
-
-	if _, ok := lists.message_ids[channel]; !ok {
-		lists.message_ids[channel] = map[string]interface{}{}
-	}
-
-	if _, ok := lists.deleted_ids[channel]; !ok {
-		lists.deleted_ids[channel] = map[string]interface{}{}
-	}
-
-	messageObj := map[string]interface{}{}
-
-	if m, ok := message.(map[string]interface{}); ok {
-		if message_id, ok := m["message_id"].(string); ok {
-			if m_ids, ok := lists.message_ids[channel].(map[string]interface{}); ok {
-				if _, ok := m_ids[message_id]; !ok {
-					lists.message_ids[channel] = message_id
-					if m_id, ok := m["message_id"].(string); ok {
-						messageObj[m_id] = message
-					}
-					lists.message_list[channel] = messageObj
-				} else {
-					if del, ok := m["deleted"].(bool); ok {
-						if del {
-							lists.deleted_ids[channel] = m["message_id"]
-							delete(lists.message_list, channel)
-						} else {
-							if m_id, ok := m["message_id"].(string); ok {
-								messageObj[m_id] = message
-							}
-							lists.message_list[channel] = messageObj
-						}
-					}
-				}
-			}
-		}
-	}
-}
-
 func main() {
 	config := pubnub.NewConfig()
 	config.PublishKey = "pub-c-1bd448ed-05ba-4dbc-81a5-7d6ff5c6e2bb"
@@ -64,12 +15,10 @@ func main() {
 
 	pn := pubnub.NewPubNub(config)
 	my_channel = "jasdeep-status"
-	lists := Lists{
-		map[string]interface{}{},
-		map[string]interface{}{},
-		map[string]interface{}{},
-	}
 
+	// The folder keeps the latest version of each message, the edits and
+	// the deletions are applied to the original messages
+	folder := pubnub.NewMessageFolder()
 	listener := pubnub.NewListener()
 
 	go func() {
@@ -78,7 +27,9 @@ func main() {
 			case status := <-listener.Status:
 				fmt.Println(status)
 			case message := <-listener.Message:
-				displayMessages(message.Message, message.Channel, &lists)
+				if visible, changed := folder.AddMessage(message); changed {
+					fmt.Println(visible.Timetoken, visible.Message, visible.Deleted)
+				}
 			case <-listener.Presence:
 			}
 		}
@@ -91,39 +42,42 @@ func main() {
 		Execute()
 
 	data := map[string]interface{}{}
-
-	data["message_id"] = "10001"
-	data["channel"] = "jasdeep-status"
-	data["original_timetoken"] = time.Now().Unix()
 	data["user"] = "jasdeep"
 	data["status"] = "Writing up design patterns..."
-	data["usecase"] = "update"
-	data["deleted"] = false
-	data["is_update"] = true
 
 	res, status, err := pn.Publish().
 		Message(data).
-		Channel("jasdeep-status").
+		Channel(my_channel).
 		Execute()
 
 	fmt.Println(res, status, err)
 
-	data["is_update"] = false
-	data["deleted"] = true
+	data["status"] = "Design patterns written"
 
-	res, status, err = pn.Publish().
+	_, status, err = pn.EditMessage().
+		Channel(my_channel).
+		Timetoken(res.Timestamp).
 		Message(data).
-		Channel("jasdeep-status-UPDATES").
 		Execute()
 
-	fmt.Println(res, status, err)
+	fmt.Println(status, err)
 
-	data["is_update"] = true
+	_, status, err = pn.SoftDeleteMessage().
+		Channel(my_channel).
+		Timetoken(res.Timestamp).
+		Execute()
 
-	res, status, err = pn.Publish().
-		Message(data).
-		Channel("jasdeep-status").
+	fmt.Println(status, err)
+
+	// The history contains the original messages and the records
+	history, _, err := pn.History().
+		Channel(my_channel).
+		IncludeTimetoken(true).
 		Execute()
 
-	fmt.Println(res, status, err)
+	if err == nil {
+		for _, visible := range pubnub.FoldHistory(my_channel, history.Messages) {
+			fmt.Println(visible.Timetoken, visible.Message)
+		}
+	}
 }
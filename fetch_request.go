@@ -17,7 +17,9 @@ import (
 var emptyFetchResp *FetchResponse
 
 const fetchPath = "/v3/history/sub-key/%s/channel/%s"
+const fetchWithActionsPath = "/v3/history-with-actions/sub-key/%s/channel/%s"
 const maxCountFetch = 25
+const maxCountFetchWithActions = 100
 
 type fetchBuilder struct {
 	opts *fetchOpts
@@ -77,6 +79,31 @@ func (b *fetchBuilder) Reverse(r bool) *fetchBuilder {
 	return b
 }
 
+// IncludeMeta tells the server to send the meta published with each message.
+func (b *fetchBuilder) IncludeMeta(withMeta bool) *fetchBuilder {
+	b.opts.IncludeMeta = withMeta
+	return b
+}
+
+// IncludeUUID tells the server to send the UUID of the publisher of each message.
+func (b *fetchBuilder) IncludeUUID(withUUID bool) *fetchBuilder {
+	b.opts.IncludeUUID = withUUID
+	return b
+}
+
+// IncludeMessageType tells the server to send the type of each message.
+func (b *fetchBuilder) IncludeMessageType(withMessageType bool) *fetchBuilder {
+	b.opts.IncludeMessageType = withMessageType
+	return b
+}
+
+// IncludeMessageActions tells the server to send the actions added to each message,
+// only a single channel can be fetched with the actions.
+func (b *fetchBuilder) IncludeMessageActions(withMessageActions bool) *fetchBuilder {
+	b.opts.IncludeMessageActions = withMessageActions
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *fetchBuilder) QueryParam(queryParam map[string]string) *fetchBuilder {
 	b.opts.QueryParam = queryParam
@@ -100,6 +127,14 @@ func (b *fetchBuilder) Execute() (*FetchResponse, StatusResponse, error) {
 	return newFetchResponse(rawJSON, b.opts, status)
 }
 
+// ExecuteAsync runs the Fetch request asynchronously, the result of the Future is a *FetchResponse.
+func (b *fetchBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type fetchOpts struct {
 	pubnub *PubNub
 
@@ -115,8 +150,14 @@ type fetchOpts struct {
 	Reverse bool
 
 	// default: false
-	IncludeTimetoken bool
-	QueryParam       map[string]string
+	IncludeTimetoken   bool
+	IncludeMeta        bool
+	IncludeUUID        bool
+	IncludeMessageType bool
+	QueryParam         map[string]string
+
+	// default: false
+	IncludeMessageActions bool
 
 	// nil hacks
 	setStart bool
@@ -148,12 +189,22 @@ func (o *fetchOpts) validate() error {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if o.IncludeMessageActions && len(o.Channels) > 1 {
+		return newValidationError(o, "Only one channel can be fetched with the message actions")
+	}
+
 	return nil
 }
 
 func (o *fetchOpts) buildPath() (string, error) {
 	channels := utils.JoinChannels(o.Channels)
 
+	if o.IncludeMessageActions {
+		return fmt.Sprintf(fetchWithActionsPath,
+			o.pubnub.Config.SubscribeKey,
+			channels), nil
+	}
+
 	return fmt.Sprintf(fetchPath,
 		o.pubnub.Config.SubscribeKey,
 		channels), nil
@@ -170,13 +221,19 @@ func (o *fetchOpts) buildQuery() (*url.Values, error) {
 		q.Set("end", strconv.FormatInt(o.End, 10))
 	}
 
-	if o.Count > 0 && o.Count <= maxCountFetch {
+	maxCount := maxCountFetch
+	if o.IncludeMessageActions {
+		maxCount = maxCountFetchWithActions
+	}
+
+	if o.Count > 0 && o.Count <= maxCount {
 		q.Set("max", strconv.Itoa(o.Count))
 	} else {
-		q.Set("max", strconv.Itoa(maxCountFetch))
+		q.Set("max", strconv.Itoa(maxCount))
 	}
 
 	q.Set("reverse", strconv.FormatBool(o.Reverse))
+	setHistoryIncludes(q, o.IncludeMeta, o.IncludeUUID, o.IncludeMessageType)
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -230,11 +287,22 @@ func (o *fetchOpts) fetchMessages(channels map[string]interface{}) map[string][]
 
 			for _, val := range histResponseMap {
 				if histResponse, ok3 := val.(map[string]interface{}); ok3 {
-					msg, _ := parseCipherInterface(histResponse["message"], o.pubnub.Config)
+					msg, _ := parseChannelCipherInterface(histResponse["message"], o.pubnub.Config, channel)
 
 					histItem := FetchResponseItem{
-						Message:   msg,
-						Timetoken: histResponse["timetoken"].(string),
+						Message:     msg,
+						Timetoken:   parseHistoryTimetoken(histResponse["timetoken"]),
+						Meta:        histResponse["meta"],
+						MessageType: parseHistoryMessageType(histResponse["message_type"]),
+					}
+					if _, ok := histResponse["timetoken"].(float64); ok {
+						o.pubnub.Config.Log.Println("Fetch: the numeric timetoken can't be read without loss", histResponse["timetoken"])
+					}
+					if uuid, ok := histResponse["uuid"].(string); ok {
+						histItem.UUID = uuid
+					}
+					if actions, ok := histResponse["actions"].(map[string]interface{}); ok {
+						histItem.MessageActions = parseHistoryMessageActions(actions)
 					}
 					items[count] = histItem
 					o.pubnub.Config.Log.Printf("Channel:%s, count:%d %d\n", channel, count, len(items))
@@ -286,7 +354,94 @@ func newFetchResponse(jsonBytes []byte, o *fetchOpts,
 }
 
 // FetchResponseItem contains the message and the associated timetoken.
+// Meta, UUID and MessageType are set when requested with IncludeMeta,
+// IncludeUUID and IncludeMessageType.
 type FetchResponseItem struct {
-	Message   interface{}
-	Timetoken string
+	Message     interface{}
+	Timetoken   int64
+	Meta        interface{}
+	UUID        string
+	MessageType int
+
+	// MessageActions are set when requested with IncludeMessageActions,
+	// the actions are keyed by type and then by value.
+	MessageActions map[string]map[string][]PNHistoryMessageAction
+}
+
+// PNHistoryMessageAction is an action added to a fetched message.
+type PNHistoryMessageAction struct {
+	UUID            string
+	ActionTimetoken int64
+}
+
+func parseHistoryMessageActions(actions map[string]interface{}) map[string]map[string][]PNHistoryMessageAction {
+	messageActions := make(map[string]map[string][]PNHistoryMessageAction, len(actions))
+
+	for actionType, values := range actions {
+		valuesMap, ok := values.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		messageActions[actionType] = make(map[string][]PNHistoryMessageAction, len(valuesMap))
+		for value, items := range valuesMap {
+			list, _ := items.([]interface{})
+			parsed := make([]PNHistoryMessageAction, 0, len(list))
+			for _, item := range list {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					action := PNHistoryMessageAction{
+						ActionTimetoken: parseHistoryTimetoken(itemMap["actionTimetoken"]),
+					}
+					action.UUID, _ = itemMap["uuid"].(string)
+					parsed = append(parsed, action)
+				}
+			}
+			messageActions[actionType][value] = parsed
+		}
+	}
+
+	return messageActions
+}
+
+// setHistoryIncludes sets the query params of the optional fields of the History and Fetch items.
+func setHistoryIncludes(q *url.Values, meta, uuid, messageType bool) {
+	if meta {
+		q.Set("include_meta", "true")
+	}
+
+	if uuid {
+		q.Set("include_uuid", "true")
+	}
+
+	if messageType {
+		q.Set("include_message_type", "true")
+	}
+}
+
+// parseHistoryTimetoken reads a timetoken sent as a string or decoded as a
+// json.Number. A timetoken decoded as a float64 has lost its last digits, 0
+// is returned.
+func parseHistoryTimetoken(value interface{}) int64 {
+	switch v := value.(type) {
+	case string:
+		timetoken, _ := strconv.ParseInt(v, 10, 64)
+		return timetoken
+	case json.Number:
+		timetoken, _ := v.Int64()
+		return timetoken
+	}
+	return 0
+}
+
+// parseHistoryMessageType reads a message type sent as a string or as a number,
+// the type of the regular messages is sent as null.
+func parseHistoryMessageType(value interface{}) int {
+	switch v := value.(type) {
+	case string:
+		messageType, _ := strconv.Atoi(v)
+		return messageType
+	case float64:
+		return int(v)
+	}
+	return 0
 }
@@ -1,6 +1,7 @@
 package pubnub
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -140,14 +141,14 @@ func TestFetchResponseWithoutCipher(t *testing.T) {
 	respMyChannel := resp.Messages["my-channel"]
 
 	assert.Equal("nyQDWnNPc1ryr5RgzVCKWw==", respTest[0].Message)
-	assert.Equal("15229448184080121", respTest[0].Timetoken)
+	assert.Equal(int64(15229448184080121), respTest[0].Timetoken)
 
 	assert.Equal("nyQDWnNPc1ryr5RgzVCKWw==", respMyChannel[0].Message)
-	assert.Equal("15229448086016618", respMyChannel[0].Timetoken)
+	assert.Equal(int64(15229448086016618), respMyChannel[0].Timetoken)
 	assert.Equal("nyQDWnNPc1ryr5RgzVCKWw==", respMyChannel[1].Message)
-	assert.Equal("15229448126438499", respMyChannel[1].Timetoken)
+	assert.Equal(int64(15229448126438499), respMyChannel[1].Timetoken)
 	assert.Equal("my-message", respMyChannel[2].Message)
-	assert.Equal("15229450607090584", respMyChannel[2].Timetoken)
+	assert.Equal(int64(15229450607090584), respMyChannel[2].Timetoken)
 
 }
 
@@ -163,14 +164,14 @@ func TestFetchResponseWithCipher(t *testing.T) {
 	respMyChannel := resp.Messages["my-channel"]
 
 	assert.Equal("yay!", respTest[0].Message)
-	assert.Equal("15229448184080121", respTest[0].Timetoken)
+	assert.Equal(int64(15229448184080121), respTest[0].Timetoken)
 
 	assert.Equal("yay!", respMyChannel[0].Message)
-	assert.Equal("15229448086016618", respMyChannel[0].Timetoken)
+	assert.Equal(int64(15229448086016618), respMyChannel[0].Timetoken)
 	assert.Equal("yay!", respMyChannel[1].Message)
-	assert.Equal("15229448126438499", respMyChannel[1].Timetoken)
+	assert.Equal(int64(15229448126438499), respMyChannel[1].Timetoken)
 	assert.Equal("my-message", respMyChannel[2].Message)
-	assert.Equal("15229450607090584", respMyChannel[2].Timetoken)
+	assert.Equal(int64(15229450607090584), respMyChannel[2].Timetoken)
 
 }
 
@@ -186,7 +187,7 @@ func TestFetchResponseWithCipherInterface(t *testing.T) {
 	respMyChannel := resp.Messages["my-channel"]
 
 	assert.Equal("{\"not_other\":\"1234\", \"pn_other\":\"yay!\"}", respTest[0].Message)
-	assert.Equal("15229448184080121", respTest[0].Timetoken)
+	assert.Equal(int64(15229448184080121), respTest[0].Timetoken)
 
 	data := respMyChannel[0].Message
 	switch v := data.(type) {
@@ -202,16 +203,16 @@ func TestFetchResponseWithCipherInterface(t *testing.T) {
 		break
 	}
 
-	assert.Equal("15229448086016618", respMyChannel[0].Timetoken)
+	assert.Equal(int64(15229448086016618), respMyChannel[0].Timetoken)
 	if testMap, ok := respMyChannel[1].Message.(map[string]interface{}); !ok {
 		assert.Fail("respMyChannel[1].Message ! map[string]interface{}")
 	} else {
 		assert.Equal("1234", testMap["not_other"])
 		assert.Equal("yay!", testMap["pn_other"])
 	}
-	assert.Equal("15229448126438499", respMyChannel[1].Timetoken)
+	assert.Equal(int64(15229448126438499), respMyChannel[1].Timetoken)
 	assert.Equal("my-message", respMyChannel[2].Message)
-	assert.Equal("15229450607090584", respMyChannel[2].Timetoken)
+	assert.Equal(int64(15229450607090584), respMyChannel[2].Timetoken)
 
 }
 
@@ -236,7 +237,7 @@ func TestFetchResponseWithCipherInterfacePNOtherDisabled(t *testing.T) {
 	respMyChannel := resp.Messages["my-channel"]
 
 	assert.Equal("{\"not_other\":\"1234\", \"pn_other\":\"yay!\"}", respTest[0].Message)
-	assert.Equal("15229448184080121", respTest[0].Timetoken)
+	assert.Equal(int64(15229448184080121), respTest[0].Timetoken)
 
 	data := respMyChannel[0].Message
 	switch v := data.(type) {
@@ -252,16 +253,16 @@ func TestFetchResponseWithCipherInterfacePNOtherDisabled(t *testing.T) {
 		break
 	}
 
-	assert.Equal("15229448086016618", respMyChannel[0].Timetoken)
+	assert.Equal(int64(15229448086016618), respMyChannel[0].Timetoken)
 	if testMap, ok := respMyChannel[1].Message.(map[string]interface{}); !ok {
 		assert.Fail("respMyChannel[1].Message ! map[string]interface{}")
 	} else {
 		assert.Equal("1234", testMap["not_other"])
 		assert.Equal("yay!", testMap["pn_other"])
 	}
-	assert.Equal("15229448126438499", respMyChannel[1].Timetoken)
+	assert.Equal(int64(15229448126438499), respMyChannel[1].Timetoken)
 	assert.Equal("my-message", respMyChannel[2].Message)
-	assert.Equal("15229450607090584", respMyChannel[2].Timetoken)
+	assert.Equal(int64(15229450607090584), respMyChannel[2].Timetoken)
 	pn.Config.CipherKey = ""
 
 }
@@ -300,3 +301,83 @@ func TestNewFetchResponseValueError(t *testing.T) {
 	_, _, err := newFetchResponse(jsonBytes, opts, StatusResponse{})
 	assert.Equal("pubnub/parsing: Error unmarshalling response: {s}", err.Error())
 }
+
+func TestFetchRequestIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newFetchBuilder(pubnub).
+		Channels([]string{"ch"}).
+		IncludeMeta(true).
+		IncludeUUID(true).
+		IncludeMessageType(true)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("true", query.Get("include_meta"))
+	assert.Equal("true", query.Get("include_uuid"))
+	assert.Equal("true", query.Get("include_message_type"))
+
+	query, err = newFetchBuilder(pubnub).Channels([]string{"ch"}).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("", query.Get("include_meta"))
+}
+
+func TestFetchResponseWithIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`{"status": 200, "error": false, "error_message": "", "channels": {"ch":[{"message":"hi","timetoken":"15229448184080121","meta":{"lang":"en"},"uuid":"publisher","message_type":null},{"message":"file","timetoken":"15229448184080122","meta":"","uuid":"publisher","message_type":4}]}}`)
+
+	resp, _, err := newFetchResponse(jsonString, initFetchOpts(""), fakeResponseState)
+	assert.Nil(err)
+
+	items := resp.Messages["ch"]
+	assert.Equal(int64(15229448184080121), items[0].Timetoken)
+	assert.Equal(map[string]interface{}{"lang": "en"}, items[0].Meta)
+	assert.Equal("publisher", items[0].UUID)
+	assert.Equal(0, items[0].MessageType)
+	assert.Equal(4, items[1].MessageType)
+}
+
+func TestFetchRequestWithMessageActions(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newFetchBuilder(pubnub).
+		Channels([]string{"ch"}).
+		Count(50).
+		IncludeMessageActions(true)
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/v3/history-with-actions/sub-key/%s/channel/ch", pubnub.Config.SubscribeKey), path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("50", query.Get("max"))
+
+	o.Channels([]string{"ch1", "ch2"})
+	assert.Contains(o.opts.validate().Error(), "Only one channel can be fetched with the message actions")
+}
+
+func TestFetchResponseWithMessageActions(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`{"status": 200, "error": false, "error_message": "", "channels": {"ch":[{"message":"hi","timetoken":"15610547826969050","actions":{"reaction":{"smiley_face":[{"uuid":"user-456","actionTimetoken":"15610547826970050"}]}}}]}}`)
+
+	resp, _, err := newFetchResponse(jsonString, initFetchOpts(""), fakeResponseState)
+	assert.Nil(err)
+
+	actions := resp.Messages["ch"][0].MessageActions
+	assert.Equal([]PNHistoryMessageAction{
+		{UUID: "user-456", ActionTimetoken: 15610547826970050},
+	}, actions["reaction"]["smiley_face"])
+}
+
+func TestParseHistoryTimetoken(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(int64(15191382475826879), parseHistoryTimetoken("15191382475826879"))
+	assert.Equal(int64(15191382475826879), parseHistoryTimetoken(json.Number("15191382475826879")))
+	// the float64 can't hold the 17 digits
+	assert.Equal(int64(0), parseHistoryTimetoken(float64(15191382475826879)))
+	assert.Equal(int64(0), parseHistoryTimetoken(nil))
+}
@@ -25,6 +25,8 @@ type fireOpts struct {
 	Transport      http.RoundTripper
 	ctx            Context
 	QueryParam     map[string]string
+	// assigned once, see publishOpts
+	seqn int
 	// nil hacks
 	setTTL         bool
 	setShouldStore bool
@@ -122,7 +124,21 @@ func (b *fireBuilder) Execute() (*PublishResponse, StatusResponse, error) {
 		return emptyPublishResponse, status, err
 	}
 
-	return newPublishResponse(rawJSON, status)
+	resp, status, err := newPublishResponse(rawJSON, status)
+	if err != nil {
+		return resp, status, err
+	}
+	resp.SequenceNumber = b.opts.seqn
+
+	return resp, status, nil
+}
+
+// ExecuteAsync runs the Fire request asynchronously, the result of the Future is a *PublishResponse.
+func (b *fireBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
 }
 
 func (o *fireOpts) config() Config {
@@ -169,7 +185,7 @@ func (o *fireOpts) buildPath() (string, error) {
 	var message []byte
 	var err error
 
-	if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
+	if cipherKey := o.pubnub.Config.cipherKeyForChannel(o.Channel); cipherKey != "" {
 		msg := utils.EncryptString(cipherKey, string(message))
 
 		o.Message = []byte(msg)
@@ -209,7 +225,10 @@ func (o *fireOpts) buildQuery() (*url.Values, error) {
 		}
 	}
 
-	q.Set("seqn", strconv.Itoa(o.pubnub.getPublishSequence()))
+	if o.seqn == 0 {
+		o.seqn = o.pubnub.getPublishSequence()
+	}
+	q.Set("seqn", strconv.Itoa(o.seqn))
 	SetQueryParam(q, o.QueryParam)
 
 	return q, nil
@@ -238,7 +257,7 @@ func (o *fireOpts) buildBody() ([]byte, error) {
 			}
 		}
 
-		if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
+		if cipherKey := o.pubnub.Config.cipherKeyForChannel(o.Channel); cipherKey != "" {
 			enc := utils.EncryptString(cipherKey, string(msg))
 			msg, err := utils.ValueAsString(enc)
 			if err != nil {
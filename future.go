@@ -0,0 +1,95 @@
+package pubnub
+
+// Future is the pending result of a request run with ExecuteAsync. The result
+// has the type of the response returned by Execute, *HereNowResponse for
+// HereNow for example.
+//
+//	hereNow := pn.HereNowWithContext(ctx).Channels([]string{"ch"}).ExecuteAsync()
+//	fetch := pn.FetchWithContext(ctx).Channels([]string{"ch"}).ExecuteAsync()
+//	pubnub.WaitAll(hereNow, fetch)
+//
+//	res, status, err := hereNow.Get()
+//	if err == nil {
+//		occupancy := res.(*pubnub.HereNowResponse).TotalOccupancy
+//	}
+type Future struct {
+	done chan struct{}
+
+	result interface{}
+	status StatusResponse
+	err    error
+}
+
+type futureResult struct {
+	result interface{}
+	status StatusResponse
+	err    error
+}
+
+// newFuture runs the request in a goroutine. When the context of the request
+// is done before the response, the future is resolved with the context error
+// and a PNCancelledCategory status.
+func newFuture(opts endpointOpts, run func() (interface{}, StatusResponse, error)) *Future {
+	f := &Future{
+		done: make(chan struct{}),
+	}
+
+	results := make(chan futureResult, 1)
+	go func() {
+		result, status, err := run()
+		results <- futureResult{result: result, status: status, err: err}
+	}()
+
+	var ctxDone <-chan struct{}
+	ctx := opts.context()
+	if ctx != nil {
+		ctxDone = ctx.Done()
+	}
+
+	go func() {
+		select {
+		case r := <-results:
+			f.resolve(r.result, r.status, r.err)
+		case <-ctxDone:
+			status := createStatus(PNCancelledCategory, "",
+				ResponseInfo{Operation: opts.operationType()}, ctx.Err())
+			f.resolve(nil, status, ctx.Err())
+		}
+	}()
+
+	return f
+}
+
+func (f *Future) resolve(result interface{}, status StatusResponse, err error) {
+	f.result = result
+	f.status = status
+	f.err = err
+	close(f.done)
+}
+
+// Done is closed once the request is done.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get blocks until the request is done and returns its result, the result is
+// nil when the context is done before the response.
+func (f *Future) Get() (interface{}, StatusResponse, error) {
+	<-f.done
+
+	return f.result, f.status, f.err
+}
+
+// OnComplete calls the callback in a goroutine once the request is done.
+func (f *Future) OnComplete(callback func(result interface{}, status StatusResponse, err error)) {
+	go func() {
+		callback(f.Get())
+	}()
+}
+
+// WaitAll blocks until all the requests are done.
+func WaitAll(futures ...*Future) {
+	for _, f := range futures {
+		<-f.done
+	}
+}
@@ -0,0 +1,15 @@
+//go:build go1.18
+// +build go1.18
+
+package pubnub
+
+// GetAs blocks until the request of the Future is done and returns its result
+// as T, the zero value of T when the result is not a T.
+//
+//	res, status, err := pubnub.GetAs[*pubnub.HereNowResponse](future)
+func GetAs[T any](f *Future) (T, StatusResponse, error) {
+	result, status, err := f.Get()
+	v, _ := result.(T)
+
+	return v, status, err
+}
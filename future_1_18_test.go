@@ -0,0 +1,23 @@
+//go:build go1.18
+// +build go1.18
+
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAs(t *testing.T) {
+	assert := assert.New(t)
+	pn := newTimeStubPubNub()
+
+	res, _, err := GetAs[*TimeResponse](pn.Time().ExecuteAsync())
+	assert.Nil(err)
+	assert.Equal(int64(15078947309567840), res.Timetoken)
+
+	other, _, err := GetAs[*HereNowResponse](pn.Time().ExecuteAsync())
+	assert.Nil(err)
+	assert.Nil(other)
+}
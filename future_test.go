@@ -0,0 +1,138 @@
+package pubnub
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingTransport never answers, it returns once the request is cancelled.
+type blockingTransport struct {
+	release chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	<-t.release
+
+	return nil, errors.New("released")
+}
+
+func newTimeStubPubNub() *PubNub {
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/time/0",
+		Query:              "",
+		ResponseBody:       `[15078947309567840]`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_time"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	return pn
+}
+
+func TestFutureGet(t *testing.T) {
+	assert := assert.New(t)
+	pn := newTimeStubPubNub()
+
+	future := pn.Time().ExecuteAsync()
+
+	res, status, err := future.Get()
+	assert.Nil(err)
+	assert.Equal(200, status.StatusCode)
+	assert.Equal(int64(15078947309567840), res.(*TimeResponse).Timetoken)
+
+	select {
+	case <-future.Done():
+	default:
+		assert.Fail("the future is not done")
+	}
+}
+
+func TestFutureValidationError(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newHereNowBuilder(pubnub).ExecuteAsync().Get()
+	assert.Nil(res.(*HereNowResponse))
+	assert.NotNil(err)
+}
+
+func TestFutureWaitAllAndOnComplete(t *testing.T) {
+	assert := assert.New(t)
+	pn := newTimeStubPubNub()
+
+	futures := []*Future{}
+	for i := 0; i < 5; i++ {
+		futures = append(futures, pn.Time().ExecuteAsync())
+	}
+	WaitAll(futures...)
+
+	for _, future := range futures {
+		select {
+		case <-future.Done():
+		default:
+			assert.Fail("WaitAll returned before the future is done")
+		}
+	}
+
+	completed := make(chan error)
+	futures[0].OnComplete(func(result interface{}, status StatusResponse, err error) {
+		completed <- err
+	})
+
+	select {
+	case err := <-completed:
+		assert.Nil(err)
+	case <-time.After(5 * time.Second):
+		assert.Fail("the callback is not called")
+	}
+}
+
+func TestFutureContextCancelled(t *testing.T) {
+	assert := assert.New(t)
+	transport := &blockingTransport{release: make(chan struct{})}
+	defer close(transport.release)
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(&http.Client{Transport: transport})
+
+	ctx, cancel := contextWithCancel(backgroundContext)
+	future := pn.HereNowWithContext(ctx).Channels([]string{"ch"}).ExecuteAsync()
+	cancel()
+
+	res, status, err := future.Get()
+	assert.Nil(res)
+	assert.NotNil(err)
+	assert.Equal(PNCancelledCategory, status.Category)
+	assert.Equal(PNHereNowOperation, status.Operation)
+}
+
+func TestFutureLeaveHasNoResult(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/presence/sub-key/demo/channel/ch/leave",
+		Query:              "",
+		ResponseBody:       `{"status": 200, "message": "OK", "action": "leave", "service": "Presence"}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := newLeaveBuilder(pn).Channels([]string{"ch"}).ExecuteAsync().Get()
+	assert.Nil(res)
+	assert.Nil(err)
+}
@@ -0,0 +1,219 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const getAllChannelMetadataPath = "/v2/objects/%s/channels"
+
+var emptyGetAllChannelMetadataResponse *PNGetAllChannelMetadataResponse
+
+type getAllChannelMetadataBuilder struct {
+	opts *getAllChannelMetadataOpts
+}
+
+func newGetAllChannelMetadataBuilder(pubnub *PubNub) *getAllChannelMetadataBuilder {
+	builder := getAllChannelMetadataBuilder{
+		opts: &getAllChannelMetadataOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newGetAllChannelMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *getAllChannelMetadataBuilder {
+	builder := newGetAllChannelMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// IncludeCustom returns the custom fields of the channels.
+func (b *getAllChannelMetadataBuilder) IncludeCustom(include bool) *getAllChannelMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// Limit sets the number of channels returned, at most 100 (default).
+func (b *getAllChannelMetadataBuilder) Limit(limit int) *getAllChannelMetadataBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of channels.
+func (b *getAllChannelMetadataBuilder) Start(start string) *getAllChannelMetadataBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of channels.
+func (b *getAllChannelMetadataBuilder) End(end string) *getAllChannelMetadataBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *getAllChannelMetadataBuilder) Count(count bool) *getAllChannelMetadataBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *getAllChannelMetadataBuilder) Sort(sort []string) *getAllChannelMetadataBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *getAllChannelMetadataBuilder) Filter(filter string) *getAllChannelMetadataBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getAllChannelMetadataBuilder) QueryParam(queryParam map[string]string) *getAllChannelMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get All Channel Metadata request.
+func (b *getAllChannelMetadataBuilder) Transport(tr http.RoundTripper) *getAllChannelMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get All Channel Metadata request.
+func (b *getAllChannelMetadataBuilder) Execute() (*PNGetAllChannelMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetAllChannelMetadataResponse, status, err
+	}
+
+	return newGetAllChannelMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get All Channel Metadata request asynchronously, the result of the Future is a *PNGetAllChannelMetadataResponse.
+func (b *getAllChannelMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getAllChannelMetadataOpts struct {
+	pubnub *PubNub
+
+	IncludeCustom bool
+	Limit         int
+	Start         string
+	End           string
+	Count         bool
+	Sort          []string
+	Filter        string
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getAllChannelMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getAllChannelMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getAllChannelMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getAllChannelMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *getAllChannelMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getAllChannelMetadataPath,
+		o.pubnub.Config.SubscribeKey), nil
+}
+
+func (o *getAllChannelMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getAllChannelMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getAllChannelMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getAllChannelMetadataOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getAllChannelMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getAllChannelMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getAllChannelMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getAllChannelMetadataOpts) operationType() OperationType {
+	return PNGetAllChannelMetadataOperation
+}
+
+func (o *getAllChannelMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetAllChannelMetadataResponse is the response to the Get All Channel Metadata request.
+type PNGetAllChannelMetadataResponse struct {
+	Status     int         `json:"status"`
+	Data       []PNChannel `json:"data"`
+	TotalCount int         `json:"totalCount"`
+	Next       string      `json:"next"`
+	Prev       string      `json:"prev"`
+}
+
+func newGetAllChannelMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetAllChannelMetadataResponse, StatusResponse, error) {
+	resp := &PNGetAllChannelMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetAllChannelMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,36 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllChannelMetadataBuildQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetAllChannelMetadataBuilder(pubnub).End("MQ").Limit(20)
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels", path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("MQ", query.Get("end"))
+	assert.Equal("20", query.Get("limit"))
+	assert.Equal("", query.Get("include"))
+}
+
+func TestNewGetAllChannelMetadataResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetAllChannelMetadataResponse([]byte(`{"status":200,"data":[{"id":"ch1"},{"id":"ch2"}],"next":"Mg"}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal(2, len(res.Data))
+	assert.Equal("ch1", res.Data[0].ID)
+	assert.Equal("Mg", res.Next)
+	assert.Equal("", res.Prev)
+}
@@ -0,0 +1,219 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const getAllUUIDMetadataPath = "/v2/objects/%s/uuids"
+
+var emptyGetAllUUIDMetadataResponse *PNGetAllUUIDMetadataResponse
+
+type getAllUUIDMetadataBuilder struct {
+	opts *getAllUUIDMetadataOpts
+}
+
+func newGetAllUUIDMetadataBuilder(pubnub *PubNub) *getAllUUIDMetadataBuilder {
+	builder := getAllUUIDMetadataBuilder{
+		opts: &getAllUUIDMetadataOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newGetAllUUIDMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *getAllUUIDMetadataBuilder {
+	builder := newGetAllUUIDMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// IncludeCustom returns the custom fields of the UUIDs.
+func (b *getAllUUIDMetadataBuilder) IncludeCustom(include bool) *getAllUUIDMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// Limit sets the number of UUIDs returned, at most 100 (default).
+func (b *getAllUUIDMetadataBuilder) Limit(limit int) *getAllUUIDMetadataBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of UUIDs.
+func (b *getAllUUIDMetadataBuilder) Start(start string) *getAllUUIDMetadataBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of UUIDs.
+func (b *getAllUUIDMetadataBuilder) End(end string) *getAllUUIDMetadataBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *getAllUUIDMetadataBuilder) Count(count bool) *getAllUUIDMetadataBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *getAllUUIDMetadataBuilder) Sort(sort []string) *getAllUUIDMetadataBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *getAllUUIDMetadataBuilder) Filter(filter string) *getAllUUIDMetadataBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getAllUUIDMetadataBuilder) QueryParam(queryParam map[string]string) *getAllUUIDMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get All UUID Metadata request.
+func (b *getAllUUIDMetadataBuilder) Transport(tr http.RoundTripper) *getAllUUIDMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get All UUID Metadata request.
+func (b *getAllUUIDMetadataBuilder) Execute() (*PNGetAllUUIDMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetAllUUIDMetadataResponse, status, err
+	}
+
+	return newGetAllUUIDMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get All UUID Metadata request asynchronously, the result of the Future is a *PNGetAllUUIDMetadataResponse.
+func (b *getAllUUIDMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getAllUUIDMetadataOpts struct {
+	pubnub *PubNub
+
+	IncludeCustom bool
+	Limit         int
+	Start         string
+	End           string
+	Count         bool
+	Sort          []string
+	Filter        string
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getAllUUIDMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getAllUUIDMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getAllUUIDMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getAllUUIDMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *getAllUUIDMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getAllUUIDMetadataPath,
+		o.pubnub.Config.SubscribeKey), nil
+}
+
+func (o *getAllUUIDMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getAllUUIDMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getAllUUIDMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getAllUUIDMetadataOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getAllUUIDMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getAllUUIDMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getAllUUIDMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getAllUUIDMetadataOpts) operationType() OperationType {
+	return PNGetAllUUIDMetadataOperation
+}
+
+func (o *getAllUUIDMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetAllUUIDMetadataResponse is the response to the Get All UUID Metadata request.
+type PNGetAllUUIDMetadataResponse struct {
+	Status     int      `json:"status"`
+	Data       []PNUUID `json:"data"`
+	TotalCount int      `json:"totalCount"`
+	Next       string   `json:"next"`
+	Prev       string   `json:"prev"`
+}
+
+func newGetAllUUIDMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetAllUUIDMetadataResponse, StatusResponse, error) {
+	resp := &PNGetAllUUIDMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetAllUUIDMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,68 @@
+package pubnub
+
+import (
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllUUIDMetadataValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(newGetAllUUIDMetadataBuilder(pubnub).opts.validate())
+	assert.Contains(newGetAllUUIDMetadataBuilder(pubnub).Limit(101).opts.validate().Error(),
+		"Limit must be between 1 and 100")
+}
+
+func TestGetAllUUIDMetadataBuildQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetAllUUIDMetadataBuilder(pubnub).
+		IncludeCustom(true).
+		Limit(10).
+		Start("MTAw").
+		Count(true).
+		Sort([]string{"name"}).
+		Filter("name == 'a'")
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/uuids", path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("include", "custom")
+	expected.Set("limit", "10")
+	expected.Set("start", "MTAw")
+	expected.Set("count", "true")
+	expected.Set("sort", "name")
+	expected.Set("filter", "name%20%3D%3D%20%27a%27")
+	h.AssertQueriesEqual(t, expected, query, []string{"pnsdk", "uuid"}, []string{})
+}
+
+func TestGetAllUUIDMetadataDefaultLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	query, err := newGetAllUUIDMetadataBuilder(pubnub).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("100", query.Get("limit"))
+	assert.Equal("", query.Get("count"))
+}
+
+func TestNewGetAllUUIDMetadataResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetAllUUIDMetadataResponse([]byte(`{"status":200,"data":[{"id":"uuid1","name":"one"},{"id":"uuid2","name":"two"}],"totalCount":5,"next":"Mg","prev":"MQ"}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal(2, len(res.Data))
+	assert.Equal("uuid2", res.Data[1].ID)
+	assert.Equal("two", res.Data[1].Name)
+	assert.Equal(5, res.TotalCount)
+	assert.Equal("Mg", res.Next)
+	assert.Equal("MQ", res.Prev)
+}
@@ -0,0 +1,249 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const getChannelMembersPath = "/v2/objects/%s/channels/%s/uuids"
+
+var emptyGetChannelMembersResponse *PNGetChannelMembersResponse
+
+type getChannelMembersBuilder struct {
+	opts *getChannelMembersOpts
+}
+
+func newGetChannelMembersBuilder(pubnub *PubNub) *getChannelMembersBuilder {
+	builder := getChannelMembersBuilder{
+		opts: &getChannelMembersOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newGetChannelMembersBuilderWithContext(pubnub *PubNub,
+	context Context) *getChannelMembersBuilder {
+	builder := newGetChannelMembersBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose members are returned.
+func (b *getChannelMembersBuilder) Channel(ch string) *getChannelMembersBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// IncludeCustom returns the custom fields of the members.
+func (b *getChannelMembersBuilder) IncludeCustom(include bool) *getChannelMembersBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeUUID returns the metadata of the UUIDs, only their ID otherwise.
+func (b *getChannelMembersBuilder) IncludeUUID(include bool) *getChannelMembersBuilder {
+	b.opts.IncludeUUID = include
+	return b
+}
+
+// IncludeUUIDCustom returns the custom fields of the UUIDs, with IncludeUUID.
+func (b *getChannelMembersBuilder) IncludeUUIDCustom(include bool) *getChannelMembersBuilder {
+	b.opts.IncludeUUIDCustom = include
+	return b
+}
+
+// Limit sets the number of members returned, at most 100 (default).
+func (b *getChannelMembersBuilder) Limit(limit int) *getChannelMembersBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of members.
+func (b *getChannelMembersBuilder) Start(start string) *getChannelMembersBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of members.
+func (b *getChannelMembersBuilder) End(end string) *getChannelMembersBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *getChannelMembersBuilder) Count(count bool) *getChannelMembersBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *getChannelMembersBuilder) Sort(sort []string) *getChannelMembersBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *getChannelMembersBuilder) Filter(filter string) *getChannelMembersBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getChannelMembersBuilder) QueryParam(queryParam map[string]string) *getChannelMembersBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get Channel Members request.
+func (b *getChannelMembersBuilder) Transport(tr http.RoundTripper) *getChannelMembersBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get Channel Members request.
+func (b *getChannelMembersBuilder) Execute() (*PNGetChannelMembersResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetChannelMembersResponse, status, err
+	}
+
+	return newGetChannelMembersResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get Channel Members request asynchronously, the result of the Future is a *PNGetChannelMembersResponse.
+func (b *getChannelMembersBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getChannelMembersOpts struct {
+	pubnub *PubNub
+
+	Channel           string
+	IncludeCustom     bool
+	IncludeUUID       bool
+	IncludeUUIDCustom bool
+	Limit             int
+	Start             string
+	End               string
+	Count             bool
+	Sort              []string
+	Filter            string
+	QueryParam        map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getChannelMembersOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getChannelMembersOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getChannelMembersOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getChannelMembersOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *getChannelMembersOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getChannelMembersPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *getChannelMembersOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":      o.IncludeCustom,
+		"uuid":        o.IncludeUUID,
+		"uuid.custom": o.IncludeUUIDCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getChannelMembersOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getChannelMembersOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getChannelMembersOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getChannelMembersOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getChannelMembersOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getChannelMembersOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getChannelMembersOpts) operationType() OperationType {
+	return PNGetChannelMembersOperation
+}
+
+func (o *getChannelMembersOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetChannelMembersResponse is the response to the Get Channel Members request.
+type PNGetChannelMembersResponse struct {
+	Status     int               `json:"status"`
+	Data       []PNChannelMember `json:"data"`
+	TotalCount int               `json:"totalCount"`
+	Next       string            `json:"next"`
+	Prev       string            `json:"prev"`
+}
+
+func newGetChannelMembersResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetChannelMembersResponse, StatusResponse, error) {
+	resp := &PNGetChannelMembersResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetChannelMembersResponse, status, err
+	}
+
+	return resp, status, nil
+}
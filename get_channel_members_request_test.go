@@ -0,0 +1,45 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChannelMembersValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newGetChannelMembersBuilder(pubnub).opts.validate().Error(), "Missing Channel")
+	assert.Nil(newGetChannelMembersBuilder(pubnub).Channel("ch").opts.validate())
+}
+
+func TestGetChannelMembersBuildPathAndQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetChannelMembersBuilderWithContext(pubnub, backgroundContext).
+		Channel("ch").
+		IncludeCustom(true).
+		IncludeUUID(true).
+		IncludeUUIDCustom(true).
+		Sort([]string{"uuid.name:asc"})
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels/ch/uuids", path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom,uuid,uuid.custom", query.Get("include"))
+	assert.Equal("uuid.name%3Aasc", query.Get("sort"))
+}
+
+func TestNewGetChannelMembersResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetChannelMembersResponse([]byte(`{"status":200,"data":[{"uuid":{"id":"uuid","name":"name"},"custom":{"role":"owner"}}],"totalCount":1}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("uuid", res.Data[0].UUID.ID)
+	assert.Equal("name", res.Data[0].UUID.Name)
+	assert.Equal("owner", res.Data[0].Custom["role"])
+}
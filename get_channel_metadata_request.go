@@ -0,0 +1,181 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const getChannelMetadataPath = "/v2/objects/%s/channels/%s"
+
+var emptyGetChannelMetadataResponse *PNGetChannelMetadataResponse
+
+type getChannelMetadataBuilder struct {
+	opts *getChannelMetadataOpts
+}
+
+func newGetChannelMetadataBuilder(pubnub *PubNub) *getChannelMetadataBuilder {
+	builder := getChannelMetadataBuilder{
+		opts: &getChannelMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newGetChannelMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *getChannelMetadataBuilder {
+	builder := newGetChannelMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose metadata is returned.
+func (b *getChannelMetadataBuilder) Channel(ch string) *getChannelMetadataBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// IncludeCustom returns the custom fields of the channel.
+func (b *getChannelMetadataBuilder) IncludeCustom(include bool) *getChannelMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getChannelMetadataBuilder) QueryParam(queryParam map[string]string) *getChannelMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get Channel Metadata request.
+func (b *getChannelMetadataBuilder) Transport(tr http.RoundTripper) *getChannelMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get Channel Metadata request.
+func (b *getChannelMetadataBuilder) Execute() (*PNGetChannelMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetChannelMetadataResponse, status, err
+	}
+
+	return newGetChannelMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get Channel Metadata request asynchronously, the result of the Future is a *PNGetChannelMetadataResponse.
+func (b *getChannelMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getChannelMetadataOpts struct {
+	pubnub *PubNub
+
+	Channel       string
+	IncludeCustom bool
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getChannelMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getChannelMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getChannelMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getChannelMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	return nil
+}
+
+func (o *getChannelMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getChannelMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *getChannelMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getChannelMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getChannelMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getChannelMetadataOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getChannelMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getChannelMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getChannelMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getChannelMetadataOpts) operationType() OperationType {
+	return PNGetChannelMetadataOperation
+}
+
+func (o *getChannelMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetChannelMetadataResponse is the response to the Get Channel Metadata request.
+type PNGetChannelMetadataResponse struct {
+	Status int       `json:"status"`
+	Data   PNChannel `json:"data"`
+}
+
+func newGetChannelMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetChannelMetadataResponse, StatusResponse, error) {
+	resp := &PNGetChannelMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetChannelMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
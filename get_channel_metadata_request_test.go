@@ -0,0 +1,32 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChannelMetadataBuildPathAndQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetChannelMetadataBuilder(pubnub).Channel("ch").IncludeCustom(true)
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels/ch", path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom", query.Get("include"))
+}
+
+func TestNewGetChannelMetadataResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetChannelMetadataResponse([]byte(`{"status":200,"data":{"id":"ch","name":"name","custom":{"topic":"go"}}}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("ch", res.Data.ID)
+	assert.Equal("go", res.Data.Custom["topic"])
+}
@@ -0,0 +1,257 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const getMembershipsPath = "/v2/objects/%s/uuids/%s/channels"
+
+var emptyGetMembershipsResponse *PNGetMembershipsResponse
+
+type getMembershipsBuilder struct {
+	opts *getMembershipsOpts
+}
+
+func newGetMembershipsBuilder(pubnub *PubNub) *getMembershipsBuilder {
+	builder := getMembershipsBuilder{
+		opts: &getMembershipsOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newGetMembershipsBuilderWithContext(pubnub *PubNub,
+	context Context) *getMembershipsBuilder {
+	builder := newGetMembershipsBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose channel memberships are returned, the UUID of the config by default.
+func (b *getMembershipsBuilder) UUID(uuid string) *getMembershipsBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// IncludeCustom returns the custom fields of the memberships.
+func (b *getMembershipsBuilder) IncludeCustom(include bool) *getMembershipsBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeChannel returns the metadata of the channels, only their ID otherwise.
+func (b *getMembershipsBuilder) IncludeChannel(include bool) *getMembershipsBuilder {
+	b.opts.IncludeChannel = include
+	return b
+}
+
+// IncludeChannelCustom returns the custom fields of the channels, with IncludeChannel.
+func (b *getMembershipsBuilder) IncludeChannelCustom(include bool) *getMembershipsBuilder {
+	b.opts.IncludeChannelCustom = include
+	return b
+}
+
+// Limit sets the number of memberships returned, at most 100 (default).
+func (b *getMembershipsBuilder) Limit(limit int) *getMembershipsBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of memberships.
+func (b *getMembershipsBuilder) Start(start string) *getMembershipsBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of memberships.
+func (b *getMembershipsBuilder) End(end string) *getMembershipsBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *getMembershipsBuilder) Count(count bool) *getMembershipsBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *getMembershipsBuilder) Sort(sort []string) *getMembershipsBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *getMembershipsBuilder) Filter(filter string) *getMembershipsBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getMembershipsBuilder) QueryParam(queryParam map[string]string) *getMembershipsBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get Memberships request.
+func (b *getMembershipsBuilder) Transport(tr http.RoundTripper) *getMembershipsBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get Memberships request.
+func (b *getMembershipsBuilder) Execute() (*PNGetMembershipsResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetMembershipsResponse, status, err
+	}
+
+	return newGetMembershipsResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get Memberships request asynchronously, the result of the Future is a *PNGetMembershipsResponse.
+func (b *getMembershipsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getMembershipsOpts struct {
+	pubnub *PubNub
+
+	UUID                 string
+	IncludeCustom        bool
+	IncludeChannel       bool
+	IncludeChannelCustom bool
+	Limit                int
+	Start                string
+	End                  string
+	Count                bool
+	Sort                 []string
+	Filter               string
+	QueryParam           map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getMembershipsOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getMembershipsOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getMembershipsOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getMembershipsOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *getMembershipsOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *getMembershipsOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getMembershipsPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *getMembershipsOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":         o.IncludeCustom,
+		"channel":        o.IncludeChannel,
+		"channel.custom": o.IncludeChannelCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getMembershipsOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getMembershipsOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getMembershipsOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getMembershipsOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getMembershipsOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getMembershipsOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getMembershipsOpts) operationType() OperationType {
+	return PNGetMembershipsOperation
+}
+
+func (o *getMembershipsOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetMembershipsResponse is the response to the Get Memberships request.
+type PNGetMembershipsResponse struct {
+	Status     int            `json:"status"`
+	Data       []PNMembership `json:"data"`
+	TotalCount int            `json:"totalCount"`
+	Next       string         `json:"next"`
+	Prev       string         `json:"prev"`
+}
+
+func newGetMembershipsResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetMembershipsResponse, StatusResponse, error) {
+	resp := &PNGetMembershipsResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetMembershipsResponse, status, err
+	}
+
+	return resp, status, nil
+}
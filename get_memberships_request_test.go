@@ -0,0 +1,38 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMembershipsBuildPathAndQuery(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UUID = "config-uuid"
+
+	o := newGetMembershipsBuilder(pn).IncludeChannel(true).IncludeChannelCustom(true)
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/demo/uuids/config-uuid/channels", path)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("channel,channel.custom", query.Get("include"))
+	assert.Equal("100", query.Get("limit"))
+}
+
+func TestNewGetMembershipsResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetMembershipsResponse([]byte(`{"status":200,"data":[{"channel":{"id":"ch","name":"name"},"custom":{"role":"admin"},"updated":"2020-06-17T16:28:14.060718Z","eTag":"AbO7ys6S2pH8cQ"}],"totalCount":1}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal(1, len(res.Data))
+	assert.Equal("ch", res.Data[0].Channel.ID)
+	assert.Equal("name", res.Data[0].Channel.Name)
+	assert.Equal("admin", res.Data[0].Custom["role"])
+	assert.Equal(1, res.TotalCount)
+}
@@ -0,0 +1,254 @@
+package pubnub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+const getMessageActionsPath = "/v1/message-actions/%s/channel/%s"
+
+// maxMessageActionsLimit is the max number of actions returned by a Get Message Actions request.
+const maxMessageActionsLimit = 100
+
+var emptyGetMessageActionsResponse *PNGetMessageActionsResponse
+
+type getMessageActionsBuilder struct {
+	opts *getMessageActionsOpts
+}
+
+func newGetMessageActionsBuilder(pubnub *PubNub) *getMessageActionsBuilder {
+	builder := getMessageActionsBuilder{
+		opts: &getMessageActionsOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newGetMessageActionsBuilderWithContext(pubnub *PubNub,
+	context Context) *getMessageActionsBuilder {
+	builder := getMessageActionsBuilder{
+		opts: &getMessageActionsOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// Channel sets the Channel whose message actions are returned.
+func (b *getMessageActionsBuilder) Channel(ch string) *getMessageActionsBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// Start sets the action timetoken (exclusive) the actions are returned before.
+func (b *getMessageActionsBuilder) Start(timetoken int64) *getMessageActionsBuilder {
+	b.opts.Start = timetoken
+	b.opts.setStart = true
+	return b
+}
+
+// End sets the action timetoken (inclusive) the actions are returned after.
+func (b *getMessageActionsBuilder) End(timetoken int64) *getMessageActionsBuilder {
+	b.opts.End = timetoken
+	b.opts.setEnd = true
+	return b
+}
+
+// Limit sets the number of actions to return, at most 100.
+func (b *getMessageActionsBuilder) Limit(limit int) *getMessageActionsBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getMessageActionsBuilder) QueryParam(queryParam map[string]string) *getMessageActionsBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get Message Actions request.
+func (b *getMessageActionsBuilder) Transport(tr http.RoundTripper) *getMessageActionsBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get Message Actions request.
+func (b *getMessageActionsBuilder) Execute() (*PNGetMessageActionsResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetMessageActionsResponse, status, err
+	}
+
+	return newGetMessageActionsResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get Message Actions request asynchronously, the result of the Future is a *PNGetMessageActionsResponse.
+func (b *getMessageActionsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getMessageActionsOpts struct {
+	pubnub *PubNub
+
+	Channel    string
+	Start      int64
+	End        int64
+	Limit      int
+	QueryParam map[string]string
+
+	// nil hacks
+	setStart bool
+	setEnd   bool
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getMessageActionsOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getMessageActionsOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getMessageActionsOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getMessageActionsOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	return nil
+}
+
+func (o *getMessageActionsOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getMessageActionsPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *getMessageActionsOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	if o.setStart {
+		q.Set("start", strconv.FormatInt(o.Start, 10))
+	}
+
+	if o.setEnd {
+		q.Set("end", strconv.FormatInt(o.End, 10))
+	}
+
+	if o.Limit > 0 && o.Limit <= maxMessageActionsLimit {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getMessageActionsOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getMessageActionsOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getMessageActionsOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getMessageActionsOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getMessageActionsOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getMessageActionsOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getMessageActionsOpts) operationType() OperationType {
+	return PNGetMessageActionsOperation
+}
+
+func (o *getMessageActionsOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetMessageActionsMore is the range of the next page of actions, set when more actions are available.
+type PNGetMessageActionsMore struct {
+	URL   string
+	Start int64
+	End   int64
+	Limit int
+}
+
+// PNGetMessageActionsResponse is the response to the Get Message Actions request.
+type PNGetMessageActionsResponse struct {
+	Data []PNMessageAction
+	More *PNGetMessageActionsMore
+}
+
+func newGetMessageActionsResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetMessageActionsResponse, StatusResponse, error) {
+	var value map[string]interface{}
+
+	err := json.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		e := pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+
+		return emptyGetMessageActionsResponse, status, e
+	}
+
+	resp := &PNGetMessageActionsResponse{
+		Data: []PNMessageAction{},
+	}
+
+	data, _ := value["data"].([]interface{})
+	for _, v := range data {
+		if action, ok := v.(map[string]interface{}); ok {
+			resp.Data = append(resp.Data, newMessageAction(action))
+		}
+	}
+
+	if more, ok := value["more"].(map[string]interface{}); ok {
+		resp.More = &PNGetMessageActionsMore{
+			Start: parseHistoryTimetoken(more["start"]),
+			End:   parseHistoryTimetoken(more["end"]),
+		}
+		resp.More.URL, _ = more["url"].(string)
+		limit, _ := more["limit"].(float64)
+		resp.More.Limit = int(limit)
+	}
+
+	return resp, status, nil
+}
@@ -89,7 +89,28 @@ func (b *getStateBuilder) Execute() (
 		return emptyGetStateResp, status, err
 	}
 
-	return newGetStateResponse(rawJSON, status)
+	resp, status, err := newGetStateResponse(rawJSON, status)
+	if err != nil {
+		return resp, status, err
+	}
+
+	if b.opts.UUID == "" || b.opts.UUID == b.opts.pubnub.Config.UUID {
+		for ch, state := range resp.State {
+			if s, ok := state.(map[string]interface{}); ok {
+				b.opts.pubnub.subscriptionManager.stateManager.updateChannelState(ch, s)
+			}
+		}
+	}
+
+	return resp, status, nil
+}
+
+// ExecuteAsync runs the Get State request asynchronously, the result of the Future is a *GetStateResponse.
+func (b *getStateBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
 }
 
 type getStateOpts struct {
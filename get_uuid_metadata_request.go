@@ -0,0 +1,189 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const getUUIDMetadataPath = "/v2/objects/%s/uuids/%s"
+
+var emptyGetUUIDMetadataResponse *PNGetUUIDMetadataResponse
+
+type getUUIDMetadataBuilder struct {
+	opts *getUUIDMetadataOpts
+}
+
+func newGetUUIDMetadataBuilder(pubnub *PubNub) *getUUIDMetadataBuilder {
+	builder := getUUIDMetadataBuilder{
+		opts: &getUUIDMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newGetUUIDMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *getUUIDMetadataBuilder {
+	builder := newGetUUIDMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose metadata is returned, the UUID of the config by default.
+func (b *getUUIDMetadataBuilder) UUID(uuid string) *getUUIDMetadataBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// IncludeCustom returns the custom fields of the UUID.
+func (b *getUUIDMetadataBuilder) IncludeCustom(include bool) *getUUIDMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *getUUIDMetadataBuilder) QueryParam(queryParam map[string]string) *getUUIDMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Get UUID Metadata request.
+func (b *getUUIDMetadataBuilder) Transport(tr http.RoundTripper) *getUUIDMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Get UUID Metadata request.
+func (b *getUUIDMetadataBuilder) Execute() (*PNGetUUIDMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGetUUIDMetadataResponse, status, err
+	}
+
+	return newGetUUIDMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Get UUID Metadata request asynchronously, the result of the Future is a *PNGetUUIDMetadataResponse.
+func (b *getUUIDMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type getUUIDMetadataOpts struct {
+	pubnub *PubNub
+
+	UUID          string
+	IncludeCustom bool
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *getUUIDMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *getUUIDMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *getUUIDMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *getUUIDMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	return nil
+}
+
+func (o *getUUIDMetadataOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *getUUIDMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(getUUIDMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *getUUIDMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *getUUIDMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *getUUIDMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *getUUIDMetadataOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *getUUIDMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *getUUIDMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *getUUIDMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *getUUIDMetadataOpts) operationType() OperationType {
+	return PNGetUUIDMetadataOperation
+}
+
+func (o *getUUIDMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGetUUIDMetadataResponse is the response to the Get UUID Metadata request.
+type PNGetUUIDMetadataResponse struct {
+	Status int    `json:"status"`
+	Data   PNUUID `json:"data"`
+}
+
+func newGetUUIDMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGetUUIDMetadataResponse, StatusResponse, error) {
+	resp := &PNGetUUIDMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyGetUUIDMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
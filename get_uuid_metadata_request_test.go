@@ -0,0 +1,48 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUUIDMetadataBuildPath(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetUUIDMetadataBuilder(pubnub).UUID("uuid")
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/uuids/uuid", path)
+	assert.Equal("GET", o.opts.httpMethod())
+}
+
+func TestGetUUIDMetadataBuildQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	query, err := newGetUUIDMetadataBuilder(pubnub).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("", query.Get("include"))
+
+	query, err = newGetUUIDMetadataBuilder(pubnub).IncludeCustom(true).opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("custom", query.Get("include"))
+}
+
+func TestNewGetUUIDMetadataResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	res, _, err := newGetUUIDMetadataResponse([]byte(`{"status":200,"data":{"id":"uuid","name":"name","profileUrl":"https://example.com/a.png","updated":"2020-06-17T16:28:14.060718Z","eTag":"AbO7ys6S2pH8cQ"}}`), StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("uuid", res.Data.ID)
+	assert.Equal("https://example.com/a.png", res.Data.ProfileURL)
+	assert.Nil(res.Data.Custom)
+}
+
+func TestNewGetUUIDMetadataResponseError(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := newGetUUIDMetadataResponse([]byte(`{"status":200,"data":`), StatusResponse{})
+	assert.Contains(err.Error(), "Error unmarshalling response")
+}
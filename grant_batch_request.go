@@ -0,0 +1,320 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// DefaultGrantBatchChunkSize is the default max number of auth keys, channels or channel groups of each Grant request of a batch.
+const DefaultGrantBatchChunkSize = 200
+
+// DefaultGrantBatchConcurrency is the default max number of Grant requests of a batch running at the same time.
+const DefaultGrantBatchConcurrency = 4
+
+// grantBatchMaxParamLength is the max length of the encoded list of names in a query param.
+const grantBatchMaxParamLength = 4000
+
+type grantBatchBuilder struct {
+	opts        *grantOpts
+	chunkSize   int
+	concurrency int
+}
+
+func newGrantBatchBuilder(pubnub *PubNub) *grantBatchBuilder {
+	builder := grantBatchBuilder{
+		opts: &grantOpts{
+			pubnub: pubnub,
+		},
+		chunkSize:   DefaultGrantBatchChunkSize,
+		concurrency: DefaultGrantBatchConcurrency,
+	}
+
+	return &builder
+}
+
+func newGrantBatchBuilderWithContext(pubnub *PubNub, context Context) *grantBatchBuilder {
+	builder := newGrantBatchBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Read sets the read permission.
+func (b *grantBatchBuilder) Read(read bool) *grantBatchBuilder {
+	b.opts.Read = read
+
+	return b
+}
+
+// Write sets the write permission.
+func (b *grantBatchBuilder) Write(write bool) *grantBatchBuilder {
+	b.opts.Write = write
+
+	return b
+}
+
+// Manage sets the manage permission.
+func (b *grantBatchBuilder) Manage(manage bool) *grantBatchBuilder {
+	b.opts.Manage = manage
+
+	return b
+}
+
+// Delete sets the delete permission.
+func (b *grantBatchBuilder) Delete(del bool) *grantBatchBuilder {
+	b.opts.Delete = del
+
+	return b
+}
+
+// Join sets the permission to join the channels.
+func (b *grantBatchBuilder) Join(join bool) *grantBatchBuilder {
+	b.opts.Join = join
+	b.opts.setJoin = true
+
+	return b
+}
+
+// TTL in minutes for which granted permissions are valid.
+func (b *grantBatchBuilder) TTL(ttl int) *grantBatchBuilder {
+	b.opts.TTL = ttl
+	b.opts.setTTL = true
+
+	return b
+}
+
+// AuthKeys sets the AuthKeys for the Grant requests.
+func (b *grantBatchBuilder) AuthKeys(authKeys []string) *grantBatchBuilder {
+	b.opts.AuthKeys = authKeys
+
+	return b
+}
+
+// Channels sets the Channels for the Grant requests.
+func (b *grantBatchBuilder) Channels(channels []string) *grantBatchBuilder {
+	b.opts.Channels = channels
+
+	return b
+}
+
+// ChannelGroups sets the ChannelGroups for the Grant requests.
+func (b *grantBatchBuilder) ChannelGroups(groups []string) *grantBatchBuilder {
+	b.opts.ChannelGroups = groups
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URLs called by the API.
+func (b *grantBatchBuilder) QueryParam(queryParam map[string]string) *grantBatchBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// ChunkSize sets the max number of auth keys, channels or channel groups sent in each Grant request.
+// Lists longer than grantBatchMaxParamLength once encoded are split too.
+func (b *grantBatchBuilder) ChunkSize(size int) *grantBatchBuilder {
+	b.chunkSize = size
+
+	return b
+}
+
+// Concurrency sets the max number of Grant requests running at the same time.
+func (b *grantBatchBuilder) Concurrency(concurrency int) *grantBatchBuilder {
+	b.concurrency = concurrency
+
+	return b
+}
+
+// GrantBatchResponse is the merged response of the Grant requests of a batch.
+type GrantBatchResponse struct {
+	*GrantResponse
+
+	Chunks []GrantBatchChunk
+}
+
+// GrantBatchChunk is the result of a single Grant request of a batch.
+type GrantBatchChunk struct {
+	AuthKeys      []string
+	Channels      []string
+	ChannelGroups []string
+
+	Status StatusResponse
+	Error  error
+}
+
+// Failed returns the chunks which failed, they can be retried with a new batch.
+func (resp *GrantBatchResponse) Failed() []GrantBatchChunk {
+	failed := []GrantBatchChunk{}
+	for _, chunk := range resp.Chunks {
+		if chunk.Error != nil {
+			failed = append(failed, chunk)
+		}
+	}
+
+	return failed
+}
+
+// Execute splits the auth keys, the channels and the channel groups in chunks
+// and runs a Grant request for each one. The responses of the successful
+// requests are merged, an error is returned if at least one request failed.
+func (b *grantBatchBuilder) Execute() (*GrantBatchResponse, StatusResponse, error) {
+	if err := b.validate(); err != nil {
+		return nil, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	chunks := b.chunks()
+	resp := &GrantBatchResponse{
+		GrantResponse: &GrantResponse{
+			Channels:      make(map[string]*PNPAMEntityData),
+			ChannelGroups: make(map[string]*PNPAMEntityData),
+			UUIDs:         make(map[string]*PNPAMEntityData),
+		},
+		Chunks: make([]GrantBatchChunk, len(chunks)),
+	}
+	responses := make([]*GrantResponse, len(chunks))
+
+	sem := make(chan bool, b.concurrency)
+	var wg sync.WaitGroup
+
+	for i, opts := range chunks {
+		wg.Add(1)
+		sem <- true
+
+		go func(i int, opts *grantOpts) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			chunk := GrantBatchChunk{
+				AuthKeys:      opts.AuthKeys,
+				Channels:      opts.Channels,
+				ChannelGroups: opts.ChannelGroups,
+			}
+
+			rawJSON, status, err := executeRequest(opts)
+			if err == nil {
+				responses[i], status, err = newGrantResponse(rawJSON, status)
+			}
+			chunk.Status, chunk.Error = status, err
+			resp.Chunks[i] = chunk
+		}(i, opts)
+	}
+	wg.Wait()
+
+	status := StatusResponse{}
+	failed := 0
+	var firstErr error
+	for i, chunk := range resp.Chunks {
+		if chunk.Error != nil {
+			if failed == 0 {
+				firstErr = chunk.Error
+				status = chunk.Status
+			}
+			failed++
+			continue
+		}
+		if failed == 0 {
+			status = chunk.Status
+		}
+		b.opts.pubnub.permissionCache.addGrantResponse(responses[i])
+		resp.mergeResponse(responses[i])
+	}
+
+	if failed > 0 {
+		return resp, status, fmt.Errorf("pubnub: %d of %d Grant requests failed: %s",
+			failed, len(chunks), firstErr.Error())
+	}
+
+	return resp, status, nil
+}
+
+// ExecuteAsync runs the Grant Batch request asynchronously, the result of the Future is a *GrantBatchResponse.
+func (b *grantBatchBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+func (b *grantBatchBuilder) validate() error {
+	if b.chunkSize <= 0 {
+		return newValidationError(b.opts, "ChunkSize must be greater than 0")
+	}
+
+	if b.concurrency <= 0 {
+		return newValidationError(b.opts, "Concurrency must be greater than 0")
+	}
+
+	return b.opts.validate()
+}
+
+// chunks returns the opts of the Grant requests, one for each chunk of auth
+// keys combined with each chunk of channels and of channel groups.
+func (b *grantBatchBuilder) chunks() []*grantOpts {
+	authChunks := splitNames(b.opts.AuthKeys, b.chunkSize, grantBatchMaxParamLength)
+	if len(authChunks) == 0 {
+		authChunks = [][]string{nil}
+	}
+
+	type resources struct {
+		channels []string
+		groups   []string
+	}
+	resourceChunks := []resources{}
+	for _, channels := range splitNames(b.opts.Channels, b.chunkSize, grantBatchMaxParamLength) {
+		resourceChunks = append(resourceChunks, resources{channels: channels})
+	}
+	for _, groups := range splitNames(b.opts.ChannelGroups, b.chunkSize, grantBatchMaxParamLength) {
+		resourceChunks = append(resourceChunks, resources{groups: groups})
+	}
+	if len(resourceChunks) == 0 {
+		resourceChunks = []resources{{}}
+	}
+
+	chunks := []*grantOpts{}
+	for _, authKeys := range authChunks {
+		for _, r := range resourceChunks {
+			opts := *b.opts
+			opts.AuthKeys = authKeys
+			opts.Channels = r.channels
+			opts.ChannelGroups = r.groups
+			chunks = append(chunks, &opts)
+		}
+	}
+
+	return chunks
+}
+
+// splitNames splits the names in chunks of at most maxCount names whose
+// comma separated list is at most maxLength characters once encoded.
+func splitNames(names []string, maxCount, maxLength int) [][]string {
+	chunks := [][]string{}
+	chunk := []string{}
+	length := 0
+
+	for _, name := range names {
+		nameLength := len(url.QueryEscape(name))
+		if len(chunk) > 0 {
+			nameLength++
+		}
+
+		if len(chunk) > 0 && (len(chunk) == maxCount || length+nameLength > maxLength) {
+			chunks = append(chunks, chunk)
+			chunk = []string{}
+			length = 0
+			nameLength--
+		}
+
+		chunk = append(chunk, name)
+		length += nameLength
+	}
+
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
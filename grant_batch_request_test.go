@@ -0,0 +1,149 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitNames(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([][]string{}, splitNames(nil, 2, 100))
+	assert.Equal([][]string{{"a", "b"}, {"c"}}, splitNames([]string{"a", "b", "c"}, 2, 100))
+
+	// "aaaa,bbbb" is 9 characters
+	assert.Equal([][]string{{"aaaa"}, {"bbbb"}}, splitNames([]string{"aaaa", "bbbb"}, 10, 8))
+	assert.Equal([][]string{{"aaaa", "bbbb"}}, splitNames([]string{"aaaa", "bbbb"}, 10, 9))
+
+	// The length is measured once encoded, "a b" is "a+b"
+	assert.Equal([][]string{{"a b"}, {"c"}}, splitNames([]string{"a b", "c"}, 10, 4))
+
+	// A name longer than the max length gets its own chunk
+	assert.Equal([][]string{{"a"}, {"toolong"}, {"b"}}, splitNames([]string{"a", "toolong", "b"}, 10, 3))
+}
+
+func TestGrantBatchChunks(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	channels := []string{}
+	for i := 0; i < 5; i++ {
+		channels = append(channels, fmt.Sprintf("ch%d", i))
+	}
+
+	b := newGrantBatchBuilder(pn).
+		AuthKeys([]string{"k1", "k2", "k3"}).
+		Channels(channels).
+		ChannelGroups([]string{"cg"}).
+		Read(true).
+		TTL(10).
+		ChunkSize(2)
+
+	chunks := b.chunks()
+	// 2 chunks of auth keys times 3 chunks of channels and 1 of groups
+	assert.Equal(8, len(chunks))
+
+	assert.Equal([]string{"k1", "k2"}, chunks[0].AuthKeys)
+	assert.Equal([]string{"ch0", "ch1"}, chunks[0].Channels)
+	assert.Nil(chunks[0].ChannelGroups)
+	assert.Equal([]string{"cg"}, chunks[3].ChannelGroups)
+	assert.Nil(chunks[3].Channels)
+	assert.Equal([]string{"k3"}, chunks[7].AuthKeys)
+
+	for _, opts := range chunks {
+		assert.True(opts.Read)
+		assert.Equal(10, opts.TTL)
+		assert.True(opts.setTTL)
+	}
+}
+
+func TestGrantBatchValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, _, err := newGrantBatchBuilder(pn).Channels([]string{"ch"}).ChunkSize(0).Execute()
+	assert.Contains(err.Error(), "ChunkSize must be greater than 0")
+
+	_, _, err = newGrantBatchBuilder(pn).Channels([]string{"ch"}).Concurrency(0).Execute()
+	assert.Contains(err.Error(), "Concurrency must be greater than 0")
+}
+
+func TestGrantBatchExecutePartialFailure(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v1/auth/grant/sub-key/demo",
+		Query:              "channel=ch1,ch2&r=1&w=0&m=0&d=0",
+		ResponseBody:       `{"message":"Success","payload":{"level":"channel","subscribe_key":"demo","ttl":1440,"channels":{"ch1":{"r":1,"w":0,"m":0,"d":0},"ch2":{"r":1,"w":0,"m":0,"d":0}}},"service":"Access Manager","status":200}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "timestamp", "signature", "l_pam"},
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v1/auth/grant/sub-key/demo",
+		Query:              "channel=ch3&r=1&w=0&m=0&d=0",
+		ResponseBody:       `{"message":"Forbidden","payload":{},"service":"Access Manager","status":403,"error":true}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "timestamp", "signature", "l_pam"},
+		ResponseStatusCode: 403,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.GrantBatch().
+		Channels([]string{"ch1", "ch2", "ch3"}).
+		Read(true).
+		ChunkSize(2).
+		Concurrency(1).
+		Execute()
+
+	assert.Contains(err.Error(), "1 of 2 Grant requests failed")
+	assert.Equal(2, len(res.Chunks))
+	assert.Nil(res.Chunks[0].Error)
+	assert.True(res.Channels["ch1"].ReadEnabled)
+	assert.True(res.Channels["ch2"].ReadEnabled)
+	assert.Nil(res.Channels["ch3"])
+
+	failed := res.Failed()
+	assert.Equal(1, len(failed))
+	assert.Equal([]string{"ch3"}, failed[0].Channels)
+	assert.Equal(403, failed[0].Status.StatusCode)
+}
+
+func TestRequestWorkerSignsAtSendTime(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/signed",
+		Query:              "signature=fresh",
+		ResponseBody:       `{}`,
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	queued, _ := url.Parse("http://ps.pndsn.com/signed?signature=stale")
+	req, _ := newRequest("GET", queued, nil, false)
+
+	j := make(chan *JobQResponse)
+	pn.jobQueue <- &JobQItem{
+		Req:         req,
+		Client:      interceptor.GetClient(),
+		JobResponse: j,
+		sign: func() (*url.URL, error) {
+			return url.Parse("http://ps.pndsn.com/signed?signature=fresh")
+		},
+	}
+	jr := <-j
+
+	assert.Nil(jr.Error)
+	assert.Equal(http.StatusOK, jr.Resp.StatusCode)
+	assert.True(strings.HasSuffix(req.URL.String(), "signature=fresh"))
+}
@@ -7,11 +7,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
 )
 
 // PNGrantType grant types
@@ -27,6 +29,12 @@ const (
 	PNManageEnabled
 	// PNDeleteEnabled Delete Enabled
 	PNDeleteEnabled
+	// PNGetEnabled Get Enabled
+	PNGetEnabled
+	// PNUpdateEnabled Update Enabled
+	PNUpdateEnabled
+	// PNJoinEnabled Join Enabled
+	PNJoinEnabled
 )
 
 var emptyGrantResponse *GrantResponse
@@ -80,6 +88,30 @@ func (b *grantBuilder) Delete(del bool) *grantBuilder {
 	return b
 }
 
+// Get sets the permission to get the metadata of the UUIDs or of the channels.
+func (b *grantBuilder) Get(get bool) *grantBuilder {
+	b.opts.Get = get
+	b.opts.setGet = true
+
+	return b
+}
+
+// Update sets the permission to update the metadata of the UUIDs or of the channels.
+func (b *grantBuilder) Update(update bool) *grantBuilder {
+	b.opts.Update = update
+	b.opts.setUpdate = true
+
+	return b
+}
+
+// Join sets the permission to join the channels.
+func (b *grantBuilder) Join(join bool) *grantBuilder {
+	b.opts.Join = join
+	b.opts.setJoin = true
+
+	return b
+}
+
 // TTL in minutes for which granted permissions are valid.
 //
 // Min: 1
@@ -101,7 +133,8 @@ func (b *grantBuilder) AuthKeys(authKeys []string) *grantBuilder {
 	return b
 }
 
-// Channels sets the Channels for the Grant request.
+// Channels sets the Channels for the Grant request. Wildcard channels, for ex. a.*, grant the permissions
+// to all the channels of the level.
 func (b *grantBuilder) Channels(channels []string) *grantBuilder {
 	b.opts.Channels = channels
 
@@ -115,6 +148,38 @@ func (b *grantBuilder) ChannelGroups(groups []string) *grantBuilder {
 	return b
 }
 
+// UUIDs sets the UUIDs for the Grant request, the Get, Update and Delete permissions apply to their metadata.
+// UUIDs can't be combined with channels or channel groups in the same request.
+func (b *grantBuilder) UUIDs(uuids []string) *grantBuilder {
+	b.opts.UUIDs = uuids
+
+	return b
+}
+
+// ChannelsPermissions sets different permissions for each channel,
+// in addition to the Channels granted the permissions of the builder.
+func (b *grantBuilder) ChannelsPermissions(channels map[string]ChannelPermissions) *grantBuilder {
+	b.opts.ChannelsPermissions = channels
+
+	return b
+}
+
+// ChannelGroupsPermissions sets different permissions for each channel group,
+// in addition to the ChannelGroups granted the permissions of the builder.
+func (b *grantBuilder) ChannelGroupsPermissions(groups map[string]GroupPermissions) *grantBuilder {
+	b.opts.ChannelGroupsPermissions = groups
+
+	return b
+}
+
+// UUIDsPermissions sets different permissions for each UUID,
+// in addition to the UUIDs granted the permissions of the builder.
+func (b *grantBuilder) UUIDsPermissions(uuids map[string]UUIDPermissions) *grantBuilder {
+	b.opts.UUIDsPermissions = uuids
+
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *grantBuilder) QueryParam(queryParam map[string]string) *grantBuilder {
 	b.opts.QueryParam = queryParam
@@ -122,14 +187,149 @@ func (b *grantBuilder) QueryParam(queryParam map[string]string) *grantBuilder {
 	return b
 }
 
-// Execute runs the Grant request.
+// Execute runs the Grant request. With per resource permissions one request
+// is sent for each distinct set of permissions and the responses are merged.
 func (b *grantBuilder) Execute() (*GrantResponse, StatusResponse, error) {
+	if len(b.opts.ChannelsPermissions) > 0 || len(b.opts.ChannelGroupsPermissions) > 0 ||
+		len(b.opts.UUIDsPermissions) > 0 {
+		return b.executeBatches(b.opts.permissionBatches())
+	}
+
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
 		return emptyGrantResponse, status, err
 	}
 
-	return newGrantResponse(rawJSON, status)
+	resp, status, err := newGrantResponse(rawJSON, status)
+	if err == nil {
+		b.opts.pubnub.permissionCache.addGrantResponse(resp)
+	}
+
+	return resp, status, err
+}
+
+// ExecuteAsync runs the Grant request asynchronously, the result of the Future is a *GrantResponse.
+func (b *grantBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+func (b *grantBuilder) executeBatches(batches []*grantOpts) (*GrantResponse, StatusResponse, error) {
+	if err := b.opts.validate(); err != nil {
+		return emptyGrantResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	merged := &GrantResponse{
+		Channels:      make(map[string]*PNPAMEntityData),
+		ChannelGroups: make(map[string]*PNPAMEntityData),
+		UUIDs:         make(map[string]*PNPAMEntityData),
+	}
+	status := StatusResponse{}
+
+	for _, opts := range batches {
+		rawJSON, batchStatus, err := executeRequest(opts)
+		if err != nil {
+			return emptyGrantResponse, batchStatus, err
+		}
+
+		resp, batchStatus, err := newGrantResponse(rawJSON, batchStatus)
+		if err != nil {
+			return emptyGrantResponse, batchStatus, err
+		}
+		b.opts.pubnub.permissionCache.addGrantResponse(resp)
+
+		merged.mergeResponse(resp)
+		status = batchStatus
+	}
+
+	return merged, status, nil
+}
+
+// permissionBatches groups the resources with the same permissions,
+// each group is sent in its own request.
+func (o *grantOpts) permissionBatches() []*grantOpts {
+	batches := []*grantOpts{}
+	byPermissions := make(map[string]*grantOpts)
+
+	batchFor := func(resourceType string, permissions interface{}, apply func(*grantOpts)) *grantOpts {
+		key := fmt.Sprintf("%s %+v", resourceType, permissions)
+		if batch, ok := byPermissions[key]; ok {
+			return batch
+		}
+		batch := &grantOpts{
+			pubnub:     o.pubnub,
+			ctx:        o.ctx,
+			AuthKeys:   o.AuthKeys,
+			QueryParam: o.QueryParam,
+			TTL:        o.TTL,
+			setTTL:     o.setTTL,
+		}
+		apply(batch)
+		byPermissions[key] = batch
+		batches = append(batches, batch)
+		return batch
+	}
+
+	if len(o.Channels) > 0 || len(o.ChannelGroups) > 0 || len(o.UUIDs) > 0 {
+		base := *o
+		base.ChannelsPermissions = nil
+		base.ChannelGroupsPermissions = nil
+		base.UUIDsPermissions = nil
+		batches = append(batches, &base)
+	}
+
+	for _, ch := range sortedKeys(o.ChannelsPermissions) {
+		p := o.ChannelsPermissions[ch]
+		batch := batchFor("channel", p, func(batch *grantOpts) {
+			batch.Read, batch.Write, batch.Manage, batch.Delete = p.Read, p.Write, p.Manage, p.Delete
+			batch.Get, batch.Update, batch.Join = p.Get, p.Update, p.Join
+			batch.setGet, batch.setUpdate, batch.setJoin = true, true, true
+		})
+		batch.Channels = append(batch.Channels, ch)
+	}
+
+	for _, cg := range sortedKeys(o.ChannelGroupsPermissions) {
+		p := o.ChannelGroupsPermissions[cg]
+		batch := batchFor("group", p, func(batch *grantOpts) {
+			batch.Read, batch.Manage = p.Read, p.Manage
+		})
+		batch.ChannelGroups = append(batch.ChannelGroups, cg)
+	}
+
+	for _, uuid := range sortedKeys(o.UUIDsPermissions) {
+		p := o.UUIDsPermissions[uuid]
+		batch := batchFor("uuid", p, func(batch *grantOpts) {
+			batch.Get, batch.Update, batch.Delete = p.Get, p.Update, p.Delete
+			batch.setGet, batch.setUpdate = true, true
+		})
+		batch.UUIDs = append(batch.UUIDs, uuid)
+	}
+
+	return batches
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+
+	switch v := m.(type) {
+	case map[string]ChannelPermissions:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]GroupPermissions:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]UUIDPermissions:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
 }
 
 type grantOpts struct {
@@ -139,14 +339,22 @@ type grantOpts struct {
 	AuthKeys      []string
 	Channels      []string
 	ChannelGroups []string
+	UUIDs         []string
 	QueryParam    map[string]string
 
+	ChannelsPermissions      map[string]ChannelPermissions
+	ChannelGroupsPermissions map[string]GroupPermissions
+	UUIDsPermissions         map[string]UUIDPermissions
+
 	// Stringified permissions
 	// Setting 'true' or 'false' will apply permissions to level
 	Read   bool
 	Write  bool
 	Manage bool
 	Delete bool
+	Get    bool
+	Update bool
+	Join   bool
 	// Max: 525600
 	// Min: 1
 	// Default: 1440
@@ -154,7 +362,10 @@ type grantOpts struct {
 	TTL int
 
 	// nil hacks
-	setTTL bool
+	setTTL    bool
+	setGet    bool
+	setUpdate bool
+	setJoin   bool
 }
 
 func (o *grantOpts) config() Config {
@@ -182,6 +393,26 @@ func (o *grantOpts) validate() error {
 		return newValidationError(o, StrMissingSecretKey)
 	}
 
+	if (len(o.UUIDs) > 0 || len(o.UUIDsPermissions) > 0) && len(o.AuthKeys) == 0 {
+		return newValidationError(o, "Missing AuthKeys for the UUIDs")
+	}
+
+	if len(o.UUIDs) > 0 && (len(o.Channels) > 0 || len(o.ChannelGroups) > 0) {
+		return newValidationError(o, "UUIDs can't be granted with Channels or ChannelGroups")
+	}
+
+	for _, ch := range o.Channels {
+		if err := utils.ValidateWildcardChannel(ch); err != nil {
+			return newValidationError(o, err.Error())
+		}
+	}
+
+	for ch := range o.ChannelsPermissions {
+		if err := utils.ValidateWildcardChannel(ch); err != nil {
+			return newValidationError(o, err.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -216,6 +447,18 @@ func (o *grantOpts) buildQuery() (*url.Values, error) {
 		q.Set("d", "0")
 	}
 
+	if o.setGet || len(o.UUIDs) > 0 {
+		q.Set("g", grantFlag(o.Get))
+	}
+
+	if o.setUpdate || len(o.UUIDs) > 0 {
+		q.Set("u", grantFlag(o.Update))
+	}
+
+	if o.setJoin {
+		q.Set("j", grantFlag(o.Join))
+	}
+
 	if len(o.AuthKeys) > 0 {
 		q.Set("auth", strings.Join(o.AuthKeys, ","))
 	}
@@ -228,6 +471,10 @@ func (o *grantOpts) buildQuery() (*url.Values, error) {
 		q.Set("channel-group", strings.Join(o.ChannelGroups, ","))
 	}
 
+	if len(o.UUIDs) > 0 {
+		q.Set("target-uuid", strings.Join(o.UUIDs, ","))
+	}
+
 	if o.setTTL {
 		if o.TTL >= -1 {
 			q.Set("ttl", fmt.Sprintf("%d", o.TTL))
@@ -241,6 +488,13 @@ func (o *grantOpts) buildQuery() (*url.Values, error) {
 	return q, nil
 }
 
+func grantFlag(enabled bool) string {
+	if enabled {
+		return "1"
+	}
+	return "0"
+}
+
 func (o *grantOpts) jobQueue() chan *JobQItem {
 	return o.pubnub.jobQueue
 }
@@ -282,11 +536,15 @@ type GrantResponse struct {
 
 	Channels      map[string]*PNPAMEntityData
 	ChannelGroups map[string]*PNPAMEntityData
+	UUIDs         map[string]*PNPAMEntityData
 
 	ReadEnabled   bool
 	WriteEnabled  bool
 	ManageEnabled bool
 	DeleteEnabled bool
+	GetEnabled    bool
+	UpdateEnabled bool
+	JoinEnabled   bool
 }
 
 // PNPAMEntityData is the struct containing the access details of the channels.
@@ -297,6 +555,9 @@ type PNPAMEntityData struct {
 	WriteEnabled  bool
 	ManageEnabled bool
 	DeleteEnabled bool
+	GetEnabled    bool
+	UpdateEnabled bool
+	JoinEnabled   bool
 	TTL           int
 }
 
@@ -306,9 +567,31 @@ type PNAccessManagerKeyData struct {
 	WriteEnabled  bool
 	ManageEnabled bool
 	DeleteEnabled bool
+	GetEnabled    bool
+	UpdateEnabled bool
+	JoinEnabled   bool
 	TTL           int
 }
 
+// mergeResponse adds the resources of another response of a batched Grant.
+func (resp *GrantResponse) mergeResponse(other *GrantResponse) {
+	if resp.Level == "" {
+		resp.Level = other.Level
+		resp.SubscribeKey = other.SubscribeKey
+		resp.TTL = other.TTL
+	}
+
+	for name, entity := range other.Channels {
+		resp.Channels[name] = entity
+	}
+	for name, entity := range other.ChannelGroups {
+		resp.ChannelGroups[name] = entity
+	}
+	for name, entity := range other.UUIDs {
+		resp.UUIDs[name] = entity
+	}
+}
+
 func newGrantResponse(jsonBytes []byte, status StatusResponse) (
 	*GrantResponse, StatusResponse, error) {
 	resp := &GrantResponse{}
@@ -365,13 +648,11 @@ func newGrantResponse(jsonBytes []byte, status StatusResponse) (
 		}
 
 		if groupMap, ok := val.(map[string]interface{}); ok {
-			groupName, _ := val.(string)
-			constructedAuthKey := make(map[string]*PNAccessManagerKeyData)
-			entityData := &PNPAMEntityData{
-				Name: groupName,
-			}
-
 			for groupName, value := range groupMap {
+				constructedAuthKey := make(map[string]*PNAccessManagerKeyData)
+				entityData := &PNPAMEntityData{
+					Name: groupName,
+				}
 				valueMap := value.(map[string]interface{})
 
 				if keys, ok := valueMap["auths"]; ok {
@@ -404,6 +685,15 @@ func newGrantResponse(jsonBytes []byte, status StatusResponse) (
 		}
 	}
 
+	constructedUUIDs := make(map[string]*PNPAMEntityData)
+	if val, ok := parsedPayload["uuids"]; ok {
+		uuidMap, _ := val.(map[string]interface{})
+
+		for uuid, value := range uuidMap {
+			constructedUUIDs[uuid] = fetchChannel(uuid, value, parsedPayload)
+		}
+	}
+
 	level, _ := parsedPayload["level"].(string)
 	subKey, _ := parsedPayload["subscribe_key"].(string)
 
@@ -411,6 +701,7 @@ func newGrantResponse(jsonBytes []byte, status StatusResponse) (
 	resp.SubscribeKey = subKey
 	resp.Channels = constructedChannels
 	resp.ChannelGroups = constructedGroups
+	resp.UUIDs = constructedUUIDs
 
 	if r, ok := parsedPayload["r"]; ok {
 		parsedValue, _ := r.(float64)
@@ -448,6 +739,21 @@ func newGrantResponse(jsonBytes []byte, status StatusResponse) (
 		}
 	}
 
+	if r, ok := parsedPayload["g"]; ok {
+		parsedValue, _ := r.(float64)
+		resp.GetEnabled = parsedValue == float64(1)
+	}
+
+	if r, ok := parsedPayload["u"]; ok {
+		parsedValue, _ := r.(float64)
+		resp.UpdateEnabled = parsedValue == float64(1)
+	}
+
+	if r, ok := parsedPayload["j"]; ok {
+		parsedValue, _ := r.(float64)
+		resp.JoinEnabled = parsedValue == float64(1)
+	}
+
 	if r, ok := parsedPayload["ttl"]; ok {
 		parsedValue, _ := r.(float64)
 		resp.TTL = int(parsedValue)
@@ -502,6 +808,12 @@ func readKeyData(val interface{}, keyData *PNAccessManagerKeyData, entityData *P
 			entityData.ManageEnabled = readValue
 		case PNDeleteEnabled:
 			entityData.DeleteEnabled = readValue
+		case PNGetEnabled:
+			entityData.GetEnabled = readValue
+		case PNUpdateEnabled:
+			entityData.UpdateEnabled = readValue
+		case PNJoinEnabled:
+			entityData.JoinEnabled = readValue
 		}
 	} else {
 		switch grantType {
@@ -513,6 +825,12 @@ func readKeyData(val interface{}, keyData *PNAccessManagerKeyData, entityData *P
 			keyData.ManageEnabled = readValue
 		case PNDeleteEnabled:
 			keyData.DeleteEnabled = readValue
+		case PNGetEnabled:
+			keyData.GetEnabled = readValue
+		case PNUpdateEnabled:
+			keyData.UpdateEnabled = readValue
+		case PNJoinEnabled:
+			keyData.JoinEnabled = readValue
 		}
 	}
 }
@@ -537,6 +855,18 @@ func createPNAccessManagerKeyData(value interface{}, entityData *PNPAMEntityData
 		readKeyData(val, keyData, entityData, writeToEntityData, PNDeleteEnabled)
 	}
 
+	if val, ok := valueMap["g"]; ok {
+		readKeyData(val, keyData, entityData, writeToEntityData, PNGetEnabled)
+	}
+
+	if val, ok := valueMap["u"]; ok {
+		readKeyData(val, keyData, entityData, writeToEntityData, PNUpdateEnabled)
+	}
+
+	if val, ok := valueMap["j"]; ok {
+		readKeyData(val, keyData, entityData, writeToEntityData, PNJoinEnabled)
+	}
+
 	if val, ok := valueMap["ttl"]; ok {
 		parsedVal, _ := val.(int)
 		entityData.TTL = parsedVal
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/tests/stubs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -376,3 +377,195 @@ func TestGrantTTL(t *testing.T) {
 	gb.TTL(10)
 	assert.Equal(10, gb.opts.TTL)
 }
+
+func TestGrantRequestUUIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &grantOpts{
+		AuthKeys: []string{"my-auth-key"},
+		UUIDs:    []string{"uuid-1", "uuid-2"},
+		Get:      true,
+		Delete:   true,
+		pubnub:   pubnub,
+	}
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("auth", "my-auth-key")
+	expected.Set("target-uuid", "uuid-1,uuid-2")
+	expected.Set("r", "0")
+	expected.Set("w", "0")
+	expected.Set("m", "0")
+	expected.Set("d", "1")
+	expected.Set("g", "1")
+	expected.Set("u", "0")
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+}
+
+func TestGrantRequestJoin(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	gb := newGrantBuilder(pn).Channels([]string{"a.*"}).Read(true).Join(true).Update(false)
+
+	query, err := gb.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("a.*", query.Get("channel"))
+	assert.Equal("1", query.Get("j"))
+	assert.Equal("0", query.Get("u"))
+	assert.Equal("", query.Get("g"))
+}
+
+func TestGrantOptsValidateUUIDs(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	opts := &grantOpts{
+		UUIDs:  []string{"uuid-1"},
+		pubnub: pn,
+	}
+	assert.Contains(opts.validate().Error(), "Missing AuthKeys for the UUIDs")
+
+	opts.AuthKeys = []string{"my-auth-key"}
+	assert.Nil(opts.validate())
+
+	opts.Channels = []string{"ch"}
+	assert.Contains(opts.validate().Error(), "UUIDs can't be granted with Channels or ChannelGroups")
+}
+
+func TestGrantOptsValidateWildcard(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	opts := &grantOpts{
+		Channels: []string{"a.b.*"},
+		pubnub:   pn,
+	}
+	assert.Nil(opts.validate())
+
+	opts.Channels = []string{"*"}
+	assert.Contains(opts.validate().Error(), "Invalid wildcard channel *")
+
+	opts.Channels = nil
+	opts.ChannelsPermissions = map[string]ChannelPermissions{"a.*b": {Read: true}}
+	assert.Contains(opts.validate().Error(), "Invalid wildcard channel a.*b")
+}
+
+func TestNewGrantResponseUUIDs(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"message":"Success","payload":{"level":"uuid","subscribe_key":"sub-c-b9ab9508-43cf-11e8-9967-869954283fb4","ttl":1440,"uuids":{"uuid-1":{"auths":{"my-auth-key":{"r":0,"w":0,"m":0,"d":1,"g":1,"u":1,"j":0}}}}},"service":"Access Manager","status":200}`)
+
+	e, _, err := newGrantResponse(jsonBytes, StatusResponse{})
+
+	assert.Nil(err)
+	assert.Equal("uuid", e.Level)
+	keyData := e.UUIDs["uuid-1"].AuthKeys["my-auth-key"]
+	assert.True(keyData.GetEnabled)
+	assert.True(keyData.UpdateEnabled)
+	assert.True(keyData.DeleteEnabled)
+	assert.False(keyData.JoinEnabled)
+	assert.False(keyData.ReadEnabled)
+	assert.Equal(1440, e.UUIDs["uuid-1"].TTL)
+}
+
+func TestNewGrantResponseJoinEnabled(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"message":"Success","payload":{"level":"channel","subscribe_key":"sub-c-b9ab9508-43cf-11e8-9967-869954283fb4","ttl":1440,"channels":{"ch1":{"r":1,"w":0,"m":0,"d":0,"j":1}}},"service":"Access Manager","status":200}`)
+
+	e, _, err := newGrantResponse(jsonBytes, StatusResponse{})
+
+	assert.Nil(err)
+	assert.True(e.Channels["ch1"].ReadEnabled)
+	assert.True(e.Channels["ch1"].JoinEnabled)
+	assert.False(e.Channels["ch1"].GetEnabled)
+}
+
+func TestGrantPermissionBatches(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	gb := newGrantBuilder(pn).
+		AuthKeys([]string{"my-auth-key"}).
+		Channels([]string{"ch"}).
+		Read(true).
+		TTL(60).
+		ChannelsPermissions(map[string]ChannelPermissions{
+			"ch2": {Read: true, Write: true},
+			"ch1": {Read: true, Write: true},
+			"ch3": {Read: true},
+		}).
+		ChannelGroupsPermissions(map[string]GroupPermissions{
+			"cg": {Manage: true},
+		}).
+		UUIDsPermissions(map[string]UUIDPermissions{
+			"uuid-1": {Get: true},
+		})
+
+	batches := gb.opts.permissionBatches()
+	assert.Equal(5, len(batches))
+
+	assert.Equal([]string{"ch"}, batches[0].Channels)
+	assert.True(batches[0].Read)
+	assert.Nil(batches[0].ChannelsPermissions)
+
+	assert.Equal([]string{"ch1", "ch2"}, batches[1].Channels)
+	assert.True(batches[1].Write)
+	assert.Equal([]string{"ch3"}, batches[2].Channels)
+	assert.False(batches[2].Write)
+
+	assert.Equal([]string{"cg"}, batches[3].ChannelGroups)
+	assert.True(batches[3].Manage)
+
+	assert.Equal([]string{"uuid-1"}, batches[4].UUIDs)
+	assert.True(batches[4].Get)
+
+	for _, batch := range batches {
+		assert.Equal([]string{"my-auth-key"}, batch.AuthKeys)
+		assert.Equal(60, batch.TTL)
+	}
+}
+
+func TestGrantExecutePerResourcePermissions(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v1/auth/grant/sub-key/demo",
+		Query:              "auth=my-auth-key&channel=ch1&r=1&w=1&m=0&d=0&g=0&u=0&j=0",
+		ResponseBody:       `{"message":"Success","payload":{"level":"user","subscribe_key":"demo","ttl":1440,"channel":"ch1","auths":{"my-auth-key":{"r":1,"w":1,"m":0,"d":0}}},"service":"Access Manager","status":200}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "timestamp", "signature", "l_pam"},
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v1/auth/grant/sub-key/demo",
+		Query:              "auth=my-auth-key&channel=ch2&r=1&w=0&m=0&d=0&g=0&u=0&j=0",
+		ResponseBody:       `{"message":"Success","payload":{"level":"user","subscribe_key":"demo","ttl":1440,"channel":"ch2","auths":{"my-auth-key":{"r":1,"w":0,"m":0,"d":0}}},"service":"Access Manager","status":200}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "timestamp", "signature", "l_pam"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.Grant().
+		AuthKeys([]string{"my-auth-key"}).
+		ChannelsPermissions(map[string]ChannelPermissions{
+			"ch1": {Read: true, Write: true},
+			"ch2": {Read: true},
+		}).
+		Execute()
+
+	assert.Nil(err)
+	assert.Equal("user", res.Level)
+	assert.True(res.Channels["ch1"].AuthKeys["my-auth-key"].WriteEnabled)
+	assert.False(res.Channels["ch2"].AuthKeys["my-auth-key"].WriteEnabled)
+
+	permissions, ok := pn.GetPermissionCache().Permissions("my-auth-key", "ch2")
+	assert.True(ok)
+	assert.True(permissions.ReadEnabled)
+	assert.False(permissions.WriteEnabled)
+}
@@ -0,0 +1,576 @@
+package pubnub
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+const grantTokenPath = "/v3/pam/%s/grant"
+
+const (
+	// MaxTokenTTL is the maximum TTL of a token in minutes.
+	MaxTokenTTL = 43200
+)
+
+// Token permission bits
+const (
+	pnTokenRead   = 1
+	pnTokenWrite  = 2
+	pnTokenManage = 4
+	pnTokenDelete = 8
+	pnTokenGet    = 32
+	pnTokenUpdate = 64
+	pnTokenJoin   = 128
+)
+
+var emptyGrantTokenResponse *PNGrantTokenResponse
+
+// ChannelPermissions contains the permissions of a channel or a channel pattern.
+type ChannelPermissions struct {
+	Read   bool
+	Write  bool
+	Manage bool
+	Delete bool
+	Get    bool
+	Update bool
+	Join   bool
+}
+
+// GroupPermissions contains the permissions of a channel group or a channel group pattern.
+type GroupPermissions struct {
+	Read   bool
+	Manage bool
+}
+
+// UUIDPermissions contains the permissions of a uuid or a uuid pattern.
+type UUIDPermissions struct {
+	Get    bool
+	Update bool
+	Delete bool
+}
+
+func (p ChannelPermissions) bitmask() int64 {
+	return tokenBitmask(map[int64]bool{
+		pnTokenRead:   p.Read,
+		pnTokenWrite:  p.Write,
+		pnTokenManage: p.Manage,
+		pnTokenDelete: p.Delete,
+		pnTokenGet:    p.Get,
+		pnTokenUpdate: p.Update,
+		pnTokenJoin:   p.Join,
+	})
+}
+
+func (p GroupPermissions) bitmask() int64 {
+	return tokenBitmask(map[int64]bool{
+		pnTokenRead:   p.Read,
+		pnTokenManage: p.Manage,
+	})
+}
+
+func (p UUIDPermissions) bitmask() int64 {
+	return tokenBitmask(map[int64]bool{
+		pnTokenGet:    p.Get,
+		pnTokenUpdate: p.Update,
+		pnTokenDelete: p.Delete,
+	})
+}
+
+func tokenBitmask(permissions map[int64]bool) int64 {
+	var bitmask int64
+	for bit, enabled := range permissions {
+		if enabled {
+			bitmask |= bit
+		}
+	}
+	return bitmask
+}
+
+func newChannelPermissions(bitmask int64) ChannelPermissions {
+	return ChannelPermissions{
+		Read:   bitmask&pnTokenRead != 0,
+		Write:  bitmask&pnTokenWrite != 0,
+		Manage: bitmask&pnTokenManage != 0,
+		Delete: bitmask&pnTokenDelete != 0,
+		Get:    bitmask&pnTokenGet != 0,
+		Update: bitmask&pnTokenUpdate != 0,
+		Join:   bitmask&pnTokenJoin != 0,
+	}
+}
+
+func newGroupPermissions(bitmask int64) GroupPermissions {
+	return GroupPermissions{
+		Read:   bitmask&pnTokenRead != 0,
+		Manage: bitmask&pnTokenManage != 0,
+	}
+}
+
+func newUUIDPermissions(bitmask int64) UUIDPermissions {
+	return UUIDPermissions{
+		Get:    bitmask&pnTokenGet != 0,
+		Update: bitmask&pnTokenUpdate != 0,
+		Delete: bitmask&pnTokenDelete != 0,
+	}
+}
+
+type grantTokenBuilder struct {
+	opts *grantTokenOpts
+}
+
+func newGrantTokenBuilder(pubnub *PubNub) *grantTokenBuilder {
+	builder := grantTokenBuilder{
+		opts: &grantTokenOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newGrantTokenBuilderWithContext(pubnub *PubNub, context Context) *grantTokenBuilder {
+	builder := grantTokenBuilder{
+		opts: &grantTokenOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// TTL in minutes for which the token is valid.
+//
+// Min: 1
+// Max: 43200
+func (b *grantTokenBuilder) TTL(ttl int) *grantTokenBuilder {
+	b.opts.TTL = ttl
+
+	return b
+}
+
+// AuthorizedUUID sets the only UUID allowed to use the token.
+func (b *grantTokenBuilder) AuthorizedUUID(uuid string) *grantTokenBuilder {
+	b.opts.AuthorizedUUID = uuid
+
+	return b
+}
+
+// Channels sets the permissions of the channels.
+func (b *grantTokenBuilder) Channels(channels map[string]ChannelPermissions) *grantTokenBuilder {
+	b.opts.Channels = channels
+
+	return b
+}
+
+// ChannelGroups sets the permissions of the channel groups.
+func (b *grantTokenBuilder) ChannelGroups(groups map[string]GroupPermissions) *grantTokenBuilder {
+	b.opts.ChannelGroups = groups
+
+	return b
+}
+
+// UUIDs sets the permissions of the uuids.
+func (b *grantTokenBuilder) UUIDs(uuids map[string]UUIDPermissions) *grantTokenBuilder {
+	b.opts.UUIDs = uuids
+
+	return b
+}
+
+// ChannelsPattern sets the permissions of the channels matching the regular expressions.
+func (b *grantTokenBuilder) ChannelsPattern(channels map[string]ChannelPermissions) *grantTokenBuilder {
+	b.opts.ChannelsPattern = channels
+
+	return b
+}
+
+// ChannelGroupsPattern sets the permissions of the channel groups matching the regular expressions.
+func (b *grantTokenBuilder) ChannelGroupsPattern(groups map[string]GroupPermissions) *grantTokenBuilder {
+	b.opts.ChannelGroupsPattern = groups
+
+	return b
+}
+
+// UUIDsPattern sets the permissions of the uuids matching the regular expressions.
+func (b *grantTokenBuilder) UUIDsPattern(uuids map[string]UUIDPermissions) *grantTokenBuilder {
+	b.opts.UUIDsPattern = uuids
+
+	return b
+}
+
+// Meta sets the extra data stored in the token, values must be scalars.
+func (b *grantTokenBuilder) Meta(meta map[string]interface{}) *grantTokenBuilder {
+	b.opts.Meta = meta
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *grantTokenBuilder) QueryParam(queryParam map[string]string) *grantTokenBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute runs the Grant Token request.
+func (b *grantTokenBuilder) Execute() (*PNGrantTokenResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGrantTokenResponse, status, err
+	}
+
+	return newGrantTokenResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Grant Token request asynchronously, the result of the Future is a *PNGrantTokenResponse.
+func (b *grantTokenBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type grantTokenOpts struct {
+	pubnub *PubNub
+	ctx    Context
+
+	TTL            int
+	AuthorizedUUID string
+
+	Channels             map[string]ChannelPermissions
+	ChannelGroups        map[string]GroupPermissions
+	UUIDs                map[string]UUIDPermissions
+	ChannelsPattern      map[string]ChannelPermissions
+	ChannelGroupsPattern map[string]GroupPermissions
+	UUIDsPattern         map[string]UUIDPermissions
+
+	Meta       map[string]interface{}
+	QueryParam map[string]string
+}
+
+func (o *grantTokenOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *grantTokenOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *grantTokenOpts) context() Context {
+	return o.ctx
+}
+
+func (o *grantTokenOpts) validate() error {
+	if o.config().PublishKey == "" {
+		return newValidationError(o, StrMissingPubKey)
+	}
+
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.config().SecretKey == "" {
+		return newValidationError(o, StrMissingSecretKey)
+	}
+
+	if o.TTL < 1 || o.TTL > MaxTokenTTL {
+		return newValidationError(o, fmt.Sprintf("Invalid TTL, min 1 and max %d", MaxTokenTTL))
+	}
+
+	if len(o.Channels) == 0 && len(o.ChannelGroups) == 0 && len(o.UUIDs) == 0 &&
+		len(o.ChannelsPattern) == 0 && len(o.ChannelGroupsPattern) == 0 && len(o.UUIDsPattern) == 0 {
+		return newValidationError(o, "Missing Resources or Patterns")
+	}
+
+	patterns := []string{}
+	for pattern := range o.ChannelsPattern {
+		patterns = append(patterns, pattern)
+	}
+	for pattern := range o.ChannelGroupsPattern {
+		patterns = append(patterns, pattern)
+	}
+	for pattern := range o.UUIDsPattern {
+		patterns = append(patterns, pattern)
+	}
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return newValidationError(o, fmt.Sprintf("Invalid pattern %s: %s", pattern, err.Error()))
+		}
+	}
+
+	for key, value := range o.Meta {
+		switch value.(type) {
+		case string, bool, int, int64, float64, nil:
+		default:
+			return newValidationError(o, fmt.Sprintf("Invalid meta %s, values must be scalars", key))
+		}
+	}
+
+	return nil
+}
+
+func (o *grantTokenOpts) buildPath() (string, error) {
+	return fmt.Sprintf(grantTokenPath, o.pubnub.Config.SubscribeKey), nil
+}
+
+func (o *grantTokenOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	timestamp := time.Now().Unix()
+	q.Set("timestamp", strconv.Itoa(int(timestamp)))
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *grantTokenOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *grantTokenOpts) buildBody() ([]byte, error) {
+	resources := map[string]interface{}{
+		"channels": map[string]int64{},
+		"groups":   map[string]int64{},
+		"uuids":    map[string]int64{},
+		"users":    map[string]int64{},
+		"spaces":   map[string]int64{},
+	}
+	patterns := map[string]interface{}{
+		"channels": map[string]int64{},
+		"groups":   map[string]int64{},
+		"uuids":    map[string]int64{},
+		"users":    map[string]int64{},
+		"spaces":   map[string]int64{},
+	}
+
+	for name, p := range o.Channels {
+		resources["channels"].(map[string]int64)[name] = p.bitmask()
+	}
+	for name, p := range o.ChannelGroups {
+		resources["groups"].(map[string]int64)[name] = p.bitmask()
+	}
+	for name, p := range o.UUIDs {
+		resources["uuids"].(map[string]int64)[name] = p.bitmask()
+	}
+	for name, p := range o.ChannelsPattern {
+		patterns["channels"].(map[string]int64)[name] = p.bitmask()
+	}
+	for name, p := range o.ChannelGroupsPattern {
+		patterns["groups"].(map[string]int64)[name] = p.bitmask()
+	}
+	for name, p := range o.UUIDsPattern {
+		patterns["uuids"].(map[string]int64)[name] = p.bitmask()
+	}
+
+	meta := o.Meta
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+
+	permissions := map[string]interface{}{
+		"resources": resources,
+		"patterns":  patterns,
+		"meta":      meta,
+	}
+	if o.AuthorizedUUID != "" {
+		permissions["uuid"] = o.AuthorizedUUID
+	}
+
+	body := map[string]interface{}{
+		"ttl":         o.TTL,
+		"permissions": permissions,
+	}
+
+	jsonEncBytes, errEnc := json.Marshal(body)
+	if errEnc != nil {
+		o.pubnub.Config.Log.Printf("ERROR: Serialization error: %s\n", errEnc.Error())
+		return []byte{}, errEnc
+	}
+	return jsonEncBytes, nil
+}
+
+func (o *grantTokenOpts) httpMethod() string {
+	return "POST"
+}
+
+func (o *grantTokenOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *grantTokenOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *grantTokenOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *grantTokenOpts) operationType() OperationType {
+	return PNAccessManagerGrantToken
+}
+
+func (o *grantTokenOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNGrantTokenResponse is the struct returned when the Execute function of GrantToken is called.
+type PNGrantTokenResponse struct {
+	Token string
+}
+
+func newGrantTokenResponse(jsonBytes []byte, status StatusResponse) (
+	*PNGrantTokenResponse, StatusResponse, error) {
+	var value map[string]interface{}
+
+	err := json.Unmarshal(jsonBytes, &value)
+	if err != nil {
+		e := pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+
+		return emptyGrantTokenResponse, status, e
+	}
+
+	data, _ := value["data"].(map[string]interface{})
+	token, _ := data["token"].(string)
+	if token == "" {
+		e := pnerr.NewResponseParsingError("Missing token in response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), nil)
+
+		return emptyGrantTokenResponse, status, e
+	}
+
+	return &PNGrantTokenResponse{Token: token}, status, nil
+}
+
+// PNTokenResources contains the permissions of the resources or of the patterns of a token.
+type PNTokenResources struct {
+	Channels      map[string]ChannelPermissions
+	ChannelGroups map[string]GroupPermissions
+	UUIDs         map[string]UUIDPermissions
+}
+
+// PNToken is the content of a token returned by GrantToken.
+type PNToken struct {
+	Version        int
+	Timestamp      int64
+	TTL            int
+	AuthorizedUUID string
+	Resources      PNTokenResources
+	Patterns       PNTokenResources
+	Meta           map[string]interface{}
+	Signature      []byte
+}
+
+// ParseToken decodes a token returned by GrantToken. The signature of the
+// token is not verified, it is only checked by the server.
+func ParseToken(token string) (*PNToken, error) {
+	raw, err := decodeTokenString(token)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid token: %s", err.Error())
+	}
+
+	value, err := utils.DecodeCBOR(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid token: %s", err.Error())
+	}
+
+	tokenMap, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("Invalid token: not a map")
+	}
+
+	version, vok := tokenMap["v"].(int64)
+	timestamp, tok := tokenMap["t"].(int64)
+	ttl, ttlok := tokenMap["ttl"].(int64)
+	signature, sigok := tokenMap["sig"].([]byte)
+	if !vok || !tok || !ttlok || !sigok {
+		return nil, errors.New("Invalid token: missing v, t, ttl or sig")
+	}
+
+	resources, err := parseTokenResources(tokenMap["res"])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid token resources: %s", err.Error())
+	}
+
+	patterns, err := parseTokenResources(tokenMap["pat"])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid token patterns: %s", err.Error())
+	}
+
+	parsed := &PNToken{
+		Version:   int(version),
+		Timestamp: timestamp,
+		TTL:       int(ttl),
+		Resources: resources,
+		Patterns:  patterns,
+		Signature: signature,
+	}
+
+	if uuid, ok := tokenMap["uuid"].(string); ok {
+		parsed.AuthorizedUUID = uuid
+	}
+
+	if meta, ok := tokenMap["meta"].(map[string]interface{}); ok {
+		parsed.Meta = meta
+	}
+
+	return parsed, nil
+}
+
+// decodeTokenString decodes the URL safe base64 string of a token, with or without padding.
+func decodeTokenString(token string) ([]byte, error) {
+	token = strings.TrimRight(token, "=")
+	token = strings.NewReplacer("-", "+", "_", "/").Replace(token)
+
+	return base64.RawStdEncoding.DecodeString(token)
+}
+
+func parseTokenResources(value interface{}) (PNTokenResources, error) {
+	resources := PNTokenResources{
+		Channels:      make(map[string]ChannelPermissions),
+		ChannelGroups: make(map[string]GroupPermissions),
+		UUIDs:         make(map[string]UUIDPermissions),
+	}
+
+	resourcesMap, ok := value.(map[string]interface{})
+	if !ok {
+		return resources, errors.New("not a map")
+	}
+
+	for resourceType, permissions := range resourcesMap {
+		permissionsMap, ok := permissions.(map[string]interface{})
+		if !ok {
+			return resources, fmt.Errorf("%s is not a map", resourceType)
+		}
+
+		for name, bitmask := range permissionsMap {
+			b, ok := bitmask.(int64)
+			if !ok {
+				return resources, fmt.Errorf("invalid permissions of %s", name)
+			}
+
+			switch resourceType {
+			case "chan":
+				resources.Channels[name] = newChannelPermissions(b)
+			case "grp":
+				resources.ChannelGroups[name] = newGroupPermissions(b)
+			case "uuid":
+				resources.UUIDs[name] = newUUIDPermissions(b)
+			}
+		}
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,286 @@
+package pubnub
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeTestCBOR encodes the values used in the tokens.
+func encodeTestCBOR(value interface{}) []byte {
+	head := func(major byte, n int) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 256:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 65536:
+			return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+		}
+		return []byte{major<<5 | 26, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+	}
+
+	switch v := value.(type) {
+	case int:
+		return head(0, v)
+	case string:
+		return append(head(3, len(v)), v...)
+	case []byte:
+		return append(head(2, len(v)), v...)
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b := head(5, len(v))
+		for _, k := range keys {
+			b = append(b, encodeTestCBOR([]byte(k))...)
+			b = append(b, encodeTestCBOR(v[k])...)
+		}
+		return b
+	}
+	return []byte{0xf6}
+}
+
+func newTestToken() string {
+	token := map[string]interface{}{
+		"v":   2,
+		"t":   1619718521,
+		"ttl": 15,
+		"res": map[string]interface{}{
+			"chan": map[string]interface{}{"ch1": 239},
+			"grp":  map[string]interface{}{"cg1": 5},
+			"uuid": map[string]interface{}{"user1": 104},
+			"usr":  map[string]interface{}{},
+			"spc":  map[string]interface{}{},
+		},
+		"pat": map[string]interface{}{
+			"chan": map[string]interface{}{"^ch-.*$": 3},
+			"grp":  map[string]interface{}{},
+			"uuid": map[string]interface{}{},
+		},
+		"meta": map[string]interface{}{"role": "admin"},
+		"uuid": "authorized-uuid",
+		"sig":  []byte{1, 2, 3, 4},
+	}
+
+	return base64.URLEncoding.EncodeToString(encodeTestCBOR(token))
+}
+
+func TestGrantTokenRequestBasic(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &grantTokenOpts{
+		TTL:            60,
+		AuthorizedUUID: "my-uuid",
+		Channels: map[string]ChannelPermissions{
+			"ch": {Read: true, Write: true, Join: true},
+		},
+		ChannelGroups: map[string]GroupPermissions{
+			"cg": {Read: true, Manage: true},
+		},
+		UUIDsPattern: map[string]UUIDPermissions{
+			"^user-.*": {Get: true, Update: true},
+		},
+		Meta:   map[string]interface{}{"role": "admin"},
+		pubnub: pubnub,
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	u := &url.URL{
+		Path: path,
+	}
+
+	h.AssertPathsEqual(t,
+		fmt.Sprintf("/v3/pam/%s/grant", opts.pubnub.Config.SubscribeKey),
+		u.EscapedPath(), []int{})
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+
+	body, err := opts.buildBody()
+	assert.Nil(err)
+
+	var decoded map[string]interface{}
+	assert.Nil(json.Unmarshal(body, &decoded))
+	assert.Equal(float64(60), decoded["ttl"])
+
+	permissions := decoded["permissions"].(map[string]interface{})
+	assert.Equal("my-uuid", permissions["uuid"])
+	assert.Equal(map[string]interface{}{"role": "admin"}, permissions["meta"])
+
+	resources := permissions["resources"].(map[string]interface{})
+	assert.Equal(map[string]interface{}{"ch": float64(131)}, resources["channels"])
+	assert.Equal(map[string]interface{}{"cg": float64(5)}, resources["groups"])
+	assert.Equal(map[string]interface{}{}, resources["uuids"])
+
+	patterns := permissions["patterns"].(map[string]interface{})
+	assert.Equal(map[string]interface{}{"^user-.*": float64(96)}, patterns["uuids"])
+}
+
+func TestGrantTokenOptsValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	opts := &grantTokenOpts{
+		TTL:    60,
+		pubnub: pn,
+	}
+
+	assert.Contains(opts.validate().Error(), "Missing Resources or Patterns")
+
+	opts.ChannelsPattern = map[string]ChannelPermissions{"ch-(": {Read: true}}
+	assert.Contains(opts.validate().Error(), "Invalid pattern ch-(")
+
+	opts.ChannelsPattern = map[string]ChannelPermissions{"ch-.*": {Read: true}}
+	assert.Nil(opts.validate())
+
+	opts.Meta = map[string]interface{}{"nested": map[string]interface{}{}}
+	assert.Contains(opts.validate().Error(), "Invalid meta nested")
+
+	opts.Meta = nil
+	opts.TTL = MaxTokenTTL + 1
+	assert.Contains(opts.validate().Error(), "Invalid TTL")
+
+	opts.TTL = 60
+	pn.Config.SecretKey = ""
+	assert.Contains(opts.validate().Error(), "Missing Secret Key")
+}
+
+func TestGrantTokenSignatureV2(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	opts := &grantTokenOpts{
+		TTL:      60,
+		Channels: map[string]ChannelPermissions{"ch": {Read: true}},
+		pubnub:   pn,
+	}
+
+	u, err := buildURL(opts)
+	assert.Nil(err)
+	signature := u.Query().Get("signature")
+	assert.True(strings.HasPrefix(signature, "v2."), signature)
+	assert.False(strings.HasSuffix(signature, "="), signature)
+}
+
+func TestNewGrantTokenResponse(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"status":200,"data":{"message":"Success","token":"p0F2AkF0Gl"},"service":"Access Manager"}`)
+
+	resp, _, err := newGrantTokenResponse(jsonBytes, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal("p0F2AkF0Gl", resp.Token)
+
+	_, _, err = newGrantTokenResponse([]byte(`{"status":200,"data":{}}`), StatusResponse{})
+	assert.Contains(err.Error(), "Missing token in response")
+
+	_, _, err = newGrantTokenResponse([]byte(`s`), StatusResponse{})
+	assert.Contains(err.Error(), "Error unmarshalling response")
+}
+
+func TestParseToken(t *testing.T) {
+	assert := assert.New(t)
+
+	token, err := ParseToken(newTestToken())
+	assert.Nil(err)
+	assert.Equal(2, token.Version)
+	assert.Equal(int64(1619718521), token.Timestamp)
+	assert.Equal(15, token.TTL)
+	assert.Equal("authorized-uuid", token.AuthorizedUUID)
+	assert.Equal([]byte{1, 2, 3, 4}, token.Signature)
+	assert.Equal(map[string]interface{}{"role": "admin"}, token.Meta)
+	assert.Equal(ChannelPermissions{Read: true, Write: true, Manage: true, Delete: true, Get: true, Update: true, Join: true},
+		token.Resources.Channels["ch1"])
+	assert.Equal(GroupPermissions{Read: true, Manage: true}, token.Resources.ChannelGroups["cg1"])
+	assert.Equal(UUIDPermissions{Get: true, Update: true, Delete: true}, token.Resources.UUIDs["user1"])
+	assert.Equal(ChannelPermissions{Read: true, Write: true}, token.Patterns.Channels["^ch-.*$"])
+
+	_, err = ParseToken(strings.TrimRight(newTestToken(), "="))
+	assert.Nil(err)
+}
+
+func TestParseTokenInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ParseToken("not a token")
+	assert.Contains(err.Error(), "Invalid token")
+
+	_, err = ParseToken(base64.URLEncoding.EncodeToString(encodeTestCBOR("string")))
+	assert.Contains(err.Error(), "not a map")
+
+	_, err = ParseToken(base64.URLEncoding.EncodeToString(encodeTestCBOR(map[string]interface{}{
+		"v": 2,
+	})))
+	assert.Contains(err.Error(), "missing v, t, ttl or sig")
+
+	_, err = ParseToken(base64.URLEncoding.EncodeToString(encodeTestCBOR(map[string]interface{}{
+		"v": 2, "t": 1, "ttl": 1, "sig": []byte{1},
+		"res": map[string]interface{}{"chan": map[string]interface{}{"ch": "r"}},
+		"pat": map[string]interface{}{},
+	})))
+	assert.Contains(err.Error(), "Invalid token resources: invalid permissions of ch")
+}
+
+func TestSetToken(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.AuthKey = "auth-key"
+
+	opts := &timeOpts{
+		pubnub: pn,
+	}
+
+	u, err := buildURL(opts)
+	assert.Nil(err)
+	assert.Equal("auth-key", u.Query().Get("auth"))
+
+	pn.SetToken("my-token")
+	assert.Equal("my-token", pn.GetToken())
+
+	u, err = buildURL(opts)
+	assert.Nil(err)
+	assert.Equal("my-token", u.Query().Get("auth"))
+
+	pn.SetToken("")
+	u, err = buildURL(opts)
+	assert.Nil(err)
+	assert.Equal("auth-key", u.Query().Get("auth"))
+}
+
+func TestSetTokenConcurrentRequests(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	opts := &timeOpts{
+		pubnub: pn,
+	}
+
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			pn.SetToken(fmt.Sprintf("token-%d", i))
+		}
+		done <- true
+	}()
+
+	for i := 0; i < 100; i++ {
+		_, err := buildURL(opts)
+		assert.Nil(err)
+	}
+	<-done
+
+	assert.Equal("token-99", pn.GetToken())
+}
@@ -22,20 +22,51 @@ type HeartbeatManager struct {
 	hbRunning                 bool
 	queryParam                map[string]string
 	state                     map[string]interface{}
+	sessionsMutex             sync.RWMutex
+	sessions                  map[*HeartbeatSession]bool
 }
 
 func newHeartbeatManager(pn *PubNub, context Context) *HeartbeatManager {
 	return &HeartbeatManager{
 		heartbeatChannels: make(map[string]*SubscriptionItem),
 		heartbeatGroups:   make(map[string]*SubscriptionItem),
+		sessions:          make(map[*HeartbeatSession]bool),
 		ctx:               context,
 		pubnub:            pn,
 	}
 }
 
-// Destroy stops the running heartbeat.
+// Destroy stops the running heartbeat and all the heartbeat sessions.
 func (m *HeartbeatManager) Destroy() {
 	m.stopHeartbeat(true, true)
+	for _, session := range m.getSessions() {
+		if session.stop() {
+			m.removeSession(session)
+		}
+	}
+}
+
+func (m *HeartbeatManager) addSession(session *HeartbeatSession) {
+	m.sessionsMutex.Lock()
+	m.sessions[session] = true
+	m.sessionsMutex.Unlock()
+}
+
+func (m *HeartbeatManager) removeSession(session *HeartbeatSession) {
+	m.sessionsMutex.Lock()
+	delete(m.sessions, session)
+	m.sessionsMutex.Unlock()
+}
+
+func (m *HeartbeatManager) getSessions() []*HeartbeatSession {
+	m.sessionsMutex.RLock()
+	defer m.sessionsMutex.RUnlock()
+
+	sessions := []*HeartbeatSession{}
+	for session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
 }
 
 func (m *HeartbeatManager) startHeartbeatTimer(runIndependentOfSubscribe bool) {
@@ -67,6 +67,20 @@ func (b *heartbeatBuilder) ChannelGroups(cg []string) *heartbeatBuilder {
 	return b
 }
 
+// UUID sets the UUID to announce in the Heartbeat request, defaults to the UUID in the config.
+func (b *heartbeatBuilder) UUID(uuid string) *heartbeatBuilder {
+	b.opts.UUID = uuid
+
+	return b
+}
+
+// PresenceTimeout sets the presence timeout for the Heartbeat request, defaults to the PresenceTimeout in the config.
+func (b *heartbeatBuilder) PresenceTimeout(timeout int) *heartbeatBuilder {
+	b.opts.PresenceTimeout = timeout
+
+	return b
+}
+
 // Execute runs the Heartbeat request
 func (b *heartbeatBuilder) Execute() (interface{}, StatusResponse, error) {
 	rawJSON, status, err := executeRequest(b.opts)
@@ -84,14 +98,24 @@ func (b *heartbeatBuilder) Execute() (interface{}, StatusResponse, error) {
 	return value, status, nil
 }
 
+// ExecuteAsync runs the Heartbeat request asynchronously, see Future.
+func (b *heartbeatBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type heartbeatOpts struct {
 	pubnub *PubNub
 
 	State interface{}
 
-	Channels      []string
-	ChannelGroups []string
-	QueryParam    map[string]string
+	Channels        []string
+	ChannelGroups   []string
+	QueryParam      map[string]string
+	UUID            string
+	PresenceTimeout int
 
 	ctx Context
 }
@@ -129,9 +153,17 @@ func (o *heartbeatOpts) buildPath() (string, error) {
 }
 
 func (o *heartbeatOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	uuid := o.pubnub.Config.UUID
+	if o.UUID != "" {
+		uuid = o.UUID
+	}
+	q := defaultQuery(uuid, o.pubnub.telemetryManager)
 
-	q.Set("heartbeat", strconv.Itoa(o.pubnub.Config.PresenceTimeout))
+	presenceTimeout := o.pubnub.Config.PresenceTimeout
+	if o.PresenceTimeout > 0 {
+		presenceTimeout = o.PresenceTimeout
+	}
+	q.Set("heartbeat", strconv.Itoa(presenceTimeout))
 
 	if len(o.ChannelGroups) > 0 {
 		q.Set("channel-group", strings.Join(o.ChannelGroups, ","))
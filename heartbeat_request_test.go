@@ -122,3 +122,20 @@ func TestHeartbeatValidateSubKey(t *testing.T) {
 	err := opts.validate()
 	assert.Equal("pubnub/validation: pubnub: 	: Missing Subscribe Key", err.Error())
 }
+
+func TestHeartbeatRequestUUIDAndTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &heartbeatOpts{
+		pubnub:          pubnub,
+		Channels:        []string{"ch"},
+		UUID:            "worker-1",
+		PresenceTimeout: 42,
+	}
+
+	u, err := opts.buildQuery()
+	assert.Nil(err)
+
+	assert.Equal("worker-1", u.Get("uuid"))
+	assert.Equal("42", u.Get("heartbeat"))
+}
@@ -0,0 +1,390 @@
+package pubnub
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+// HeartbeatSession announces the presence of a single UUID on its own set of
+// channels and channel groups. Sessions run independently of the subscribe
+// loop and of each other, so one PubNub instance can keep several UUIDs
+// online at the same time.
+type HeartbeatSession struct {
+	sync.RWMutex
+
+	pubnub *PubNub
+	ctx    Context
+
+	uuid            string
+	channels        []string
+	channelGroups   []string
+	state           map[string]interface{}
+	interval        int
+	presenceTimeout int
+	queryParam      map[string]string
+	onStatus        func(*PNStatus)
+
+	running   bool
+	done      chan bool
+	lastError error
+}
+
+type heartbeatSessionBuilder struct {
+	opts *heartbeatSessionOpts
+}
+
+type heartbeatSessionOpts struct {
+	pubnub *PubNub
+
+	UUID            string
+	Channels        []string
+	ChannelGroups   []string
+	State           map[string]interface{}
+	Interval        int
+	PresenceTimeout int
+	QueryParam      map[string]string
+	OnStatus        func(*PNStatus)
+
+	ctx Context
+}
+
+func newHeartbeatSessionBuilder(pubnub *PubNub) *heartbeatSessionBuilder {
+	builder := heartbeatSessionBuilder{
+		opts: &heartbeatSessionOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newHeartbeatSessionBuilderWithContext(pubnub *PubNub,
+	context Context) *heartbeatSessionBuilder {
+	builder := heartbeatSessionBuilder{
+		opts: &heartbeatSessionOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// UUID sets the UUID announced by the session, defaults to the UUID in the config.
+func (b *heartbeatSessionBuilder) UUID(uuid string) *heartbeatSessionBuilder {
+	b.opts.UUID = uuid
+
+	return b
+}
+
+// Channels sets the Channels on which the session announces presence.
+func (b *heartbeatSessionBuilder) Channels(ch []string) *heartbeatSessionBuilder {
+	b.opts.Channels = ch
+
+	return b
+}
+
+// ChannelGroups sets the ChannelGroups on which the session announces presence.
+func (b *heartbeatSessionBuilder) ChannelGroups(cg []string) *heartbeatSessionBuilder {
+	b.opts.ChannelGroups = cg
+
+	return b
+}
+
+// State sets the state sent with every heartbeat of the session.
+func (b *heartbeatSessionBuilder) State(state map[string]interface{}) *heartbeatSessionBuilder {
+	b.opts.State = state
+
+	return b
+}
+
+// Interval sets the frequency of the heartbeats in seconds, defaults to the HeartbeatInterval in the config.
+func (b *heartbeatSessionBuilder) Interval(interval int) *heartbeatSessionBuilder {
+	b.opts.Interval = interval
+
+	return b
+}
+
+// PresenceTimeout sets the presence timeout of the session in seconds, defaults to the PresenceTimeout in the config.
+func (b *heartbeatSessionBuilder) PresenceTimeout(timeout int) *heartbeatSessionBuilder {
+	b.opts.PresenceTimeout = timeout
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *heartbeatSessionBuilder) QueryParam(queryParam map[string]string) *heartbeatSessionBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// OnStatus sets the handler that receives the status of every heartbeat of the session.
+// When no handler is set the statuses are announced to the listeners.
+func (b *heartbeatSessionBuilder) OnStatus(handler func(*PNStatus)) *heartbeatSessionBuilder {
+	b.opts.OnStatus = handler
+
+	return b
+}
+
+// Execute validates the options and starts the heartbeat session.
+func (b *heartbeatSessionBuilder) Execute() (*HeartbeatSession, error) {
+	if err := b.opts.validate(); err != nil {
+		return nil, err
+	}
+
+	session := newHeartbeatSession(b.opts)
+	b.opts.pubnub.heartbeatManager.addSession(session)
+	session.start()
+
+	return session, nil
+}
+
+func (o *heartbeatSessionOpts) validate() error {
+	if o.pubnub.Config.SubscribeKey == "" {
+		return pnerr.NewValidationError(PNHeartBeatOperation.String(), StrMissingSubKey)
+	}
+
+	if len(o.Channels) == 0 && len(o.ChannelGroups) == 0 {
+		return pnerr.NewValidationError(PNHeartBeatOperation.String(), "Missing Channel or Channel Group")
+	}
+
+	if o.Interval <= 0 && o.pubnub.Config.HeartbeatInterval <= 0 {
+		return pnerr.NewValidationError(PNHeartBeatOperation.String(), "Missing Heartbeat Interval")
+	}
+
+	return nil
+}
+
+func newHeartbeatSession(opts *heartbeatSessionOpts) *HeartbeatSession {
+	session := &HeartbeatSession{
+		pubnub:          opts.pubnub,
+		ctx:             opts.ctx,
+		uuid:            opts.UUID,
+		channels:        channelsFromPresence(opts.Channels),
+		channelGroups:   channelsFromPresence(opts.ChannelGroups),
+		state:           opts.State,
+		interval:        opts.Interval,
+		presenceTimeout: opts.PresenceTimeout,
+		queryParam:      opts.QueryParam,
+		onStatus:        opts.OnStatus,
+	}
+
+	if session.uuid == "" {
+		session.uuid = opts.pubnub.Config.UUID
+	}
+
+	if session.interval <= 0 {
+		session.interval = opts.pubnub.Config.HeartbeatInterval
+	}
+
+	return session
+}
+
+func channelsFromPresence(names []string) []string {
+	response := []string{}
+
+	for _, name := range names {
+		response = append(response, utils.ChannelFromPresence(name))
+	}
+	return response
+}
+
+// UUID returns the UUID announced by the session.
+func (s *HeartbeatSession) UUID() string {
+	return s.uuid
+}
+
+// Channels returns the channels on which the session announces presence.
+func (s *HeartbeatSession) Channels() []string {
+	return s.channels
+}
+
+// ChannelGroups returns the channel groups on which the session announces presence.
+func (s *HeartbeatSession) ChannelGroups() []string {
+	return s.channelGroups
+}
+
+// IsRunning returns true until the session is stopped.
+func (s *HeartbeatSession) IsRunning() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.running
+}
+
+// LastError returns the error of the latest heartbeat, nil if it succeeded.
+func (s *HeartbeatSession) LastError() error {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.lastError
+}
+
+// SetState replaces the state sent with the following heartbeats of the session.
+func (s *HeartbeatSession) SetState(state map[string]interface{}) {
+	s.Lock()
+	s.state = state
+	s.Unlock()
+}
+
+// Stop stops the heartbeats of the session and, unless SuppressLeaveEvents
+// is set in the config, sends a leave for the session UUID.
+func (s *HeartbeatSession) Stop() {
+	if !s.stop() {
+		return
+	}
+	s.pubnub.heartbeatManager.removeSession(s)
+
+	if s.pubnub.Config.SuppressLeaveEvents {
+		return
+	}
+
+	_, err := newLeaveBuilder(s.pubnub).
+		UUID(s.uuid).
+		Channels(s.channels).
+		ChannelGroups(s.channelGroups).
+		QueryParam(s.queryParam).
+		Execute()
+
+	if err != nil {
+		s.announceStatus(&PNStatus{
+			Category:              PNBadRequestCategory,
+			Operation:             PNUnsubscribeOperation,
+			Error:                 true,
+			ErrorData:             err,
+			UUID:                  s.uuid,
+			AffectedChannels:      s.channels,
+			AffectedChannelGroups: s.channelGroups,
+		})
+	}
+}
+
+func (s *HeartbeatSession) start() {
+	s.Lock()
+	s.running = true
+	s.done = make(chan bool)
+	done := s.done
+	s.Unlock()
+
+	s.pubnub.Config.Log.Println(fmt.Sprintf("heartbeat session %s: start, interval %d", s.uuid, s.interval))
+
+	var ctxDone <-chan struct{}
+	if s.ctx != nil {
+		ctxDone = s.ctx.Done()
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(s.interval) * time.Second)
+		defer ticker.Stop()
+
+		s.performHeartbeat()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.performHeartbeat()
+			case <-done:
+				s.pubnub.Config.Log.Println(fmt.Sprintf("heartbeat session %s: loop after stop", s.uuid))
+				return
+			case <-ctxDone:
+				s.pubnub.Config.Log.Println(fmt.Sprintf("heartbeat session %s: context done", s.uuid))
+				if s.stop() {
+					s.pubnub.heartbeatManager.removeSession(s)
+				}
+				return
+			case <-s.pubnub.ctx.Done():
+				s.pubnub.Config.Log.Println(fmt.Sprintf("heartbeat session %s: pubnub.ctx.Done", s.uuid))
+				if s.stop() {
+					s.pubnub.heartbeatManager.removeSession(s)
+				}
+				return
+			}
+		}
+	}()
+}
+
+// stop returns false if the session was already stopped.
+func (s *HeartbeatSession) stop() bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.running {
+		return false
+	}
+	s.running = false
+	close(s.done)
+
+	return true
+}
+
+func (s *HeartbeatSession) performHeartbeat() {
+	s.RLock()
+	state := s.state
+	s.RUnlock()
+
+	builder := newHeartbeatBuilder(s.pubnub)
+	if s.ctx != nil {
+		builder = newHeartbeatBuilderWithContext(s.pubnub, s.ctx)
+	}
+
+	builder.
+		UUID(s.uuid).
+		Channels(s.channels).
+		ChannelGroups(s.channelGroups).
+		PresenceTimeout(s.presenceTimeout).
+		QueryParam(s.queryParam)
+
+	if len(state) > 0 {
+		builder.State(state)
+	}
+
+	_, status, err := builder.Execute()
+
+	s.Lock()
+	s.lastError = err
+	s.Unlock()
+
+	if err != nil {
+		category := status.Category
+		if category == 0 || category == PNUnknownCategory {
+			category = PNBadRequestCategory
+		}
+		pnStatus := &PNStatus{
+			Operation:             PNHeartBeatOperation,
+			Category:              category,
+			Error:                 true,
+			ErrorData:             err,
+			StatusCode:            status.StatusCode,
+			UUID:                  s.uuid,
+			AffectedChannels:      s.channels,
+			AffectedChannelGroups: s.channelGroups,
+		}
+		s.pubnub.Config.Log.Println("heartbeat session: err", err, pnStatus)
+		s.announceStatus(pnStatus)
+
+		return
+	}
+
+	s.announceStatus(&PNStatus{
+		Category:              PNUnknownCategory,
+		Error:                 false,
+		Operation:             PNHeartBeatOperation,
+		StatusCode:            status.StatusCode,
+		UUID:                  s.uuid,
+		AffectedChannels:      s.channels,
+		AffectedChannelGroups: s.channelGroups,
+	})
+}
+
+func (s *HeartbeatSession) announceStatus(status *PNStatus) {
+	if s.onStatus != nil {
+		s.onStatus(status)
+		return
+	}
+	s.pubnub.subscriptionManager.listenerManager.announceStatus(status)
+}
@@ -0,0 +1,137 @@
+package pubnub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatSessionValidateChAndCg(t *testing.T) {
+	assert := assert.New(t)
+
+	session, err := newHeartbeatSessionBuilder(pubnub).Interval(10).Execute()
+	assert.Nil(session)
+	assert.Contains(err.Error(), "Missing Channel or Channel Group")
+}
+
+func TestHeartbeatSessionValidateInterval(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.HeartbeatInterval = 0
+
+	session, err := newHeartbeatSessionBuilder(pn).Channels([]string{"ch"}).Execute()
+	assert.Nil(session)
+	assert.Contains(err.Error(), "Missing Heartbeat Interval")
+}
+
+func TestHeartbeatSessionDefaults(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.HeartbeatInterval = 20
+
+	session := newHeartbeatSession(&heartbeatSessionOpts{
+		pubnub:   pn,
+		Channels: []string{"ch-pnpres", "ch2"},
+	})
+
+	assert.Equal(pn.Config.UUID, session.UUID())
+	assert.Equal(20, session.interval)
+	assert.Equal([]string{"ch", "ch2"}, session.Channels())
+}
+
+func TestHeartbeatSessionsRunConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/presence/sub-key/demo/channel/ch1/heartbeat",
+		Query:              "heartbeat=60&uuid=worker-1",
+		ResponseBody:       `{"status": 200, "message": "OK", "service": "Presence"}`,
+		IgnoreQueryKeys:    []string{"pnsdk"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.Config.PresenceTimeout = 60
+	pn.SetClient(interceptor.GetClient())
+
+	statuses1 := make(chan *PNStatus, 10)
+	statuses2 := make(chan *PNStatus, 10)
+
+	session1, err := pn.StartHeartbeatSession().
+		UUID("worker-1").
+		Channels([]string{"ch1"}).
+		Interval(1).
+		OnStatus(func(status *PNStatus) {
+			statuses1 <- status
+		}).
+		Execute()
+	assert.Nil(err)
+
+	session2, err := pn.StartHeartbeatSession().
+		UUID("worker-2").
+		Channels([]string{"ch2"}).
+		Interval(1).
+		OnStatus(func(status *PNStatus) {
+			statuses2 <- status
+		}).
+		Execute()
+	assert.Nil(err)
+	assert.Equal(2, len(pn.GetHeartbeatSessions()))
+
+	select {
+	case status := <-statuses1:
+		assert.False(status.Error, "%v", status.ErrorData)
+		assert.Equal("worker-1", status.UUID)
+		assert.Equal([]string{"ch1"}, status.AffectedChannels)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no status for worker-1")
+	}
+
+	select {
+	case status := <-statuses2:
+		assert.True(status.Error)
+		assert.Equal("worker-2", status.UUID)
+		assert.Equal([]string{"ch2"}, status.AffectedChannels)
+		assert.NotNil(session2.LastError())
+	case <-time.After(5 * time.Second):
+		assert.Fail("no status for worker-2")
+	}
+
+	pn.Config.SuppressLeaveEvents = true
+	session1.Stop()
+	assert.False(session1.IsRunning())
+	assert.True(session2.IsRunning())
+	assert.Equal(1, len(pn.GetHeartbeatSessions()))
+
+	pn.heartbeatManager.Destroy()
+	assert.False(session2.IsRunning())
+	assert.Equal(0, len(pn.GetHeartbeatSessions()))
+}
+
+func TestHeartbeatSessionRemovedOnPubNubContextDone(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	session, err := pn.StartHeartbeatSession().
+		Channels([]string{"ch"}).
+		Interval(60).
+		Execute()
+	assert.Nil(err)
+	assert.Equal(1, len(pn.GetHeartbeatSessions()))
+
+	pn.cancel()
+
+	for i := 0; i < 100 && len(pn.GetHeartbeatSessions()) > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(session.IsRunning())
+	assert.Equal(0, len(pn.GetHeartbeatSessions()))
+}
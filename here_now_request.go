@@ -90,6 +90,14 @@ func (b *hereNowBuilder) Execute() (*HereNowResponse, StatusResponse, error) {
 	return newHereNowResponse(rawJSON, b.opts.Channels, status)
 }
 
+// ExecuteAsync runs the HereNow request asynchronously, the result of the Future is a *HereNowResponse.
+func (b *hereNowBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type hereNowOpts struct {
 	pubnub *PubNub
 
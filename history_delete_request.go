@@ -82,6 +82,14 @@ func (b *historyDeleteBuilder) Execute() (*HistoryDeleteResponse, StatusResponse
 	return emptyHistoryDeleteResp, status, nil
 }
 
+// ExecuteAsync runs the DeleteMessages request asynchronously, the result of the Future is a *HistoryDeleteResponse.
+func (b *historyDeleteBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type historyDeleteOpts struct {
 	pubnub *PubNub
 
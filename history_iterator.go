@@ -0,0 +1,337 @@
+package pubnub
+
+import (
+	"sort"
+)
+
+type historyIteratorBuilder struct {
+	opts *historyIteratorOpts
+}
+
+type historyIteratorOpts struct {
+	pubnub *PubNub
+
+	Channels   []string
+	Start      int64
+	End        int64
+	QueryParam map[string]string
+
+	// default: maxCount
+	PageSize int
+
+	// default: false, newest messages first
+	Reverse bool
+
+	// default: false
+	Prefetch bool
+
+	// nil hacks
+	setStart bool
+	setEnd   bool
+
+	ctx Context
+}
+
+func newHistoryIteratorBuilder(pubnub *PubNub) *historyIteratorBuilder {
+	builder := historyIteratorBuilder{
+		opts: &historyIteratorOpts{
+			pubnub:   pubnub,
+			PageSize: maxCount,
+		},
+	}
+
+	return &builder
+}
+
+func newHistoryIteratorBuilderWithContext(pubnub *PubNub,
+	context Context) *historyIteratorBuilder {
+	builder := newHistoryIteratorBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose history is iterated.
+func (b *historyIteratorBuilder) Channel(ch string) *historyIteratorBuilder {
+	b.opts.Channels = []string{ch}
+	return b
+}
+
+// Channels sets the Channels whose history is iterated, the messages of all
+// the channels are returned ordered by timetoken.
+func (b *historyIteratorBuilder) Channels(channels []string) *historyIteratorBuilder {
+	b.opts.Channels = channels
+	return b
+}
+
+// Start sets the Start Timetoken (exclusive) of the time window.
+// The newer bound of the window, or the older one with Reverse.
+func (b *historyIteratorBuilder) Start(start int64) *historyIteratorBuilder {
+	b.opts.Start = start
+	b.opts.setStart = true
+	return b
+}
+
+// End sets the End Timetoken (inclusive) of the time window.
+// The older bound of the window, or the newer one with Reverse.
+func (b *historyIteratorBuilder) End(end int64) *historyIteratorBuilder {
+	b.opts.End = end
+	b.opts.setEnd = true
+	return b
+}
+
+// Reverse iterates from the oldest message to the newest one.
+func (b *historyIteratorBuilder) Reverse(r bool) *historyIteratorBuilder {
+	b.opts.Reverse = r
+	return b
+}
+
+// PageSize sets the number of messages of each History request, at most 100.
+func (b *historyIteratorBuilder) PageSize(size int) *historyIteratorBuilder {
+	b.opts.PageSize = size
+	return b
+}
+
+// Prefetch requests the next page of a channel while the current one is iterated.
+func (b *historyIteratorBuilder) Prefetch(prefetch bool) *historyIteratorBuilder {
+	b.opts.Prefetch = prefetch
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URLs called by the API.
+func (b *historyIteratorBuilder) QueryParam(queryParam map[string]string) *historyIteratorBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute validates the options and returns the iterator, the History
+// requests are run while iterating.
+func (b *historyIteratorBuilder) Execute() (*HistoryIterator, error) {
+	if err := b.opts.validate(); err != nil {
+		return nil, err
+	}
+
+	it := &HistoryIterator{
+		opts:    b.opts,
+		cursors: make([]*historyCursor, len(b.opts.Channels)),
+	}
+	for i, ch := range b.opts.Channels {
+		it.cursors[i] = &historyCursor{
+			channel:  ch,
+			start:    b.opts.Start,
+			setStart: b.opts.setStart,
+		}
+	}
+
+	return it, nil
+}
+
+func (o *historyIteratorOpts) validate() error {
+	h := &historyOpts{pubnub: o.pubnub}
+
+	if o.pubnub.Config.SubscribeKey == "" {
+		return newValidationError(h, StrMissingSubKey)
+	}
+
+	if len(o.Channels) == 0 {
+		return newValidationError(h, StrMissingChannel)
+	}
+
+	for _, ch := range o.Channels {
+		if ch == "" {
+			return newValidationError(h, StrMissingChannel)
+		}
+	}
+
+	if o.PageSize <= 0 || o.PageSize > maxCount {
+		return newValidationError(h, "PageSize must be between 1 and 100")
+	}
+
+	return nil
+}
+
+// HistoryIteratorItem is a message returned by the HistoryIterator.
+type HistoryIteratorItem struct {
+	Channel   string
+	Message   interface{}
+	Timetoken int64
+}
+
+// HistoryIterator pages through the history of one or more channels.
+//
+//	for it.Next() {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type HistoryIterator struct {
+	opts    *historyIteratorOpts
+	cursors []*historyCursor
+	item    HistoryIteratorItem
+	err     error
+}
+
+type historyCursor struct {
+	channel string
+
+	// Messages of the current page in the order of the iteration
+	buffer []HistoryIteratorItem
+
+	start    int64
+	setStart bool
+	done     bool
+
+	pending chan historyPage
+}
+
+type historyPage struct {
+	items []HistoryIteratorItem
+	err   error
+}
+
+// Next advances to the next message, it returns false when all the messages
+// of the time window are returned or when a request fails, see Err.
+func (it *HistoryIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	var next *historyCursor
+
+	for _, c := range it.cursors {
+		if err := it.fill(c); err != nil {
+			it.err = err
+			return false
+		}
+
+		if len(c.buffer) == 0 {
+			continue
+		}
+		if next == nil || it.before(c.buffer[0].Timetoken, next.buffer[0].Timetoken) {
+			next = c
+		}
+	}
+
+	if next == nil {
+		return false
+	}
+
+	it.item = next.buffer[0]
+	next.buffer = next.buffer[1:]
+
+	return true
+}
+
+// Item returns the current message.
+func (it *HistoryIterator) Item() HistoryIteratorItem {
+	return it.item
+}
+
+// Err returns the error of the failed request which stopped the iteration.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+func (it *HistoryIterator) before(a, b int64) bool {
+	if it.opts.Reverse {
+		return a < b
+	}
+	return a > b
+}
+
+// fill loads the next page of the cursor when its buffer is empty.
+func (it *HistoryIterator) fill(c *historyCursor) error {
+	if len(c.buffer) > 0 {
+		return nil
+	}
+
+	if c.pending == nil {
+		if c.done {
+			return nil
+		}
+		it.request(c)
+	}
+
+	page := <-c.pending
+	c.pending = nil
+	if page.err != nil {
+		return page.err
+	}
+
+	c.buffer = page.items
+
+	if it.opts.Prefetch && !c.done {
+		it.request(c)
+	}
+
+	return nil
+}
+
+// request runs the History request of the next page of the cursor and
+// advances the cursor.
+func (it *HistoryIterator) request(c *historyCursor) {
+	pending := make(chan historyPage, 1)
+	c.pending = pending
+
+	opts := &historyOpts{
+		pubnub:           it.opts.pubnub,
+		ctx:              it.opts.ctx,
+		Channel:          c.channel,
+		Start:            c.start,
+		setStart:         c.setStart,
+		End:              it.opts.End,
+		setEnd:           it.opts.setEnd,
+		Count:            it.opts.PageSize,
+		Reverse:          it.opts.Reverse,
+		IncludeTimetoken: true,
+		QueryParam:       it.opts.QueryParam,
+	}
+
+	// The cursor advances only with the response, mark it as done to avoid
+	// a second request for the same page
+	c.done = true
+
+	run := func() {
+		pending <- it.runPage(c, opts)
+	}
+
+	if it.opts.Prefetch {
+		go run()
+	} else {
+		run()
+	}
+}
+
+func (it *HistoryIterator) runPage(c *historyCursor, opts *historyOpts) historyPage {
+	rawJSON, _, err := executeRequest(opts)
+	if err != nil {
+		return historyPage{err: err}
+	}
+
+	resp, _, err := newHistoryResponse(rawJSON, opts, StatusResponse{})
+	if err != nil {
+		return historyPage{err: err}
+	}
+
+	items := make([]HistoryIteratorItem, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		items = append(items, HistoryIteratorItem{
+			Channel:   c.channel,
+			Message:   m.Message,
+			Timetoken: m.Timetoken,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return it.before(items[i].Timetoken, items[j].Timetoken)
+	})
+
+	// The next page starts after the last message of this page
+	if len(items) > 0 && len(resp.Messages) >= opts.Count {
+		c.start = items[len(items)-1].Timetoken
+		c.setStart = true
+		c.done = false
+	}
+
+	return historyPage{items: items}
+}
@@ -0,0 +1,24 @@
+//go:build go1.23
+// +build go1.23
+
+package pubnub
+
+import "iter"
+
+// All returns the messages of the iterator for a range loop, the iteration
+// stops at the first failed request and yields its error.
+//
+//	for item, err := range it.All() {
+//	}
+func (it *HistoryIterator) All() iter.Seq2[HistoryIteratorItem, error] {
+	return func(yield func(HistoryIteratorItem, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(HistoryIteratorItem{}, err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+// +build go1.23
+
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryIteratorAll(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(newHistoryIteratorInterceptor().GetClient())
+
+	it, err := pn.HistoryIterator().Channel("ch1").PageSize(2).Execute()
+	assert.Nil(err)
+
+	messages := []interface{}{}
+	for item, err := range it.All() {
+		assert.Nil(err)
+		messages = append(messages, item.Message)
+		if len(messages) == 2 {
+			break
+		}
+	}
+	assert.Equal([]interface{}{"m3", "m2"}, messages)
+
+	it, err = pn.HistoryIterator().Channel("ch3").PageSize(2).Execute()
+	assert.Nil(err)
+
+	for _, err := range it.All() {
+		assert.NotNil(err)
+	}
+}
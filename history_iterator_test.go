@@ -0,0 +1,135 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+var historyIteratorIgnoredKeys = []string{"pnsdk", "uuid", "l_hist"}
+
+func newHistoryIteratorInterceptor() *stubs.Interceptor {
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch1",
+		Query:              "count=2&reverse=false&include_token=true",
+		ResponseBody:       `[[{"message":"m2","timetoken":15341234567890002},{"message":"m3","timetoken":15341234567890003}],15341234567890002,15341234567890003]`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch1",
+		Query:              "count=2&reverse=false&include_token=true&start=15341234567890002",
+		ResponseBody:       `[[{"message":"m1","timetoken":15341234567890001}],15341234567890001,15341234567890001]`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch1",
+		Query:              "count=2&reverse=true&include_token=true&start=15341234567890000",
+		ResponseBody:       `[[{"message":"m1","timetoken":15341234567890001},{"message":"m2","timetoken":15341234567890002}],15341234567890001,15341234567890002]`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch1",
+		Query:              "count=2&reverse=true&include_token=true&start=15341234567890002",
+		ResponseBody:       `[[{"message":"m3","timetoken":15341234567890003}],15341234567890003,15341234567890003]`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch2",
+		Query:              "count=2&reverse=true&include_token=true&start=15341234567890000",
+		ResponseBody:       `[[{"message":"n1","timetoken":15341234567890004}],15341234567890004,15341234567890004]`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/history/sub-key/demo/channel/ch3",
+		Query:              "count=2&reverse=false&include_token=true",
+		ResponseBody:       `{"error":true}`,
+		IgnoreQueryKeys:    historyIteratorIgnoredKeys,
+		ResponseStatusCode: 400,
+	})
+
+	return interceptor
+}
+
+func collectHistory(it *HistoryIterator) ([]string, []string) {
+	messages := []string{}
+	channels := []string{}
+	for it.Next() {
+		messages = append(messages, it.Item().Message.(string))
+		channels = append(channels, it.Item().Channel)
+	}
+	return messages, channels
+}
+
+func TestHistoryIteratorNewestFirst(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(newHistoryIteratorInterceptor().GetClient())
+
+	it, err := pn.HistoryIterator().Channel("ch1").PageSize(2).Execute()
+	assert.Nil(err)
+
+	messages, _ := collectHistory(it)
+	assert.Nil(it.Err())
+	assert.Equal([]string{"m3", "m2", "m1"}, messages)
+	assert.False(it.Next())
+}
+
+func TestHistoryIteratorReverseChannelsPrefetch(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(newHistoryIteratorInterceptor().GetClient())
+
+	it, err := pn.HistoryIterator().
+		Channels([]string{"ch2", "ch1"}).
+		Start(15341234567890000).
+		Reverse(true).
+		PageSize(2).
+		Prefetch(true).
+		Execute()
+	assert.Nil(err)
+
+	messages, channels := collectHistory(it)
+	assert.Nil(it.Err())
+	assert.Equal([]string{"m1", "m2", "m3", "n1"}, messages)
+	assert.Equal([]string{"ch1", "ch1", "ch1", "ch2"}, channels)
+}
+
+func TestHistoryIteratorError(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(newHistoryIteratorInterceptor().GetClient())
+
+	it, err := pn.HistoryIterator().Channels([]string{"ch1", "ch3"}).PageSize(2).Execute()
+	assert.Nil(err)
+
+	assert.False(it.Next())
+	assert.NotNil(it.Err())
+	assert.False(it.Next())
+}
+
+func TestHistoryIteratorValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, err := pn.HistoryIterator().Execute()
+	assert.Contains(err.Error(), "Missing Channel")
+
+	_, err = pn.HistoryIterator().Channel("ch").PageSize(101).Execute()
+	assert.Contains(err.Error(), "PageSize must be between 1 and 100")
+}
@@ -82,6 +82,24 @@ func (b *historyBuilder) IncludeTimetoken(i bool) *historyBuilder {
 	return b
 }
 
+// IncludeMeta tells the server to send the meta published with each history item.
+func (b *historyBuilder) IncludeMeta(withMeta bool) *historyBuilder {
+	b.opts.IncludeMeta = withMeta
+	return b
+}
+
+// IncludeUUID tells the server to send the UUID of the publisher of each history item.
+func (b *historyBuilder) IncludeUUID(withUUID bool) *historyBuilder {
+	b.opts.IncludeUUID = withUUID
+	return b
+}
+
+// IncludeMessageType tells the server to send the type of each history item.
+func (b *historyBuilder) IncludeMessageType(withMessageType bool) *historyBuilder {
+	b.opts.IncludeMessageType = withMessageType
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *historyBuilder) QueryParam(queryParam map[string]string) *historyBuilder {
 	b.opts.QueryParam = queryParam
@@ -105,6 +123,14 @@ func (b *historyBuilder) Execute() (*HistoryResponse, StatusResponse, error) {
 	return newHistoryResponse(rawJSON, b.opts, status)
 }
 
+// ExecuteAsync runs the History request asynchronously, the result of the Future is a *HistoryResponse.
+func (b *historyBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type historyOpts struct {
 	pubnub *PubNub
 
@@ -123,6 +149,11 @@ type historyOpts struct {
 	// default: false
 	IncludeTimetoken bool
 
+	// default: false
+	IncludeMeta        bool
+	IncludeUUID        bool
+	IncludeMessageType bool
+
 	// nil hacks
 	setStart bool
 	setEnd   bool
@@ -181,6 +212,7 @@ func (o *historyOpts) buildQuery() (*url.Values, error) {
 
 	q.Set("reverse", strconv.FormatBool(o.Reverse))
 	q.Set("include_token", strconv.FormatBool(o.IncludeTimetoken))
+	setHistoryIncludes(q, o.IncludeMeta, o.IncludeUUID, o.IncludeMessageType)
 
 	SetQueryParam(q, o.QueryParam)
 
@@ -227,9 +259,23 @@ type HistoryResponse struct {
 }
 
 // HistoryResponseItem is used to store the Message and the associated timetoken from the History request.
+// Meta, UUID and MessageType are set when requested with IncludeMeta,
+// IncludeUUID and IncludeMessageType.
 type HistoryResponseItem struct {
-	Message   interface{}
-	Timetoken int64
+	Message     interface{}
+	Timetoken   int64
+	Meta        interface{}
+	UUID        string
+	MessageType int
+}
+
+// historyResponseItemRaw is a history item sent with its timetoken or its meta.
+type historyResponseItemRaw struct {
+	Message     interface{}
+	Timetoken   int64
+	Meta        interface{}
+	UUID        string
+	MessageType interface{} `json:"message_type"`
 }
 
 func logAndCreateNewResponseParsingError(o *historyOpts, err error, jsonBody string, message string) *pnerr.ResponseParsingError {
@@ -252,12 +298,12 @@ func getHistoryItemsWithoutTimetoken(historyResponseRaw []byte, o *historyOpts,
 
 	for i, v := range historyResponseItems {
 		o.pubnub.Config.Log.Println(v)
-		items[i].Message, _ = parseCipherInterface(v, o.pubnub.Config)
+		items[i].Message, _ = parseChannelCipherInterface(v, o.pubnub.Config, o.Channel)
 	}
 	return items, nil
 }
 
-func getHistoryItemsWithTimetoken(historyResponseItems []HistoryResponseItem, o *historyOpts, historyResponseRaw []byte, jsonBytes []byte) ([]HistoryResponseItem, *pnerr.ResponseParsingError) {
+func getHistoryItemsWithTimetoken(historyResponseItems []historyResponseItemRaw, o *historyOpts, historyResponseRaw []byte, jsonBytes []byte) ([]HistoryResponseItem, *pnerr.ResponseParsingError) {
 	items := make([]HistoryResponseItem, len(historyResponseItems))
 
 	b := false
@@ -265,10 +311,13 @@ func getHistoryItemsWithTimetoken(historyResponseItems []HistoryResponseItem, o
 	for i, v := range historyResponseItems {
 		if v.Message != nil {
 			o.pubnub.Config.Log.Println(v.Message)
-			items[i].Message, _ = parseCipherInterface(v.Message, o.pubnub.Config)
+			items[i].Message, _ = parseChannelCipherInterface(v.Message, o.pubnub.Config, o.Channel)
 
 			o.pubnub.Config.Log.Println(v.Timetoken)
 			items[i].Timetoken = v.Timetoken
+			items[i].Meta = v.Meta
+			items[i].UUID = v.UUID
+			items[i].MessageType = parseHistoryMessageType(v.MessageType)
 		} else {
 			b = true
 			break
@@ -301,7 +350,7 @@ func newHistoryResponse(jsonBytes []byte, o *historyOpts,
 		o.pubnub.Config.Log.Println("T1", string(historyResponseRaw[1]))
 		o.pubnub.Config.Log.Println("T2", string(historyResponseRaw[2]))
 
-		var historyResponseItems []HistoryResponseItem
+		var historyResponseItems []historyResponseItemRaw
 		var items []HistoryResponseItem
 
 		err1 := json.Unmarshal(historyResponseRaw[0], &historyResponseItems)
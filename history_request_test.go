@@ -455,3 +455,35 @@ func TestHistoryResponseEndTTError(t *testing.T) {
 
 	//assert.Equal("pubnub/parsing: Error parsing response: {[[{\"message\":[1,2,3,[\"one\",\"two\",\"three\"]],\"timetoken\":1111}],121324,\"a\"]}", err.Error())
 }
+
+func TestHistoryRequestIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newHistoryBuilder(pubnub).
+		Channel("ch").
+		IncludeMeta(true).
+		IncludeUUID(true).
+		IncludeMessageType(true)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("true", query.Get("include_meta"))
+	assert.Equal("true", query.Get("include_uuid"))
+	assert.Equal("true", query.Get("include_message_type"))
+}
+
+func TestHistoryResponseParsingWithIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	jsonString := []byte(`[[{"message":"hi","timetoken":15232761410327866,"meta":{"lang":"en"},"uuid":"publisher","message_type":"4"}],15232761410327866,15232761410327866]`)
+
+	resp, _, err := newHistoryResponse(jsonString, initHistoryOpts(), fakeResponseState)
+	assert.Nil(err)
+
+	messages := resp.Messages
+	assert.Equal("hi", messages[0].Message)
+	assert.Equal(int64(15232761410327866), messages[0].Timetoken)
+	assert.Equal(map[string]interface{}{"lang": "en"}, messages[0].Meta)
+	assert.Equal("publisher", messages[0].UUID)
+	assert.Equal(4, messages[0].MessageType)
+}
@@ -47,6 +47,12 @@ func (b *leaveBuilder) ChannelGroups(groups []string) *leaveBuilder {
 	return b
 }
 
+// UUID sets the UUID that leaves the channels, defaults to the UUID in the config.
+func (b *leaveBuilder) UUID(uuid string) *leaveBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *leaveBuilder) QueryParam(queryParam map[string]string) *leaveBuilder {
 	b.opts.QueryParam = queryParam
@@ -64,10 +70,19 @@ func (b *leaveBuilder) Execute() (StatusResponse, error) {
 	return status, nil
 }
 
+// ExecuteAsync runs the Leave request asynchronously, the Future has no result.
+func (b *leaveBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		status, err := b.Execute()
+		return nil, status, err
+	})
+}
+
 type leaveOpts struct {
 	Channels      []string
 	ChannelGroups []string
 	QueryParam    map[string]string
+	UUID          string
 
 	pubnub *PubNub
 	ctx    Context
@@ -98,7 +113,11 @@ func (o *leaveOpts) jobQueue() chan *JobQItem {
 }
 
 func (o *leaveOpts) buildQuery() (*url.Values, error) {
-	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+	uuid := o.pubnub.Config.UUID
+	if o.UUID != "" {
+		uuid = o.UUID
+	}
+	q := defaultQuery(uuid, o.pubnub.telemetryManager)
 
 	if len(o.ChannelGroups) > 0 {
 		channelGroup := utils.JoinChannels(o.ChannelGroups)
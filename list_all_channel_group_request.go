@@ -67,6 +67,14 @@ func (b *allChannelGroupBuilder) Execute() (
 	return newAllChannelGroupResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the ListChannelsInChannelGroup request asynchronously, the result of the Future is a *AllChannelGroupResponse.
+func (b *allChannelGroupBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type allChannelGroupOpts struct {
 	pubnub *PubNub
 
@@ -14,6 +14,8 @@ import (
 
 const listChannelsOfPushPath = "/v1/push/sub-key/%s/devices/%s"
 
+const listChannelsOfPushPathAPNS2 = "/v2/push/sub-key/%s/devices-apns2/%s"
+
 var emptyListPushProvisionsRequestResponse *ListPushProvisionsRequestResponse
 
 type listPushProvisionsRequestBuilder struct {
@@ -56,6 +58,18 @@ func (b *listPushProvisionsRequestBuilder) DeviceIDForPush(
 	return b
 }
 
+// Topic sets the APNS topic (bundle ID) of the app, required with PNPushTypeAPNS2.
+func (b *listPushProvisionsRequestBuilder) Topic(topic string) *listPushProvisionsRequestBuilder {
+	b.opts.Topic = topic
+	return b
+}
+
+// Environment sets the APNS environment of the device, development (the default) or production, only used with PNPushTypeAPNS2.
+func (b *listPushProvisionsRequestBuilder) Environment(env PNPushEnvironment) *listPushProvisionsRequestBuilder {
+	b.opts.Environment = env
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *listPushProvisionsRequestBuilder) QueryParam(queryParam map[string]string) *listPushProvisionsRequestBuilder {
 	b.opts.QueryParam = queryParam
@@ -74,6 +88,14 @@ func (b *listPushProvisionsRequestBuilder) Execute() (
 	return newListPushProvisionsRequestResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the List Push Provisions request asynchronously, the result of the Future is a *ListPushProvisionsRequestResponse.
+func (b *listPushProvisionsRequestBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 func newListPushProvisionsRequestResponse(jsonBytes []byte, status StatusResponse) (
 	*ListPushProvisionsRequestResponse, StatusResponse, error) {
 	resp := &ListPushProvisionsRequestResponse{}
@@ -102,7 +124,9 @@ func newListPushProvisionsRequestResponse(jsonBytes []byte, status StatusRespons
 type listPushProvisionsRequestOpts struct {
 	pubnub *PubNub
 
-	PushType PNPushType
+	PushType    PNPushType
+	Topic       string
+	Environment PNPushEnvironment
 
 	DeviceIDForPush string
 	QueryParam      map[string]string
@@ -136,6 +160,10 @@ func (o *listPushProvisionsRequestOpts) validate() error {
 		return newValidationError(o, StrMissingPushType)
 	}
 
+	if err := validatePushParams(o, o.PushType, o.Topic, o.Environment); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -145,6 +173,12 @@ type ListPushProvisionsRequestResponse struct {
 }
 
 func (o *listPushProvisionsRequestOpts) buildPath() (string, error) {
+	if o.PushType == PNPushTypeAPNS2 {
+		return fmt.Sprintf(listChannelsOfPushPathAPNS2,
+			o.pubnub.Config.SubscribeKey,
+			utils.URLEncode(o.DeviceIDForPush)), nil
+	}
+
 	return fmt.Sprintf(listChannelsOfPushPath,
 		o.pubnub.Config.SubscribeKey,
 		utils.URLEncode(o.DeviceIDForPush)), nil
@@ -152,7 +186,7 @@ func (o *listPushProvisionsRequestOpts) buildPath() (string, error) {
 
 func (o *listPushProvisionsRequestOpts) buildQuery() (*url.Values, error) {
 	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
-	q.Set("type", o.PushType.String())
+	setPushQuery(q, o.PushType, o.Topic, o.Environment)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -148,3 +148,26 @@ func TestListPushProvisionsValidateSubscribeKey(t *testing.T) {
 
 	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
 }
+
+func TestListPushProvisionsRequestAPNS2(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newListPushProvisionsRequestBuilder(pubnub)
+	o.DeviceIDForPush("deviceID")
+	o.PushType(PNPushTypeAPNS2)
+
+	assert.Contains(o.opts.validate().Error(), "Missing Push Topic")
+
+	o.Topic("com.example.app")
+	o.Environment(PNPushEnvironmentProduction)
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/push/sub-key/sub_key/devices-apns2/deviceID", path)
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("com.example.app", u.Get("topic"))
+	assert.Equal("production", u.Get("environment"))
+}
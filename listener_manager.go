@@ -19,20 +19,80 @@ func NewListener() *Listener {
 	}
 }
 
+// StateChangeListener receives the state changes of the UUIDs present on the
+// subscribed channels, it is fed by the state-change presence events.
+type StateChangeListener struct {
+	StateChange chan *PNStateChange
+}
+
+// NewStateChangeListener initiates a StateChangeListener.
+func NewStateChangeListener() *StateChangeListener {
+	return &StateChangeListener{
+		StateChange: make(chan *PNStateChange),
+	}
+}
+
+// MessageActionsListener receives the message actions added or removed on
+// the subscribed channels.
+type MessageActionsListener struct {
+	MessageActionsEvent chan *PNMessageActionsEvent
+}
+
+// NewMessageActionsListener initiates a MessageActionsListener.
+func NewMessageActionsListener() *MessageActionsListener {
+	return &MessageActionsListener{
+		MessageActionsEvent: make(chan *PNMessageActionsEvent),
+	}
+}
+
+// SignalListener receives the messages sent with Signal on the subscribed
+// channels.
+type SignalListener struct {
+	Signal chan *PNMessage
+}
+
+// NewSignalListener initiates a SignalListener.
+func NewSignalListener() *SignalListener {
+	return &SignalListener{
+		Signal: make(chan *PNMessage),
+	}
+}
+
+// ObjectsListener receives the changes of the UUID metadata, the channel
+// metadata and the memberships on the subscribed channels.
+type ObjectsListener struct {
+	Objects chan *PNObjectsEvent
+}
+
+// NewObjectsListener initiates an ObjectsListener.
+func NewObjectsListener() *ObjectsListener {
+	return &ObjectsListener{
+		Objects: make(chan *PNObjectsEvent),
+	}
+}
+
 type ListenerManager struct {
 	sync.RWMutex
-	ctx          Context
-	listeners    map[*Listener]bool
-	exitListener chan bool
-	pubnub       *PubNub
+	ctx                     Context
+	listeners               map[*Listener]bool
+	stateChangeListeners    map[*StateChangeListener]bool
+	messageActionsListeners map[*MessageActionsListener]bool
+	signalListeners         map[*SignalListener]bool
+	objectsListeners        map[*ObjectsListener]bool
+	exitListener            chan bool
+	pubnub                  *PubNub
 }
 
 func newListenerManager(ctx Context, pn *PubNub) *ListenerManager {
 	return &ListenerManager{
-		listeners:    make(map[*Listener]bool, 2),
-		ctx:          ctx,
-		exitListener: make(chan bool),
-		pubnub:       pn,
+		listeners:               make(map[*Listener]bool, 2),
+		stateChangeListeners:    make(map[*StateChangeListener]bool),
+		messageActionsListeners: make(map[*MessageActionsListener]bool),
+		signalListeners:         make(map[*SignalListener]bool),
+		objectsListeners:        make(map[*ObjectsListener]bool),
+		ctx:                     ctx,
+		exitListener:            make(chan bool),
+		pubnub:                  pn,
 	}
 }
 
@@ -52,6 +112,66 @@ func (m *ListenerManager) removeAllListeners() {
 	for l := range m.listeners {
 		delete(m.listeners, l)
 	}
+	for l := range m.stateChangeListeners {
+		delete(m.stateChangeListeners, l)
+	}
+	for l := range m.messageActionsListeners {
+		delete(m.messageActionsListeners, l)
+	}
+	for l := range m.signalListeners {
+		delete(m.signalListeners, l)
+	}
+	for l := range m.objectsListeners {
+		delete(m.objectsListeners, l)
+	}
+	m.Unlock()
+}
+
+func (m *ListenerManager) addStateChangeListener(listener *StateChangeListener) {
+	m.Lock()
+	m.stateChangeListeners[listener] = true
+	m.Unlock()
+}
+
+func (m *ListenerManager) removeStateChangeListener(listener *StateChangeListener) {
+	m.Lock()
+	delete(m.stateChangeListeners, listener)
+	m.Unlock()
+}
+
+func (m *ListenerManager) addMessageActionsListener(listener *MessageActionsListener) {
+	m.Lock()
+	m.messageActionsListeners[listener] = true
+	m.Unlock()
+}
+
+func (m *ListenerManager) removeMessageActionsListener(listener *MessageActionsListener) {
+	m.Lock()
+	delete(m.messageActionsListeners, listener)
+	m.Unlock()
+}
+
+func (m *ListenerManager) addSignalListener(listener *SignalListener) {
+	m.Lock()
+	m.signalListeners[listener] = true
+	m.Unlock()
+}
+
+func (m *ListenerManager) removeSignalListener(listener *SignalListener) {
+	m.Lock()
+	delete(m.signalListeners, listener)
+	m.Unlock()
+}
+
+func (m *ListenerManager) addObjectsListener(listener *ObjectsListener) {
+	m.Lock()
+	m.objectsListeners[listener] = true
+	m.Unlock()
+}
+
+func (m *ListenerManager) removeObjectsListener(listener *ObjectsListener) {
+	m.Lock()
+	delete(m.objectsListeners, listener)
 	m.Unlock()
 }
 
@@ -85,6 +205,70 @@ func (m *ListenerManager) announceMessage(message *PNMessage) {
 	}()
 }
 
+func (m *ListenerManager) announceSignal(message *PNMessage) {
+	go func() {
+		m.RLock()
+		defer m.RUnlock()
+
+		for l := range m.signalListeners {
+			select {
+			case <-m.exitListener:
+				m.pubnub.Config.Log.Println("announceSignal exitListener")
+				return
+			case l.Signal <- message:
+			}
+		}
+	}()
+}
+
+func (m *ListenerManager) announceObjects(event *PNObjectsEvent) {
+	go func() {
+		m.RLock()
+		defer m.RUnlock()
+
+		for l := range m.objectsListeners {
+			select {
+			case <-m.exitListener:
+				m.pubnub.Config.Log.Println("announceObjects exitListener")
+				return
+			case l.Objects <- event:
+			}
+		}
+	}()
+}
+
+func (m *ListenerManager) announceStateChange(stateChange *PNStateChange) {
+	go func() {
+		m.RLock()
+		defer m.RUnlock()
+
+		for l := range m.stateChangeListeners {
+			select {
+			case <-m.exitListener:
+				m.pubnub.Config.Log.Println("announceStateChange exitListener")
+				return
+			case l.StateChange <- stateChange:
+			}
+		}
+	}()
+}
+
+func (m *ListenerManager) announceMessageActionsEvent(event *PNMessageActionsEvent) {
+	go func() {
+		m.RLock()
+		defer m.RUnlock()
+
+		for l := range m.messageActionsListeners {
+			select {
+			case <-m.exitListener:
+				m.pubnub.Config.Log.Println("announceMessageActionsEvent exitListener")
+				return
+			case l.MessageActionsEvent <- event:
+			}
+		}
+	}()
+}
+
 func (m *ListenerManager) announcePresence(presence *PNPresence) {
 	m.RLock()
 
@@ -121,6 +305,19 @@ type PNMessage struct {
 	Subscription      string
 	Publisher         string
 	Timetoken         int64
+	// SequenceNumber is the seqn sent by the publisher, see PublishResponse.
+	SequenceNumber int
+}
+
+// PNMessageActionsEvent is a message action added or removed, Event is
+// "added" or "removed".
+type PNMessageActionsEvent struct {
+	Event             string
+	Data              PNMessageAction
+	SubscribedChannel string
+	ActualChannel     string
+	Channel           string
+	Subscription      string
 }
 
 type PNPresence struct {
@@ -140,3 +337,13 @@ type PNPresence struct {
 	Timeout           []string
 	HereNowRefresh    bool
 }
+
+// PNStateChange is the state of a UUID on a channel after a state-change presence event.
+type PNStateChange struct {
+	UUID              string
+	Channel           string
+	SubscribedChannel string
+	State             map[string]interface{}
+	Timetoken         int64
+	Timestamp         int64
+}
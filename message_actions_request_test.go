@@ -0,0 +1,146 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMessageActionRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newAddMessageActionBuilder(pubnub).
+		Channel("my channel").
+		MessageTimetoken(15610547826970040).
+		Action(MessageAction{ActionType: "reaction", ActionValue: "smiley_face"})
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/v1/message-actions/%s/channel/my%%20channel/message/15610547826970040",
+		pubnub.Config.SubscribeKey), path)
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"type":"reaction","value":"smiley_face"}`, string(body))
+	assert.Equal("POST", o.opts.httpMethod())
+}
+
+func TestAddMessageActionValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newAddMessageActionBuilder(pubnub).Channel("ch")
+	assert.Contains(o.opts.validate().Error(), "Missing Message Timetoken")
+
+	o.MessageTimetoken(15610547826970040)
+	assert.Contains(o.opts.validate().Error(), "Missing Action Type")
+
+	o.Action(MessageAction{ActionType: "a-very-long-action-type", ActionValue: "v"})
+	assert.Contains(o.opts.validate().Error(), "Action Type longer than 15 characters")
+
+	o.Action(MessageAction{ActionType: "receipt"})
+	assert.Contains(o.opts.validate().Error(), "Missing Action Value")
+
+	o.Action(MessageAction{ActionType: "receipt", ActionValue: "read"})
+	assert.Nil(o.opts.validate())
+}
+
+func TestNewAddMessageActionResponse(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"status":200,"data":{"type":"reaction","value":"smiley_face","uuid":"user-456","actionTimetoken":"15610547826970050","messageTimetoken":"15610547826969050"}}`)
+
+	resp, _, err := newAddMessageActionResponse(jsonBytes, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal(PNMessageAction{
+		ActionType:       "reaction",
+		ActionValue:      "smiley_face",
+		UUID:             "user-456",
+		ActionTimetoken:  15610547826970050,
+		MessageTimetoken: 15610547826969050,
+	}, resp.Data)
+
+	_, _, err = newAddMessageActionResponse([]byte(`s`), StatusResponse{})
+	assert.Contains(err.Error(), "Error unmarshalling response")
+}
+
+func TestRemoveMessageActionRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveMessageActionBuilderWithContext(pubnub, backgroundContext).
+		Channel("ch").
+		MessageTimetoken(15610547826969050)
+	assert.Contains(o.opts.validate().Error(), "Missing Action Timetoken")
+
+	o.ActionTimetoken(15610547826970050)
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("/v1/message-actions/%s/channel/ch/message/15610547826969050/action/15610547826970050",
+		pubnub.Config.SubscribeKey), path)
+	assert.Equal("DELETE", o.opts.httpMethod())
+}
+
+func TestGetMessageActionsRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newGetMessageActionsBuilder(pubnub).
+		Channel("ch").
+		Start(15610547826970050).
+		End(15610547826969050).
+		Limit(10)
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("start", "15610547826970050")
+	expected.Set("end", "15610547826969050")
+	expected.Set("limit", "10")
+	h.AssertQueriesEqual(t, expected, query, []string{"pnsdk", "uuid"}, []string{})
+}
+
+func TestNewGetMessageActionsResponse(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"status":200,"data":[{"type":"reaction","value":"smiley_face","uuid":"user-456","actionTimetoken":"15610547826970050","messageTimetoken":"15610547826969050"}],"more":{"url":"/v1/message-actions/demo/channel/ch?start=15610547826970050","start":"15610547826970050","end":"15610547826969050","limit":1}}`)
+
+	resp, _, err := newGetMessageActionsResponse(jsonBytes, StatusResponse{})
+	assert.Nil(err)
+	assert.Equal(1, len(resp.Data))
+	assert.Equal("user-456", resp.Data[0].UUID)
+	assert.Equal(int64(15610547826970050), resp.More.Start)
+	assert.Equal(1, resp.More.Limit)
+
+	resp, _, err = newGetMessageActionsResponse([]byte(`{"status":200,"data":[]}`), StatusResponse{})
+	assert.Nil(err)
+	assert.Equal(0, len(resp.Data))
+	assert.Nil(resp.More)
+}
+
+func TestMessageActionsExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "DELETE",
+		Path:               "/v1/message-actions/demo/channel/ch/message/15610547826969050/action/15610547826970050",
+		ResponseBody:       `{"status":200,"data":{}}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_msga"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	resp, status, err := pn.RemoveMessageAction().
+		Channel("ch").
+		MessageTimetoken(15610547826969050).
+		ActionTimetoken(15610547826970050).
+		Execute()
+	assert.Nil(err)
+	assert.NotNil(resp)
+	assert.Equal(200, status.StatusCode)
+}
@@ -88,6 +88,14 @@ func (b *messageCountsBuilder) Execute() (*MessageCountsResponse, StatusResponse
 	return newMessageCountsResponse(rawJSON, b.opts, status)
 }
 
+// ExecuteAsync runs the MessageCounts request asynchronously, the result of the Future is a *MessageCountsResponse.
+func (b *messageCountsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type messageCountsOpts struct {
 	pubnub *PubNub
 
@@ -0,0 +1,330 @@
+package pubnub
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// messageUpdateKey is the key of the records published by EditMessage and SoftDeleteMessage.
+const messageUpdateKey = "pn_update"
+
+const (
+	// PNMessageUpdateEdit is the action of the records published by EditMessage.
+	PNMessageUpdateEdit = "edit"
+	// PNMessageUpdateDelete is the action of the records published by SoftDeleteMessage.
+	PNMessageUpdateDelete = "delete"
+)
+
+type messageUpdateBuilder struct {
+	action    string
+	publish   *publishBuilder
+	timetoken int64
+	message   interface{}
+}
+
+func newMessageUpdateBuilder(pubnub *PubNub, action string) *messageUpdateBuilder {
+	return &messageUpdateBuilder{
+		action:  action,
+		publish: newPublishBuilder(pubnub),
+	}
+}
+
+func newMessageUpdateBuilderWithContext(pubnub *PubNub, context Context, action string) *messageUpdateBuilder {
+	return &messageUpdateBuilder{
+		action:  action,
+		publish: newPublishBuilderWithContext(pubnub, context),
+	}
+}
+
+// Channel sets the Channel of the message.
+func (b *messageUpdateBuilder) Channel(ch string) *messageUpdateBuilder {
+	b.publish.Channel(ch)
+	return b
+}
+
+// Timetoken sets the timetoken of the original message which is edited or deleted.
+func (b *messageUpdateBuilder) Timetoken(timetoken int64) *messageUpdateBuilder {
+	b.timetoken = timetoken
+	return b
+}
+
+// Message sets the new content of the edited message, it is ignored by SoftDeleteMessage.
+func (b *messageUpdateBuilder) Message(msg interface{}) *messageUpdateBuilder {
+	b.message = msg
+	return b
+}
+
+// Meta sets the Meta Payload published with the record.
+func (b *messageUpdateBuilder) Meta(meta interface{}) *messageUpdateBuilder {
+	b.publish.Meta(meta)
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *messageUpdateBuilder) QueryParam(queryParam map[string]string) *messageUpdateBuilder {
+	b.publish.QueryParam(queryParam)
+	return b
+}
+
+// Execute publishes the edit or delete record on the channel of the original message.
+func (b *messageUpdateBuilder) Execute() (*PublishResponse, StatusResponse, error) {
+	opts := b.publish.opts
+
+	if b.timetoken <= 0 {
+		err := newValidationError(opts, "Missing Message Timetoken")
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	if b.action == PNMessageUpdateEdit && b.message == nil {
+		err := newValidationError(opts, StrMissingMessage)
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	record := map[string]interface{}{
+		"action": b.action,
+		"target": strconv.FormatInt(b.timetoken, 10),
+	}
+	if b.action == PNMessageUpdateEdit {
+		record["message"] = b.message
+	}
+
+	return b.publish.Message(map[string]interface{}{messageUpdateKey: record}).Execute()
+}
+
+// ExecuteAsync publishes the edit or delete record on the channel of the original message asynchronously, the result of the Future is a *PublishResponse.
+func (b *messageUpdateBuilder) ExecuteAsync() *Future {
+	return newFuture(b.publish.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+// PNMessageUpdate is an edit or delete record published by EditMessage or SoftDeleteMessage.
+type PNMessageUpdate struct {
+	Action string
+	// Target is the timetoken of the original message.
+	Target  int64
+	Message interface{}
+}
+
+// ParseMessageUpdate returns the edit or delete record of a received
+// message, false if the message is a regular one.
+func ParseMessageUpdate(message interface{}) (*PNMessageUpdate, bool) {
+	m, ok := message.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return nil, false
+	}
+
+	record, ok := m[messageUpdateKey].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	update := &PNMessageUpdate{
+		Target:  parseHistoryTimetoken(record["target"]),
+		Message: record["message"],
+	}
+	update.Action, _ = record["action"].(string)
+
+	if update.Target <= 0 ||
+		(update.Action != PNMessageUpdateEdit && update.Action != PNMessageUpdateDelete) {
+		return nil, false
+	}
+
+	return update, true
+}
+
+// PNVisibleMessage is the latest version of a message once its edits and
+// deletion are applied.
+type PNVisibleMessage struct {
+	Channel string
+	// Timetoken is the timetoken of the original message.
+	Timetoken int64
+	Message   interface{}
+	// EditTimetoken is the timetoken of the last edit applied, 0 if the message is not edited.
+	EditTimetoken int64
+	Deleted       bool
+}
+
+// MessageFolder folds the edit and delete records into the latest visible
+// version of each message. The records can be added before or after the
+// original message, the last edit wins and a deletion is final.
+//
+// The records of a message which is never added are kept until Prune removes
+// them, a folder fed from the subscribe loop should be pruned regularly.
+type MessageFolder struct {
+	sync.RWMutex
+
+	messages map[string]map[int64]*PNVisibleMessage
+	// records whose original message is not known yet
+	pending map[string]map[int64][]messageUpdateRecord
+	// the messages and records older than prunedBefore are ignored
+	prunedBefore int64
+}
+
+type messageUpdateRecord struct {
+	update    *PNMessageUpdate
+	timetoken int64
+}
+
+// NewMessageFolder initiates a MessageFolder.
+func NewMessageFolder() *MessageFolder {
+	return &MessageFolder{
+		messages: make(map[string]map[int64]*PNVisibleMessage),
+		pending:  make(map[string]map[int64][]messageUpdateRecord),
+	}
+}
+
+// Add adds a message or a record received on the channel with its timetoken.
+// It returns a copy of the visible message which changed, false if no known
+// message changed.
+func (f *MessageFolder) Add(channel string, timetoken int64, message interface{}) (PNVisibleMessage, bool) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.messages[channel] == nil {
+		f.messages[channel] = make(map[int64]*PNVisibleMessage)
+		f.pending[channel] = make(map[int64][]messageUpdateRecord)
+	}
+
+	if update, ok := ParseMessageUpdate(message); ok {
+		if update.Target < f.prunedBefore {
+			return PNVisibleMessage{}, false
+		}
+
+		visible, known := f.messages[channel][update.Target]
+		if !known {
+			f.pending[channel][update.Target] = append(f.pending[channel][update.Target],
+				messageUpdateRecord{update: update, timetoken: timetoken})
+			return PNVisibleMessage{}, false
+		}
+
+		applyMessageUpdate(visible, update, timetoken)
+		return *visible, true
+	}
+
+	if timetoken < f.prunedBefore {
+		return PNVisibleMessage{}, false
+	}
+
+	visible, known := f.messages[channel][timetoken]
+	if known {
+		return *visible, false
+	}
+
+	visible = &PNVisibleMessage{
+		Channel:   channel,
+		Timetoken: timetoken,
+		Message:   message,
+	}
+	f.messages[channel][timetoken] = visible
+
+	for _, record := range f.pending[channel][timetoken] {
+		applyMessageUpdate(visible, record.update, record.timetoken)
+	}
+	delete(f.pending[channel], timetoken)
+
+	return *visible, true
+}
+
+// Prune removes the messages older than the timetoken and the pending records
+// of these messages. The messages and records older than the timetoken added
+// after Prune are ignored.
+func (f *MessageFolder) Prune(before int64) {
+	f.Lock()
+	defer f.Unlock()
+
+	if before > f.prunedBefore {
+		f.prunedBefore = before
+	}
+
+	for channel, messages := range f.messages {
+		for timetoken := range messages {
+			if timetoken < before {
+				delete(messages, timetoken)
+			}
+		}
+		for target := range f.pending[channel] {
+			if target < before {
+				delete(f.pending[channel], target)
+			}
+		}
+
+		if len(messages) == 0 && len(f.pending[channel]) == 0 {
+			delete(f.messages, channel)
+			delete(f.pending, channel)
+		}
+	}
+}
+
+// AddMessage adds a message received with subscribe.
+func (f *MessageFolder) AddMessage(message *PNMessage) (PNVisibleMessage, bool) {
+	return f.Add(message.Channel, message.Timetoken, message.Message)
+}
+
+// Messages returns the visible messages of the channel ordered by timetoken,
+// the deleted messages are skipped.
+func (f *MessageFolder) Messages(channel string) []PNVisibleMessage {
+	f.RLock()
+	defer f.RUnlock()
+
+	messages := []PNVisibleMessage{}
+	for _, visible := range f.messages[channel] {
+		if !visible.Deleted {
+			messages = append(messages, *visible)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Timetoken < messages[j].Timetoken
+	})
+
+	return messages
+}
+
+func applyMessageUpdate(visible *PNVisibleMessage, update *PNMessageUpdate, timetoken int64) {
+	if visible.Deleted {
+		return
+	}
+
+	switch update.Action {
+	case PNMessageUpdateDelete:
+		visible.Deleted = true
+		visible.Message = nil
+	case PNMessageUpdateEdit:
+		if timetoken >= visible.EditTimetoken {
+			visible.Message = update.Message
+			visible.EditTimetoken = timetoken
+		}
+	}
+}
+
+// FoldHistory returns the visible messages of History items fetched with
+// IncludeTimetoken, ordered by timetoken.
+func FoldHistory(channel string, items []HistoryResponseItem) []PNVisibleMessage {
+	f := NewMessageFolder()
+	for _, item := range items {
+		f.Add(channel, item.Timetoken, item.Message)
+	}
+
+	return f.Messages(channel)
+}
+
+// FoldFetch returns the visible messages of each channel of a Fetch response,
+// ordered by timetoken.
+func FoldFetch(resp *FetchResponse) map[string][]PNVisibleMessage {
+	folded := make(map[string][]PNVisibleMessage)
+	if resp == nil {
+		return folded
+	}
+
+	f := NewMessageFolder()
+	for channel, items := range resp.Messages {
+		for _, item := range items {
+			f.Add(channel, item.Timetoken, item.Message)
+		}
+		folded[channel] = f.Messages(channel)
+	}
+
+	return folded
+}
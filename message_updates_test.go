@@ -0,0 +1,204 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessageUpdate(action string, target string, message interface{}) map[string]interface{} {
+	record := map[string]interface{}{
+		"action": action,
+		"target": target,
+	}
+	if message != nil {
+		record["message"] = message
+	}
+	return map[string]interface{}{"pn_update": record}
+}
+
+func TestParseMessageUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	update, ok := ParseMessageUpdate(newTestMessageUpdate("edit", "15610547826970040", "new"))
+	assert.True(ok)
+	assert.Equal(&PNMessageUpdate{Action: PNMessageUpdateEdit, Target: 15610547826970040, Message: "new"}, update)
+
+	update, ok = ParseMessageUpdate(newTestMessageUpdate("delete", "15610547826970040", nil))
+	assert.True(ok)
+	assert.Equal(PNMessageUpdateDelete, update.Action)
+
+	_, ok = ParseMessageUpdate("hi")
+	assert.False(ok)
+
+	_, ok = ParseMessageUpdate(newTestMessageUpdate("move", "15610547826970040", nil))
+	assert.False(ok)
+
+	_, ok = ParseMessageUpdate(newTestMessageUpdate("edit", "", "new"))
+	assert.False(ok)
+
+	// A regular message with a pn_update field and other fields
+	_, ok = ParseMessageUpdate(map[string]interface{}{
+		"pn_update": map[string]interface{}{"action": "edit", "target": "1"},
+		"text":      "hi",
+	})
+	assert.False(ok)
+}
+
+func TestMessageFolder(t *testing.T) {
+	assert := assert.New(t)
+	f := NewMessageFolder()
+
+	visible, changed := f.Add("ch", 100, "first")
+	assert.True(changed)
+	assert.Equal(PNVisibleMessage{Channel: "ch", Timetoken: 100, Message: "first"}, visible)
+
+	// The edit is received before its original message
+	_, changed = f.Add("ch", 300, newTestMessageUpdate("edit", "200", "second, edited"))
+	assert.False(changed)
+
+	visible, changed = f.Add("ch", 200, "second")
+	assert.True(changed)
+	assert.Equal("second, edited", visible.Message)
+	assert.Equal(int64(300), visible.EditTimetoken)
+
+	// An older edit received late doesn't override the newer one
+	visible, changed = f.Add("ch", 250, newTestMessageUpdate("edit", "200", "second, old edit"))
+	assert.True(changed)
+	assert.Equal("second, edited", visible.Message)
+
+	visible, changed = f.Add("ch", 400, newTestMessageUpdate("delete", "100", nil))
+	assert.True(changed)
+	assert.True(visible.Deleted)
+
+	// A deletion is final
+	visible, _ = f.Add("ch", 500, newTestMessageUpdate("edit", "100", "back"))
+	assert.True(visible.Deleted)
+	assert.Nil(visible.Message)
+
+	messages := f.Messages("ch")
+	assert.Equal(1, len(messages))
+	assert.Equal(int64(200), messages[0].Timetoken)
+	assert.Equal(0, len(f.Messages("other")))
+
+	visible, changed = f.AddMessage(&PNMessage{Channel: "other", Timetoken: 600, Message: "hi"})
+	assert.True(changed)
+	assert.Equal("other", visible.Channel)
+}
+
+func TestMessageFolderPrune(t *testing.T) {
+	assert := assert.New(t)
+	f := NewMessageFolder()
+
+	f.Add("ch", 100, "old")
+	f.Add("ch", 300, "new")
+	// The original messages of these records are never added
+	f.Add("ch", 400, newTestMessageUpdate("edit", "50", "missed"))
+	f.Add("ch", 500, newTestMessageUpdate("delete", "250", nil))
+	f.Add("other", 600, newTestMessageUpdate("edit", "60", "missed"))
+	assert.Equal(2, len(f.pending["ch"]))
+
+	f.Prune(200)
+	assert.Equal(1, len(f.pending["ch"]))
+	assert.Nil(f.pending["other"])
+	assert.Nil(f.messages["other"])
+
+	messages := f.Messages("ch")
+	assert.Equal(1, len(messages))
+	assert.Equal(int64(300), messages[0].Timetoken)
+
+	// The records of the pruned messages are not kept again
+	_, changed := f.Add("ch", 700, newTestMessageUpdate("edit", "100", "late"))
+	assert.False(changed)
+	_, changed = f.Add("ch", 150, "late")
+	assert.False(changed)
+	assert.Equal(1, len(f.pending["ch"]))
+	assert.Equal(1, len(f.Messages("ch")))
+
+	visible, changed := f.Add("ch", 250, "kept")
+	assert.True(changed)
+	assert.True(visible.Deleted)
+}
+
+func TestFoldHistoryAndFetch(t *testing.T) {
+	assert := assert.New(t)
+
+	history := []HistoryResponseItem{
+		{Message: "a", Timetoken: 1},
+		{Message: "b", Timetoken: 2},
+		{Message: newTestMessageUpdate("edit", "1", "a2"), Timetoken: 3},
+		{Message: newTestMessageUpdate("delete", "2", nil), Timetoken: 4},
+	}
+
+	folded := FoldHistory("ch", history)
+	assert.Equal([]PNVisibleMessage{
+		{Channel: "ch", Timetoken: 1, Message: "a2", EditTimetoken: 3},
+	}, folded)
+
+	resp := &FetchResponse{
+		Messages: map[string][]FetchResponseItem{
+			"ch1": {
+				{Message: "x", Timetoken: 10},
+				{Message: newTestMessageUpdate("edit", "10", "x2"), Timetoken: 11},
+			},
+			"ch2": {
+				{Message: "y", Timetoken: 10},
+			},
+		},
+	}
+
+	byChannel := FoldFetch(resp)
+	assert.Equal("x2", byChannel["ch1"][0].Message)
+	assert.Equal("y", byChannel["ch2"][0].Message)
+	assert.Equal(0, len(FoldFetch(nil)))
+}
+
+func TestEditMessageExecute(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+
+	expected := &publishOpts{
+		pubnub:    pn,
+		Channel:   "ch",
+		Message:   newTestMessageUpdate("edit", "15610547826970040", "new"),
+		Serialize: true,
+	}
+	path, err := expected.buildPath()
+	assert.Nil(err)
+
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               path,
+		ResponseBody:       `[1,"Sent","15610547826970050"]`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "seqn", "l_pub"},
+		ResponseStatusCode: 200,
+	})
+	pn.SetClient(interceptor.GetClient())
+
+	b := pn.EditMessage().
+		Channel("ch").
+		Timetoken(15610547826970040).
+		Message("new")
+
+	res, _, err := b.Execute()
+	assert.Nil(err)
+	assert.Equal(int64(15610547826970050), res.Timestamp)
+
+	update, ok := ParseMessageUpdate(b.publish.opts.Message)
+	assert.True(ok)
+	assert.Equal(&PNMessageUpdate{Action: PNMessageUpdateEdit, Target: 15610547826970040, Message: "new"}, update)
+}
+
+func TestMessageUpdateValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, _, err := pn.SoftDeleteMessage().Channel("ch").Execute()
+	assert.Contains(err.Error(), "Missing Message Timetoken")
+
+	_, _, err = pn.EditMessage().Channel("ch").Timetoken(1).Execute()
+	assert.Contains(err.Error(), "Missing Message")
+}
@@ -0,0 +1,228 @@
+package pubnub
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+// objectsLimit is the default and max number of objects returned by the objects list requests.
+const objectsLimit = 100
+
+const (
+	// PNObjectsEventSet is the Event of the objects events sent when metadata is set.
+	PNObjectsEventSet = "set"
+	// PNObjectsEventDelete is the Event of the objects events sent when metadata is removed.
+	PNObjectsEventDelete = "delete"
+)
+
+const (
+	// PNObjectsTypeUUID is the Type of the objects events of the UUID metadata.
+	PNObjectsTypeUUID = "uuid"
+	// PNObjectsTypeChannel is the Type of the objects events of the channel metadata.
+	PNObjectsTypeChannel = "channel"
+	// PNObjectsTypeMembership is the Type of the objects events of the memberships.
+	PNObjectsTypeMembership = "membership"
+)
+
+// PNUUID is the metadata of a UUID.
+type PNUUID struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	ExternalID string                 `json:"externalId"`
+	ProfileURL string                 `json:"profileUrl"`
+	Email      string                 `json:"email"`
+	Custom     map[string]interface{} `json:"custom"`
+	Updated    string                 `json:"updated"`
+	ETag       string                 `json:"eTag"`
+}
+
+// PNChannel is the metadata of a channel.
+type PNChannel struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Custom      map[string]interface{} `json:"custom"`
+	Updated     string                 `json:"updated"`
+	ETag        string                 `json:"eTag"`
+}
+
+// PNMembership is a channel membership of a UUID, the Channel has only its
+// ID unless the channel is included.
+type PNMembership struct {
+	Channel PNChannel              `json:"channel"`
+	Custom  map[string]interface{} `json:"custom"`
+	Updated string                 `json:"updated"`
+	ETag    string                 `json:"eTag"`
+}
+
+// PNChannelMember is a UUID member of a channel, the UUID has only its ID
+// unless the UUID is included.
+type PNChannelMember struct {
+	UUID    PNUUID                 `json:"uuid"`
+	Custom  map[string]interface{} `json:"custom"`
+	Updated string                 `json:"updated"`
+	ETag    string                 `json:"eTag"`
+}
+
+// PNMembershipsSet is a channel membership set with SetMemberships.
+type PNMembershipsSet struct {
+	Channel string
+	Custom  map[string]interface{}
+}
+
+// PNChannelMembersSet is a UUID member set with SetChannelMembers.
+type PNChannelMembersSet struct {
+	UUID   string
+	Custom map[string]interface{}
+}
+
+// PNObjectsEvent is a change of the metadata of a UUID, of a channel or of a
+// membership on the subscribed channels.
+type PNObjectsEvent struct {
+	// Event is PNObjectsEventSet or PNObjectsEventDelete.
+	Event string
+	// Type is PNObjectsTypeUUID, PNObjectsTypeChannel or PNObjectsTypeMembership.
+	Type string
+
+	// UUIDMetadata is set by the UUID and the membership events, it has only
+	// the ID of the UUID with a membership event.
+	UUIDMetadata *PNUUID
+	// ChannelMetadata is set by the channel and the membership events, it has
+	// only the ID of the channel with a membership event.
+	ChannelMetadata *PNChannel
+
+	// Custom, Updated and ETag of the membership events.
+	Custom  map[string]interface{}
+	Updated string
+	ETag    string
+
+	SubscribedChannel string
+	ActualChannel     string
+	Channel           string
+	Subscription      string
+}
+
+type objectsID struct {
+	ID string `json:"id"`
+}
+
+type objectsEventMembership struct {
+	Channel *PNChannel             `json:"channel"`
+	UUID    *PNUUID                `json:"uuid"`
+	Custom  map[string]interface{} `json:"custom"`
+	Updated string                 `json:"updated"`
+	ETag    string                 `json:"eTag"`
+}
+
+// newObjectsEvent parses the payload of an objects event.
+func newObjectsEvent(payload map[string]interface{}) (*PNObjectsEvent, error) {
+	event := &PNObjectsEvent{}
+	event.Event, _ = payload["event"].(string)
+	event.Type, _ = payload["type"].(string)
+
+	data, err := json.Marshal(payload["data"])
+	if err != nil {
+		return event, err
+	}
+
+	switch event.Type {
+	case PNObjectsTypeUUID:
+		event.UUIDMetadata = &PNUUID{}
+		err = json.Unmarshal(data, event.UUIDMetadata)
+	case PNObjectsTypeChannel:
+		event.ChannelMetadata = &PNChannel{}
+		err = json.Unmarshal(data, event.ChannelMetadata)
+	case PNObjectsTypeMembership:
+		membership := objectsEventMembership{}
+		err = json.Unmarshal(data, &membership)
+		event.UUIDMetadata = membership.UUID
+		event.ChannelMetadata = membership.Channel
+		event.Custom = membership.Custom
+		event.Updated = membership.Updated
+		event.ETag = membership.ETag
+	}
+
+	return event, err
+}
+
+// setObjectsListQuery sets the paging, sort and filter params of the objects
+// list requests.
+func setObjectsListQuery(q *url.Values, limit int, start, end string, count bool, sort []string, filter string) {
+	q.Set("limit", strconv.Itoa(limit))
+
+	if start != "" {
+		q.Set("start", utils.URLEncode(start))
+	}
+
+	if end != "" {
+		q.Set("end", utils.URLEncode(end))
+	}
+
+	if count {
+		q.Set("count", "true")
+	}
+
+	if len(sort) > 0 {
+		sorted := make([]string, len(sort))
+		for i, s := range sort {
+			sorted[i] = utils.URLEncode(s)
+		}
+		q.Set("sort", strings.Join(sorted, ","))
+	}
+
+	if filter != "" {
+		q.Set("filter", utils.URLEncode(filter))
+	}
+}
+
+// setObjectsInclude sets the include param with the included fields.
+func setObjectsInclude(q *url.Values, include map[string]bool) {
+	fields := []string{}
+	for _, field := range []string{"custom", "channel", "channel.custom", "uuid", "uuid.custom"} {
+		if include[field] {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) > 0 {
+		q.Set("include", strings.Join(fields, ","))
+	}
+}
+
+func validateObjectsLimit(o endpointOpts, limit int) error {
+	if limit <= 0 || limit > objectsLimit {
+		return newValidationError(o, "Limit must be between 1 and 100")
+	}
+
+	return nil
+}
+
+// parseObjectsResponse unmarshals the response of an objects request in resp.
+func parseObjectsResponse(jsonBytes []byte, resp interface{}) error {
+	if err := json.Unmarshal(jsonBytes, resp); err != nil {
+		return pnerr.NewResponseParsingError("Error unmarshalling response",
+			ioutil.NopCloser(bytes.NewBufferString(string(jsonBytes))), err)
+	}
+
+	return nil
+}
+
+// objectsChangeItem is an item of the body of the requests changing the
+// memberships or the members, key is "channel" or "uuid".
+func objectsChangeItem(key, id string, custom map[string]interface{}) map[string]interface{} {
+	item := map[string]interface{}{
+		key: objectsID{ID: id},
+	}
+	if custom != nil {
+		item["custom"] = custom
+	}
+
+	return item
+}
@@ -0,0 +1,135 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewObjectsEventUUID(t *testing.T) {
+	assert := assert.New(t)
+
+	event, err := newObjectsEvent(map[string]interface{}{
+		"source":  "objects",
+		"version": "2.0",
+		"event":   "set",
+		"type":    "uuid",
+		"data": map[string]interface{}{
+			"id":      "uuid",
+			"name":    "name",
+			"email":   "uuid@example.com",
+			"custom":  map[string]interface{}{"a": "b"},
+			"updated": "2020-06-17T16:28:14.060718Z",
+			"eTag":    "AbO7ys6S2pH8cQ",
+		},
+	})
+
+	assert.Nil(err)
+	assert.Equal(PNObjectsEventSet, event.Event)
+	assert.Equal(PNObjectsTypeUUID, event.Type)
+	assert.Equal("uuid", event.UUIDMetadata.ID)
+	assert.Equal("name", event.UUIDMetadata.Name)
+	assert.Equal("uuid@example.com", event.UUIDMetadata.Email)
+	assert.Equal("b", event.UUIDMetadata.Custom["a"])
+	assert.Equal("AbO7ys6S2pH8cQ", event.UUIDMetadata.ETag)
+	assert.Nil(event.ChannelMetadata)
+}
+
+func TestNewObjectsEventChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	event, err := newObjectsEvent(map[string]interface{}{
+		"event": "delete",
+		"type":  "channel",
+		"data":  map[string]interface{}{"id": "ch"},
+	})
+
+	assert.Nil(err)
+	assert.Equal(PNObjectsEventDelete, event.Event)
+	assert.Equal(PNObjectsTypeChannel, event.Type)
+	assert.Equal("ch", event.ChannelMetadata.ID)
+	assert.Nil(event.UUIDMetadata)
+}
+
+func TestNewObjectsEventMembership(t *testing.T) {
+	assert := assert.New(t)
+
+	event, err := newObjectsEvent(map[string]interface{}{
+		"event": "set",
+		"type":  "membership",
+		"data": map[string]interface{}{
+			"channel": map[string]interface{}{"id": "ch"},
+			"uuid":    map[string]interface{}{"id": "uuid"},
+			"custom":  map[string]interface{}{"role": "admin"},
+			"updated": "2020-06-17T16:28:14.060718Z",
+			"eTag":    "AbO7ys6S2pH8cQ",
+		},
+	})
+
+	assert.Nil(err)
+	assert.Equal(PNObjectsTypeMembership, event.Type)
+	assert.Equal("ch", event.ChannelMetadata.ID)
+	assert.Equal("uuid", event.UUIDMetadata.ID)
+	assert.Equal("admin", event.Custom["role"])
+	assert.Equal("2020-06-17T16:28:14.060718Z", event.Updated)
+	assert.Equal("AbO7ys6S2pH8cQ", event.ETag)
+}
+
+func TestSetObjectsListQuery(t *testing.T) {
+	q := &url.Values{}
+	setObjectsListQuery(q, 10, "MTAw", "", true, []string{"name:desc", "updated"}, `name like "a*"`)
+
+	expected := &url.Values{}
+	expected.Set("limit", "10")
+	expected.Set("start", "MTAw")
+	expected.Set("count", "true")
+	expected.Set("sort", "name%3Adesc,updated")
+	expected.Set("filter", "name%20like%20%22a%2A%22")
+
+	h.AssertQueriesEqual(t, expected, q, []string{}, []string{})
+}
+
+func TestSetObjectsInclude(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &url.Values{}
+	setObjectsInclude(q, map[string]bool{"custom": false})
+	assert.Equal("", q.Get("include"))
+
+	setObjectsInclude(q, map[string]bool{"channel.custom": true, "custom": true, "channel": true})
+	assert.Equal("custom,channel,channel.custom", q.Get("include"))
+}
+
+func TestObjectsChangeItem(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := json.Marshal([]map[string]interface{}{
+		objectsChangeItem("channel", "ch", map[string]interface{}{"a": 1}),
+		objectsChangeItem("uuid", "uuid", nil),
+	})
+
+	assert.Nil(err)
+	assert.Equal(`[{"channel":{"id":"ch"},"custom":{"a":1}},{"uuid":{"id":"uuid"}}]`, string(b))
+}
+
+func TestValidateObjectsLimit(t *testing.T) {
+	assert := assert.New(t)
+	opts := &getAllUUIDMetadataOpts{pubnub: pubnub}
+
+	assert.Nil(validateObjectsLimit(opts, 1))
+	assert.Nil(validateObjectsLimit(opts, 100))
+	assert.Contains(validateObjectsLimit(opts, 0).Error(), "Limit must be between 1 and 100")
+	assert.Contains(validateObjectsLimit(opts, 101).Error(), "Limit must be between 1 and 100")
+}
+
+func TestObjectsUseSignatureV2(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(usesSignatureV2(PNGetAllUUIDMetadataOperation))
+	assert.True(usesSignatureV2(PNRemoveChannelMembersOperation))
+	assert.True(usesSignatureV2(PNAccessManagerGrantToken))
+	assert.False(usesSignatureV2(PNPublishOperation))
+}
@@ -0,0 +1,388 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+// StrOutboxEnabled is the error of Outbox().Execute() when the client already has an Outbox.
+const StrOutboxEnabled = "Outbox is already enabled"
+
+// StrOutboxWaiting is the error of the Publish requests the Outbox can't store
+// while stored messages are waiting.
+const StrOutboxWaiting = "Outbox messages are waiting, the delivery confirmation and the push payloads can't be published before them"
+
+// Outbox stores the messages published while the network is down and
+// publishes them again, in order, once the network is back.
+//
+// When a Publish request fails with a ConnectionError the message is stored
+// and Execute returns a PublishQueuedError with the ID of the stored entry.
+// While messages are waiting, the new messages are stored behind them to keep
+// the order. The stored messages are published again when the subscribe loop
+// reconnects, every RetryInterval, or on Replay. The final timetoken or the
+// permanent failure of each stored message is sent to the OnResult handler.
+//
+// The messages published with ConfirmDelivery or a PushPayload are not
+// stored, they fail with StrOutboxWaiting while stored messages are waiting.
+type Outbox struct {
+	sync.Mutex
+
+	pubnub        *PubNub
+	store         OutboxStore
+	retryInterval time.Duration
+	onResult      func(*OutboxResult)
+
+	entries   []OutboxEntry
+	replaying bool
+	timer     *time.Timer
+	closed    bool
+}
+
+// OutboxResult is the result of a stored message published again, Error is
+// set when the message can't be published, it is then removed from the store.
+type OutboxResult struct {
+	Entry     OutboxEntry
+	Timetoken int64
+	Status    StatusResponse
+	Error     error
+}
+
+type outboxBuilder struct {
+	opts *outboxOpts
+}
+
+type outboxOpts struct {
+	pubnub *PubNub
+
+	// default: NewMemoryOutboxStore()
+	Store OutboxStore
+	// default: reconnectionInterval
+	RetryInterval time.Duration
+	OnResult      func(*OutboxResult)
+}
+
+func newOutboxBuilder(pubnub *PubNub) *outboxBuilder {
+	builder := outboxBuilder{
+		opts: &outboxOpts{
+			pubnub:        pubnub,
+			RetryInterval: reconnectionInterval * time.Second,
+		},
+	}
+
+	return &builder
+}
+
+// Store sets the OutboxStore persisting the messages, use a FileOutboxStore
+// to publish the messages stored before a restart.
+func (b *outboxBuilder) Store(store OutboxStore) *outboxBuilder {
+	b.opts.Store = store
+
+	return b
+}
+
+// RetryInterval sets how often the stored messages are published again while
+// the subscribe loop is not running.
+func (b *outboxBuilder) RetryInterval(interval time.Duration) *outboxBuilder {
+	b.opts.RetryInterval = interval
+
+	return b
+}
+
+// OnResult sets the handler that receives the result of every stored message.
+func (b *outboxBuilder) OnResult(handler func(*OutboxResult)) *outboxBuilder {
+	b.opts.OnResult = handler
+
+	return b
+}
+
+// Execute loads the stored messages and enables the Outbox of the client,
+// the loaded messages are published again right away.
+func (b *outboxBuilder) Execute() (*Outbox, error) {
+	if err := b.opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if b.opts.Store == nil {
+		b.opts.Store = NewMemoryOutboxStore()
+	}
+
+	entries, err := b.opts.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	outbox := &Outbox{
+		pubnub:        b.opts.pubnub,
+		store:         b.opts.Store,
+		retryInterval: b.opts.RetryInterval,
+		onResult:      b.opts.OnResult,
+		entries:       entries,
+	}
+
+	if !b.opts.pubnub.setOutbox(outbox) {
+		return nil, pnerr.NewValidationError(PNPublishOperation.String(), StrOutboxEnabled)
+	}
+
+	if len(entries) > 0 {
+		go outbox.Replay()
+	}
+
+	return outbox, nil
+}
+
+func (o *outboxOpts) validate() error {
+	if o.pubnub.Config.PublishKey == "" {
+		return pnerr.NewValidationError(PNPublishOperation.String(), StrMissingPubKey)
+	}
+
+	if o.RetryInterval <= 0 {
+		return pnerr.NewValidationError(PNPublishOperation.String(), "RetryInterval must be greater than 0")
+	}
+
+	return nil
+}
+
+// Len returns the number of stored messages.
+func (ob *Outbox) Len() int {
+	ob.Lock()
+	defer ob.Unlock()
+
+	return len(ob.entries)
+}
+
+// Entries returns the stored messages in the order they are published.
+func (ob *Outbox) Entries() []OutboxEntry {
+	ob.Lock()
+	defer ob.Unlock()
+
+	return append([]OutboxEntry{}, ob.entries...)
+}
+
+// Close disables the Outbox of the client, the stored messages stay in the
+// store and are loaded by the next Outbox.
+func (ob *Outbox) Close() {
+	ob.Lock()
+	ob.closed = true
+	if ob.timer != nil {
+		ob.timer.Stop()
+		ob.timer = nil
+	}
+	ob.Unlock()
+
+	ob.pubnub.removeOutbox(ob)
+}
+
+// publish runs the Publish request and stores the message when the network
+// is down or when older messages are waiting. The requests with a cancelled
+// context are not stored.
+func (ob *Outbox) publish(o *publishOpts) (*PublishResponse, StatusResponse, error) {
+	if err := o.validate(); err != nil {
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	if ob.Len() > 0 {
+		if o.cancelled() {
+			err := pnerr.NewConnectionError("Failed to execute request", o.ctx.Err())
+			return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+		}
+		return ob.queue(o, createStatus(PNUnknownCategory, "", ResponseInfo{}, nil), nil)
+	}
+
+	resp, status, err := o.send()
+	// the ConnectionError of a cancelled context isn't a network failure
+	if _, ok := err.(*pnerr.ConnectionError); ok && !o.cancelled() {
+		return ob.queue(o, status, err)
+	}
+
+	return resp, status, err
+}
+
+func (ob *Outbox) queue(o *publishOpts, status StatusResponse, origErr error) (*PublishResponse, StatusResponse, error) {
+	entry, err := newOutboxEntry(o)
+	if err == nil {
+		err = ob.add(entry)
+	}
+	if err != nil {
+		ob.pubnub.Config.Log.Println("outbox: can't store the message", err)
+		if origErr == nil {
+			origErr = err
+		}
+		status.Error = origErr
+		return emptyPublishResponse, status, origErr
+	}
+
+	e := pnerr.NewPublishQueuedError(entry.ID, origErr)
+	status.Error = e
+	ob.pubnub.Config.Log.Println(fmt.Sprintf("outbox: message %s stored", entry.ID))
+
+	if origErr == nil {
+		// older messages are waiting, the network may be back already
+		go ob.Replay()
+	} else {
+		ob.scheduleRetry()
+	}
+
+	return emptyPublishResponse, status, e
+}
+
+func (ob *Outbox) add(entry OutboxEntry) error {
+	ob.Lock()
+	defer ob.Unlock()
+
+	if err := ob.store.Add(entry); err != nil {
+		return err
+	}
+	ob.entries = append(ob.entries, entry)
+
+	return nil
+}
+
+// Replay publishes the stored messages in order. It stops at the first
+// message failing with a ConnectionError, the other failures are permanent
+// and the message is removed. Replay is called when the subscribe loop
+// reconnects and every RetryInterval while messages are waiting.
+func (ob *Outbox) Replay() {
+	ob.Lock()
+	if ob.replaying || ob.closed {
+		ob.Unlock()
+		return
+	}
+	ob.replaying = true
+	if ob.timer != nil {
+		ob.timer.Stop()
+		ob.timer = nil
+	}
+	ob.Unlock()
+
+	for {
+		ob.Lock()
+		if len(ob.entries) == 0 || ob.closed {
+			// reset under the same lock as the check, a message stored
+			// after it starts a new Replay
+			ob.replaying = false
+			ob.Unlock()
+			return
+		}
+		entry := ob.entries[0]
+		ob.Unlock()
+
+		resp, status, err := entry.publishOpts(ob.pubnub).send()
+		if _, ok := err.(*pnerr.ConnectionError); ok {
+			ob.Lock()
+			ob.replaying = false
+			ob.Unlock()
+
+			ob.scheduleRetry()
+			return
+		}
+
+		result := &OutboxResult{
+			Entry:  entry,
+			Status: status,
+			Error:  err,
+		}
+		if err == nil {
+			result.Timetoken = resp.Timestamp
+		}
+
+		ob.Lock()
+		if removeErr := ob.store.Remove(entry.ID); removeErr != nil {
+			ob.pubnub.Config.Log.Println("outbox: can't remove the message", entry.ID, removeErr)
+		}
+		ob.entries = removeOutboxEntry(ob.entries, entry.ID)
+		ob.Unlock()
+
+		if ob.onResult != nil {
+			ob.onResult(result)
+		}
+	}
+}
+
+func (ob *Outbox) scheduleRetry() {
+	ob.Lock()
+	defer ob.Unlock()
+
+	if ob.timer != nil || ob.replaying || ob.closed {
+		return
+	}
+
+	ob.timer = time.AfterFunc(ob.retryInterval, func() {
+		ob.Lock()
+		ob.timer = nil
+		ob.Unlock()
+
+		ob.Replay()
+	})
+}
+
+func newOutboxEntry(o *publishOpts) (OutboxEntry, error) {
+	entry := OutboxEntry{
+		ID:             utils.UUID(),
+		Channel:        o.Channel,
+		UsePost:        o.UsePost,
+		DoNotReplicate: o.DoNotReplicate,
+		QueryParam:     o.QueryParam,
+		Queued:         time.Now().UnixNano(),
+	}
+
+	// the message is stored encrypted, it is published again as is
+	message, err := o.serializedMessage()
+	if err != nil {
+		return entry, err
+	}
+	if !json.Valid([]byte(message)) {
+		return entry, pnerr.NewBuildRequestError("Message is not JSON serialized.")
+	}
+	entry.Message = json.RawMessage(message)
+
+	if o.Meta != nil {
+		meta, err := json.Marshal(o.Meta)
+		if err != nil {
+			return entry, err
+		}
+		entry.Meta = meta
+	}
+
+	if o.setTTL {
+		ttl := o.TTL
+		entry.TTL = &ttl
+	}
+
+	if o.setShouldStore {
+		store := o.ShouldStore
+		entry.ShouldStore = &store
+	}
+
+	return entry, nil
+}
+
+func (e OutboxEntry) publishOpts(pubnub *PubNub) *publishOpts {
+	// the stored message is already serialized and encrypted
+	builder := newPublishBuilder(pubnub).
+		Channel(e.Channel).
+		Message(string(e.Message)).
+		Serialize(false).
+		CipherKey("").
+		UsePost(e.UsePost).
+		DoNotReplicate(e.DoNotReplicate).
+		QueryParam(e.QueryParam)
+
+	if e.Meta != nil {
+		builder.Meta(e.Meta)
+	}
+
+	if e.TTL != nil {
+		builder.TTL(*e.TTL)
+	}
+
+	if e.ShouldStore != nil {
+		builder.ShouldStore(*e.ShouldStore)
+	}
+
+	return builder.opts
+}
@@ -0,0 +1,187 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// OutboxEntry is a message published while the network was down, stored
+// until it is published again.
+type OutboxEntry struct {
+	ID      string `json:"id"`
+	Channel string `json:"channel"`
+	// Message is the serialized message, already encrypted when a cipher key
+	// is used, the key is not stored.
+	Message json.RawMessage `json:"message"`
+	Meta    json.RawMessage `json:"meta,omitempty"`
+	// TTL and ShouldStore are nil when not set on the Publish request.
+	TTL            *int              `json:"ttl,omitempty"`
+	ShouldStore    *bool             `json:"store,omitempty"`
+	UsePost        bool              `json:"post,omitempty"`
+	DoNotReplicate bool              `json:"norep,omitempty"`
+	QueryParam     map[string]string `json:"query,omitempty"`
+	// Queued is the unix time in nanoseconds when the message was stored.
+	Queued int64 `json:"queued"`
+}
+
+// OutboxStore persists the messages of the Outbox. Load returns the entries
+// in the order they were added.
+type OutboxStore interface {
+	Load() ([]OutboxEntry, error)
+	Add(entry OutboxEntry) error
+	Remove(id string) error
+}
+
+// MemoryOutboxStore keeps the entries in memory, they are lost when the
+// process exits.
+type MemoryOutboxStore struct {
+	sync.Mutex
+
+	entries []OutboxEntry
+}
+
+// NewMemoryOutboxStore returns an empty MemoryOutboxStore.
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{}
+}
+
+// Load returns a copy of the entries.
+func (s *MemoryOutboxStore) Load() ([]OutboxEntry, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return append([]OutboxEntry{}, s.entries...), nil
+}
+
+// Add appends the entry.
+func (s *MemoryOutboxStore) Add(entry OutboxEntry) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.entries = append(s.entries, entry)
+
+	return nil
+}
+
+// Remove removes the entry with the id.
+func (s *MemoryOutboxStore) Remove(id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.entries = removeOutboxEntry(s.entries, id)
+
+	return nil
+}
+
+// FileOutboxStore keeps the entries in a JSON file, the file is rewritten on
+// every change through a temporary file renamed over it.
+type FileOutboxStore struct {
+	sync.Mutex
+
+	path string
+}
+
+// NewFileOutboxStore returns a FileOutboxStore writing the file at path, the
+// file is created with the first entry.
+func NewFileOutboxStore(path string) *FileOutboxStore {
+	return &FileOutboxStore{
+		path: path,
+	}
+}
+
+// Load reads the entries of the file.
+func (s *FileOutboxStore) Load() ([]OutboxEntry, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.read()
+}
+
+// Add appends the entry to the file.
+func (s *FileOutboxStore) Add(entry OutboxEntry) error {
+	s.Lock()
+	defer s.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	return s.write(append(entries, entry))
+}
+
+// Remove removes the entry with the id from the file.
+func (s *FileOutboxStore) Remove(id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	return s.write(removeOutboxEntry(entries, id))
+}
+
+func (s *FileOutboxStore) read() ([]OutboxEntry, error) {
+	entries := []OutboxEntry{}
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *FileOutboxStore) write(entries []OutboxEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func removeOutboxEntry(entries []OutboxEntry, id string) []OutboxEntry {
+	kept := []OutboxEntry{}
+	for _, entry := range entries {
+		if entry.ID != id {
+			kept = append(kept, entry)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,58 @@
+package pubnub
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryOutboxStore(t *testing.T) {
+	assert := assert.New(t)
+	store := NewMemoryOutboxStore()
+
+	store.Add(OutboxEntry{ID: "1"})
+	store.Add(OutboxEntry{ID: "2"})
+	store.Add(OutboxEntry{ID: "3"})
+	store.Remove("2")
+
+	entries, err := store.Load()
+	assert.Nil(err)
+	assert.Equal(2, len(entries))
+	assert.Equal("1", entries[0].ID)
+	assert.Equal("3", entries[1].ID)
+}
+
+func TestFileOutboxStore(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "outbox")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "outbox.json")
+
+	entries, err := NewFileOutboxStore(path).Load()
+	assert.Nil(err)
+	assert.Equal(0, len(entries))
+
+	ttl := 10
+	store := NewFileOutboxStore(path)
+	assert.Nil(store.Add(OutboxEntry{ID: "1", Channel: "ch", Message: []byte(`"a"`), TTL: &ttl}))
+	assert.Nil(store.Add(OutboxEntry{ID: "2", Channel: "ch", Message: []byte(`{"b":2}`)}))
+	assert.Nil(store.Add(OutboxEntry{ID: "3", Channel: "ch", Message: []byte(`3`)}))
+	assert.Nil(store.Remove("1"))
+
+	// the entries are loaded by a new store after a restart
+	entries, err = NewFileOutboxStore(path).Load()
+	assert.Nil(err)
+	assert.Equal(2, len(entries))
+	assert.Equal("2", entries[0].ID)
+	assert.Equal(`{"b":2}`, string(entries[0].Message))
+	assert.Nil(entries[0].TTL)
+	assert.Equal("3", entries[1].ID)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Equal(1, len(files))
+}
@@ -0,0 +1,362 @@
+package pubnub
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// outboxTransport fails the requests while offline and records the
+// published channels, the channel "invalid" is answered with a 400.
+type outboxTransport struct {
+	sync.Mutex
+
+	offline  bool
+	channels []string
+}
+
+func (t *outboxTransport) setOffline(offline bool) {
+	t.Lock()
+	t.offline = offline
+	t.Unlock()
+}
+
+func (t *outboxTransport) published() []string {
+	t.Lock()
+	defer t.Unlock()
+
+	return append([]string{}, t.channels...)
+}
+
+func (t *outboxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.offline {
+		return nil, errors.New("network is unreachable")
+	}
+
+	parsedURL, _ := req.URL.Parse(req.URL.String())
+	channel := strings.Split(parsedURL.EscapedPath(), "/")[5]
+
+	if channel == "invalid" {
+		return &http.Response{
+			StatusCode: 400,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`[0,"Invalid Channel","15191382475826880"]`)),
+			Request:    req,
+		}, nil
+	}
+
+	t.channels = append(t.channels, channel)
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`[1,"Sent","15191382475826880"]`)),
+		Request:    req,
+	}, nil
+}
+
+func newOutboxTestPubNub(transport *outboxTransport) *PubNub {
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(&http.Client{Transport: transport})
+
+	return pn
+}
+
+func collectOutboxResults(results chan *OutboxResult, count int) []*OutboxResult {
+	collected := []*OutboxResult{}
+	for len(collected) < count {
+		select {
+		case result := <-results:
+			collected = append(collected, result)
+		case <-time.After(5 * time.Second):
+			return collected
+		}
+	}
+
+	return collected
+}
+
+func TestOutboxValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	outbox, err := pn.Outbox().RetryInterval(0).Execute()
+	assert.Nil(outbox)
+	assert.Contains(err.Error(), "RetryInterval must be greater than 0")
+
+	outbox, err = pn.Outbox().Execute()
+	assert.Nil(err)
+	assert.Equal(outbox, pn.GetOutbox())
+
+	_, err = pn.Outbox().Execute()
+	assert.Contains(err.Error(), StrOutboxEnabled)
+
+	outbox.Close()
+	assert.Nil(pn.GetOutbox())
+}
+
+func TestOutboxStoresOfflinePublishes(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+	results := make(chan *OutboxResult, 10)
+
+	outbox, _ := pn.Outbox().
+		RetryInterval(time.Hour).
+		OnResult(func(result *OutboxResult) {
+			results <- result
+		}).
+		Execute()
+
+	_, _, err := pn.Publish().Channel("ch1").Message(map[string]interface{}{"n": 1}).TTL(5).Execute()
+	queued, ok := err.(*pnerr.PublishQueuedError)
+	assert.True(ok)
+	_, ok = queued.OrigError.(*pnerr.ConnectionError)
+	assert.True(ok)
+
+	_, _, err = pn.Publish().Channel("ch2").Message("two").Execute()
+	assert.NotNil(err)
+
+	entries := outbox.Entries()
+	assert.Equal(2, len(entries))
+	assert.Equal(queued.ID, entries[0].ID)
+	assert.Equal("ch1", entries[0].Channel)
+	assert.Equal(`{"n":1}`, string(entries[0].Message))
+	assert.Equal(5, *entries[0].TTL)
+	assert.Nil(entries[0].ShouldStore)
+
+	transport.setOffline(false)
+	outbox.Replay()
+
+	collected := collectOutboxResults(results, 2)
+	assert.Equal(2, len(collected))
+	assert.Equal(queued.ID, collected[0].Entry.ID)
+	assert.Equal(int64(15191382475826880), collected[0].Timetoken)
+	assert.Nil(collected[0].Error)
+	assert.Equal("ch2", collected[1].Entry.Channel)
+	assert.Equal([]string{"ch1", "ch2"}, transport.published())
+	assert.Equal(0, outbox.Len())
+
+	outbox.Close()
+}
+
+func TestOutboxKeepsOrderBehindWaitingMessages(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+	results := make(chan *OutboxResult, 10)
+
+	outbox, _ := pn.Outbox().
+		RetryInterval(time.Hour).
+		OnResult(func(result *OutboxResult) {
+			results <- result
+		}).
+		Execute()
+
+	pn.Publish().Channel("first").Message("a").Execute()
+	transport.setOffline(false)
+
+	// the network is back but the first message is still waiting
+	_, _, err := pn.Publish().Channel("second").Message("b").Execute()
+	_, ok := err.(*pnerr.PublishQueuedError)
+	assert.True(ok)
+
+	collected := collectOutboxResults(results, 2)
+	assert.Equal(2, len(collected))
+	assert.Equal([]string{"first", "second"}, transport.published())
+
+	// nothing is waiting, the messages are published right away
+	resp, _, err := pn.Publish().Channel("third").Message("c").Execute()
+	assert.Nil(err)
+	assert.Equal(int64(15191382475826880), resp.Timestamp)
+
+	outbox.Close()
+}
+
+func TestOutboxDoesNotStoreCancelledPublishes(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+
+	outbox, _ := pn.Outbox().RetryInterval(time.Hour).Execute()
+
+	ctx, cancel := contextWithCancel(backgroundContext)
+	cancel()
+
+	_, _, err := pn.PublishWithContext(ctx).Channel("ch").Message("a").Execute()
+	_, ok := err.(*pnerr.ConnectionError)
+	assert.True(ok)
+	assert.Equal(0, outbox.Len())
+
+	// stored behind a waiting message unless cancelled
+	pn.Publish().Channel("ch").Message("b").Execute()
+	assert.Equal(1, outbox.Len())
+
+	_, _, err = pn.PublishWithContext(ctx).Channel("ch").Message("c").Execute()
+	_, ok = err.(*pnerr.ConnectionError)
+	assert.True(ok)
+	assert.Equal(1, outbox.Len())
+
+	outbox.Close()
+}
+
+func TestOutboxRejectsUnstoredPublishesWhileWaiting(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+	pn.subscriptionManager.stateManager.adaptSubscribeOperation(&SubscribeOperation{
+		Channels: []string{"ch"},
+	})
+
+	outbox, _ := pn.Outbox().RetryInterval(time.Hour).Execute()
+
+	pn.Publish().Channel("ch").Message("a").Execute()
+	assert.Equal(1, outbox.Len())
+
+	_, _, err := pn.Publish().Channel("ch").Message("b").ConfirmDelivery(time.Second).Execute()
+	assert.Contains(err.Error(), StrOutboxWaiting)
+
+	_, _, err = pn.Publish().Channel("ch").
+		PushPayload(NewPushPayload().FCM(&PNFCMPayload{Notification: &PNFCMNotification{Body: "hi"}})).
+		Execute()
+	assert.Contains(err.Error(), StrOutboxWaiting)
+
+	assert.Equal(1, outbox.Len())
+	assert.Equal(0, len(transport.published()))
+
+	outbox.Close()
+}
+
+func TestOutboxPermanentFailure(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+	results := make(chan *OutboxResult, 10)
+
+	outbox, _ := pn.Outbox().
+		RetryInterval(time.Hour).
+		OnResult(func(result *OutboxResult) {
+			results <- result
+		}).
+		Execute()
+
+	pn.Publish().Channel("invalid").Message("a").Execute()
+	pn.Publish().Channel("ch").Message("b").Execute()
+
+	transport.setOffline(false)
+	outbox.Replay()
+
+	collected := collectOutboxResults(results, 2)
+	assert.Equal(2, len(collected))
+	assert.NotNil(collected[0].Error)
+	assert.Equal(400, collected[0].Status.StatusCode)
+	assert.Nil(collected[1].Error)
+	assert.Equal(0, outbox.Len())
+
+	outbox.Close()
+}
+
+func TestOutboxRetryInterval(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{offline: true}
+	pn := newOutboxTestPubNub(transport)
+	results := make(chan *OutboxResult, 10)
+
+	outbox, _ := pn.Outbox().
+		RetryInterval(20 * time.Millisecond).
+		OnResult(func(result *OutboxResult) {
+			results <- result
+		}).
+		Execute()
+
+	pn.Publish().Channel("ch").Message("a").Execute()
+	time.Sleep(50 * time.Millisecond)
+	transport.setOffline(false)
+
+	collected := collectOutboxResults(results, 1)
+	assert.Equal(1, len(collected))
+	assert.Nil(collected[0].Error)
+
+	outbox.Close()
+}
+
+func TestOutboxReplaysLoadedEntries(t *testing.T) {
+	assert := assert.New(t)
+	transport := &outboxTransport{}
+	pn := newOutboxTestPubNub(transport)
+	results := make(chan *OutboxResult, 10)
+
+	store := NewMemoryOutboxStore()
+	store.Add(OutboxEntry{ID: "1", Channel: "stored", Message: []byte(`{"big":12345678901234567890}`)})
+
+	outbox, err := pn.Outbox().
+		Store(store).
+		OnResult(func(result *OutboxResult) {
+			results <- result
+		}).
+		Execute()
+	assert.Nil(err)
+
+	collected := collectOutboxResults(results, 1)
+	assert.Equal(1, len(collected))
+	assert.Equal("1", collected[0].Entry.ID)
+	assert.Equal([]string{"stored"}, transport.published())
+
+	entries, _ := store.Load()
+	assert.Equal(0, len(entries))
+
+	outbox.Close()
+}
+
+func TestOutboxEntryPublishOpts(t *testing.T) {
+	assert := assert.New(t)
+	store := false
+
+	entry := OutboxEntry{
+		Channel:     "ch",
+		Message:     []byte(`{"big":12345678901234567890}`),
+		Meta:        []byte(`{"m":1}`),
+		ShouldStore: &store,
+	}
+	opts := entry.publishOpts(pubnub)
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Contains(path, "12345678901234567890")
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(`{"m":1}`, query.Get("meta"))
+	assert.False(opts.Serialize)
+}
+
+func TestOutboxEntryStoresEncryptedMessage(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+
+	opts := newPublishBuilder(pn).Channel("ch").Message("test").CipherKey("testCipher").opts
+	entry, err := newOutboxEntry(opts)
+	assert.Nil(err)
+	assert.Equal(`"jC/yJ2y99BeYFYMQ7c53pg=="`, string(entry.Message))
+
+	stored, err := json.Marshal(entry)
+	assert.Nil(err)
+	assert.NotContains(string(stored), "testCipher")
+
+	// the stored message is not encrypted again with the CipherKey
+	path, err := entry.publishOpts(pn).buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/ch/0/%22jC%2FyJ2y99BeYFYMQ7c53pg%3D%3D%22", path)
+}
@@ -0,0 +1,323 @@
+package pubnub
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+)
+
+const (
+	pnPermissionRead   = "read"
+	pnPermissionWrite  = "write"
+	pnPermissionManage = "manage"
+	pnPermissionDelete = "delete"
+)
+
+const (
+	pnResourceChannel = "channel"
+	pnResourceGroup   = "channel group"
+	pnResourceSubKey  = "subkey"
+)
+
+// PermissionCache remembers the permissions returned by Grant, Revoke and
+// Audit and the permissions of the tokens set with SetToken, until their TTL
+// expires. Like the server, the permissions of the auth key, of the channel
+// or channel group and of the subscribe key are combined, a permission granted
+// on any level is allowed.
+type PermissionCache struct {
+	sync.RWMutex
+
+	entries  map[permissionKey]*permissionEntry
+	patterns map[string][]*permissionPattern
+}
+
+type permissionKey struct {
+	authKey      string
+	resourceType string
+	name         string
+}
+
+type permissionEntry struct {
+	permissions PNAccessManagerKeyData
+	expires     time.Time
+}
+
+type permissionPattern struct {
+	resourceType string
+	pattern      *regexp.Regexp
+	entry        *permissionEntry
+}
+
+func newPermissionCache() *PermissionCache {
+	return &PermissionCache{
+		entries:  make(map[permissionKey]*permissionEntry),
+		patterns: make(map[string][]*permissionPattern),
+	}
+}
+
+func newPermissionEntry(read, write, manage, del bool, ttl int) *permissionEntry {
+	entry := &permissionEntry{
+		permissions: PNAccessManagerKeyData{
+			ReadEnabled:   read,
+			WriteEnabled:  write,
+			ManageEnabled: manage,
+			DeleteEnabled: del,
+			TTL:           ttl,
+		},
+	}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(time.Duration(ttl) * time.Minute)
+	}
+	return entry
+}
+
+func (e *permissionEntry) expired() bool {
+	return !e.expires.IsZero() && time.Now().After(e.expires)
+}
+
+// merge adds the permissions of another level, keeping the first expiration.
+func (e *permissionEntry) merge(other *permissionEntry) {
+	e.permissions.ReadEnabled = e.permissions.ReadEnabled || other.permissions.ReadEnabled
+	e.permissions.WriteEnabled = e.permissions.WriteEnabled || other.permissions.WriteEnabled
+	e.permissions.ManageEnabled = e.permissions.ManageEnabled || other.permissions.ManageEnabled
+	e.permissions.DeleteEnabled = e.permissions.DeleteEnabled || other.permissions.DeleteEnabled
+	e.permissions.GetEnabled = e.permissions.GetEnabled || other.permissions.GetEnabled
+	e.permissions.UpdateEnabled = e.permissions.UpdateEnabled || other.permissions.UpdateEnabled
+	e.permissions.JoinEnabled = e.permissions.JoinEnabled || other.permissions.JoinEnabled
+
+	if !other.expires.IsZero() && (e.expires.IsZero() || other.expires.Before(e.expires)) {
+		e.expires = other.expires
+	}
+}
+
+func (e *permissionEntry) allows(permission string) bool {
+	switch permission {
+	case pnPermissionRead:
+		return e.permissions.ReadEnabled
+	case pnPermissionWrite:
+		return e.permissions.WriteEnabled
+	case pnPermissionManage:
+		return e.permissions.ManageEnabled
+	case pnPermissionDelete:
+		return e.permissions.DeleteEnabled
+	}
+	return false
+}
+
+// addGrantResponse stores the permissions of a Grant, Revoke or Audit response.
+func (c *PermissionCache) addGrantResponse(resp *GrantResponse) {
+	if resp == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if resp.Level == pnResourceSubKey {
+		c.entries[permissionKey{resourceType: pnResourceSubKey}] = newPermissionEntry(
+			resp.ReadEnabled, resp.WriteEnabled, resp.ManageEnabled, resp.DeleteEnabled, resp.TTL)
+	}
+
+	c.addEntities(pnResourceChannel, resp.Channels, resp.TTL)
+	c.addEntities(pnResourceGroup, resp.ChannelGroups, resp.TTL)
+}
+
+func (c *PermissionCache) addEntities(resourceType string,
+	entities map[string]*PNPAMEntityData, defaultTTL int) {
+	for name, entity := range entities {
+		ttl := entity.TTL
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+
+		// The permissions of the entity are only returned without auth keys
+		if len(entity.AuthKeys) == 0 {
+			c.entries[permissionKey{resourceType: resourceType, name: name}] = newPermissionEntry(
+				entity.ReadEnabled, entity.WriteEnabled, entity.ManageEnabled, entity.DeleteEnabled, ttl)
+			continue
+		}
+
+		for authKey, keyData := range entity.AuthKeys {
+			c.entries[permissionKey{authKey: authKey, resourceType: resourceType, name: name}] = newPermissionEntry(
+				keyData.ReadEnabled, keyData.WriteEnabled, keyData.ManageEnabled, keyData.DeleteEnabled, ttl)
+		}
+	}
+}
+
+// addToken stores the permissions of a parsed token, the token is the auth key.
+func (c *PermissionCache) addToken(token string, parsed *PNToken) {
+	expires := time.Unix(parsed.Timestamp, 0).Add(time.Duration(parsed.TTL) * time.Minute)
+	entry := func(p ChannelPermissions) *permissionEntry {
+		return &permissionEntry{
+			permissions: PNAccessManagerKeyData{
+				ReadEnabled:   p.Read,
+				WriteEnabled:  p.Write,
+				ManageEnabled: p.Manage,
+				DeleteEnabled: p.Delete,
+				TTL:           parsed.TTL,
+			},
+			expires: expires,
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	for name, p := range parsed.Resources.Channels {
+		c.entries[permissionKey{authKey: token, resourceType: pnResourceChannel, name: name}] = entry(p)
+	}
+	for name, p := range parsed.Resources.ChannelGroups {
+		c.entries[permissionKey{authKey: token, resourceType: pnResourceGroup, name: name}] = entry(
+			ChannelPermissions{Read: p.Read, Manage: p.Manage})
+	}
+
+	patterns := []*permissionPattern{}
+	for pattern, p := range parsed.Patterns.Channels {
+		if re, err := regexp.Compile(pattern); err == nil {
+			patterns = append(patterns, &permissionPattern{
+				resourceType: pnResourceChannel,
+				pattern:      re,
+				entry:        entry(p),
+			})
+		}
+	}
+	for pattern, p := range parsed.Patterns.ChannelGroups {
+		if re, err := regexp.Compile(pattern); err == nil {
+			patterns = append(patterns, &permissionPattern{
+				resourceType: pnResourceGroup,
+				pattern:      re,
+				entry:        entry(ChannelPermissions{Read: p.Read, Manage: p.Manage}),
+			})
+		}
+	}
+	c.patterns[token] = patterns
+}
+
+// lookup returns the cached permissions of the resource for the auth key,
+// merged from every level, false if they are unknown or expired. The merged
+// permissions expire with the first expiring level.
+func (c *PermissionCache) lookup(authKey, resourceType, name string) (*permissionEntry, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	matching := []*permissionEntry{}
+	keys := []permissionKey{
+		{authKey: authKey, resourceType: resourceType, name: name},
+		{resourceType: resourceType, name: name},
+		{resourceType: pnResourceSubKey},
+	}
+	for _, key := range keys {
+		if entry, ok := c.entries[key]; ok && !entry.expired() {
+			matching = append(matching, entry)
+		}
+	}
+
+	for _, p := range c.patterns[authKey] {
+		if p.resourceType == resourceType && p.pattern.MatchString(name) && !p.entry.expired() {
+			matching = append(matching, p.entry)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil, false
+	}
+	if len(matching) == 1 {
+		return matching[0], true
+	}
+
+	merged := &permissionEntry{}
+	for _, entry := range matching {
+		merged.merge(entry)
+	}
+	if !merged.expires.IsZero() {
+		merged.permissions.TTL = int(math.Ceil(time.Until(merged.expires).Minutes()))
+	}
+
+	return merged, true
+}
+
+// Permissions returns the cached permissions of the channel for the auth key,
+// false if they are unknown or expired.
+func (c *PermissionCache) Permissions(authKey, channel string) (PNAccessManagerKeyData, bool) {
+	entry, ok := c.lookup(authKey, pnResourceChannel, channel)
+	if !ok {
+		return PNAccessManagerKeyData{}, false
+	}
+	return entry.permissions, true
+}
+
+// ChannelGroupPermissions returns the cached permissions of the channel group
+// for the auth key, false if they are unknown or expired.
+func (c *PermissionCache) ChannelGroupPermissions(authKey, group string) (PNAccessManagerKeyData, bool) {
+	entry, ok := c.lookup(authKey, pnResourceGroup, group)
+	if !ok {
+		return PNAccessManagerKeyData{}, false
+	}
+	return entry.permissions, true
+}
+
+// allows returns false only if the cached permissions deny the permission.
+func (c *PermissionCache) allows(authKey, resourceType, name, permission string) bool {
+	entry, ok := c.lookup(authKey, resourceType, name)
+
+	return !ok || entry.allows(permission)
+}
+
+// Clear removes all the cached permissions.
+func (c *PermissionCache) Clear() {
+	c.Lock()
+	c.entries = make(map[permissionKey]*permissionEntry)
+	c.patterns = make(map[string][]*permissionPattern)
+	c.Unlock()
+}
+
+// accessRequirements returns the permission and the resources required by a request.
+func accessRequirements(opts endpointOpts) (*PubNub, string, []string, []string) {
+	switch o := opts.(type) {
+	case *publishOpts:
+		return o.pubnub, pnPermissionWrite, []string{o.Channel}, nil
+	case *fireOpts:
+		return o.pubnub, pnPermissionWrite, []string{o.Channel}, nil
+	case *subscribeOpts:
+		return o.pubnub, pnPermissionRead, o.Channels, o.ChannelGroups
+	case *historyOpts:
+		return o.pubnub, pnPermissionRead, []string{o.Channel}, nil
+	case *fetchOpts:
+		return o.pubnub, pnPermissionRead, o.Channels, nil
+	case *historyDeleteOpts:
+		return o.pubnub, pnPermissionDelete, []string{o.Channel}, nil
+	}
+	return nil, "", nil, nil
+}
+
+// checkCachedPermissions returns an AccessDeniedError if the cached
+// permissions of the current auth key deny the request.
+func checkCachedPermissions(opts endpointOpts) error {
+	pn, permission, channels, groups := accessRequirements(opts)
+	if pn == nil {
+		return nil
+	}
+
+	config := opts.config()
+	authKey := config.currentAuthKey()
+
+	for _, ch := range channels {
+		if !pn.permissionCache.allows(authKey, pnResourceChannel, ch, permission) {
+			return pnerr.NewAccessDeniedError(opts.operationType().String(), permission,
+				fmt.Sprintf("%s %s", pnResourceChannel, ch))
+		}
+	}
+
+	for _, cg := range groups {
+		if !pn.permissionCache.allows(authKey, pnResourceGroup, cg, permission) {
+			return pnerr.NewAccessDeniedError(opts.operationType().String(), permission,
+				fmt.Sprintf("%s %s", pnResourceGroup, cg))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package pubnub
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionCacheGrantResponse(t *testing.T) {
+	assert := assert.New(t)
+	jsonBytes := []byte(`{"message":"Success","payload":{"level":"user","subscribe_key":"sub-key","ttl":1440,"channel":"ch1","auths":{"reader":{"r":1,"w":0,"m":0,"d":0}}},"service":"Access Manager","status":200}`)
+	resp, _, err := newGrantResponse(jsonBytes, StatusResponse{})
+	assert.Nil(err)
+
+	c := newPermissionCache()
+	c.addGrantResponse(resp)
+
+	assert.True(c.allows("reader", pnResourceChannel, "ch1", pnPermissionRead))
+	assert.False(c.allows("reader", pnResourceChannel, "ch1", pnPermissionWrite))
+	// Unknown permissions are allowed
+	assert.True(c.allows("other", pnResourceChannel, "ch1", pnPermissionWrite))
+	assert.True(c.allows("reader", pnResourceChannel, "ch2", pnPermissionWrite))
+
+	permissions, ok := c.Permissions("reader", "ch1")
+	assert.True(ok)
+	assert.Equal(1440, permissions.TTL)
+
+	c.Clear()
+	_, ok = c.Permissions("reader", "ch1")
+	assert.False(ok)
+}
+
+func TestPermissionCacheLevels(t *testing.T) {
+	assert := assert.New(t)
+	c := newPermissionCache()
+
+	c.addGrantResponse(&GrantResponse{
+		Level:       "subkey",
+		ReadEnabled: true,
+	})
+	c.addGrantResponse(&GrantResponse{
+		Level: "channel",
+		Channels: map[string]*PNPAMEntityData{
+			"open": {Name: "open", ReadEnabled: true, WriteEnabled: true},
+		},
+		ChannelGroups: map[string]*PNPAMEntityData{
+			"cg": {Name: "cg", ManageEnabled: true},
+		},
+	})
+
+	assert.True(c.allows("key", pnResourceChannel, "open", pnPermissionWrite))
+	assert.True(c.allows("key", pnResourceChannel, "other", pnPermissionRead))
+	assert.False(c.allows("key", pnResourceChannel, "other", pnPermissionWrite))
+	// the subscribe key grants the read on every channel group
+	assert.True(c.allows("key", pnResourceGroup, "cg", pnPermissionRead))
+	assert.False(c.allows("key", pnResourceGroup, "cg", pnPermissionWrite))
+
+	permissions, ok := c.ChannelGroupPermissions("key", "cg")
+	assert.True(ok)
+	assert.True(permissions.ManageEnabled)
+}
+
+func TestPermissionCacheMergesLevels(t *testing.T) {
+	assert := assert.New(t)
+	c := newPermissionCache()
+
+	c.addGrantResponse(&GrantResponse{
+		Level:        "subkey",
+		WriteEnabled: true,
+		TTL:          60,
+	})
+	c.addGrantResponse(&GrantResponse{
+		Level: "channel",
+		TTL:   5,
+		Channels: map[string]*PNPAMEntityData{
+			"audited": {Name: "audited", ReadEnabled: true},
+		},
+	})
+
+	assert.True(c.allows("key", pnResourceChannel, "audited", pnPermissionRead))
+	assert.True(c.allows("key", pnResourceChannel, "audited", pnPermissionWrite))
+	assert.False(c.allows("key", pnResourceChannel, "audited", pnPermissionManage))
+
+	permissions, ok := c.Permissions("key", "audited")
+	assert.True(ok)
+	assert.True(permissions.ReadEnabled)
+	assert.True(permissions.WriteEnabled)
+	assert.Equal(5, permissions.TTL)
+
+	// only the subscribe key level is left once the channel level expires
+	c.entries[permissionKey{resourceType: pnResourceChannel, name: "audited"}].expires = time.Now().Add(-time.Second)
+	permissions, ok = c.Permissions("key", "audited")
+	assert.True(ok)
+	assert.False(permissions.ReadEnabled)
+	assert.True(permissions.WriteEnabled)
+}
+
+func TestPermissionCacheExpires(t *testing.T) {
+	assert := assert.New(t)
+	c := newPermissionCache()
+
+	entry := newPermissionEntry(false, false, false, false, 1)
+	entry.expires = time.Now().Add(-time.Second)
+	c.entries[permissionKey{authKey: "key", resourceType: pnResourceChannel, name: "ch"}] = entry
+
+	assert.True(c.allows("key", pnResourceChannel, "ch", pnPermissionRead))
+}
+
+func TestPermissionCacheToken(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	token := base64.URLEncoding.EncodeToString(encodeTestCBOR(map[string]interface{}{
+		"v":   2,
+		"t":   int(time.Now().Unix()),
+		"ttl": 60,
+		"res": map[string]interface{}{
+			"chan": map[string]interface{}{"readonly": 1},
+			"grp":  map[string]interface{}{},
+			"uuid": map[string]interface{}{},
+		},
+		"pat": map[string]interface{}{
+			"chan": map[string]interface{}{"^chat-.*$": 3},
+		},
+		"sig": []byte{1},
+	}))
+
+	pn.SetToken(token)
+
+	assert.True(pn.CanRead("readonly"))
+	assert.False(pn.CanWrite("readonly"))
+	assert.True(pn.CanWrite("chat-1"))
+	assert.False(pn.CanDelete("chat-1"))
+	assert.True(pn.CanManage("unknown"))
+	assert.True(pn.CanReadChannelGroup("cg"))
+
+	// Another auth key doesn't use the permissions of the token
+	pn.SetToken("")
+	assert.True(pn.CanWrite("readonly"))
+}
+
+func TestPreflightAccessChecks(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.Config.AuthKey = "reader"
+	pn.SetClient(interceptor.GetClient())
+	pn.permissionCache.addGrantResponse(&GrantResponse{
+		Level: "user",
+		Channels: map[string]*PNPAMEntityData{
+			"ch": {
+				Name: "ch",
+				AuthKeys: map[string]*PNAccessManagerKeyData{
+					"reader": {ReadEnabled: true},
+				},
+			},
+		},
+	})
+
+	assert.False(pn.CanWrite("ch"))
+
+	// Sent when the checks are disabled, no stub matches
+	_, status, err := pn.Publish().Channel("ch").Message("hi").Execute()
+	assert.NotNil(err)
+	assert.NotEqual(PNAccessDeniedCategory, status.Category)
+
+	pn.Config.PreflightAccessChecks = true
+	_, status, err = pn.Publish().Channel("ch").Message("hi").Execute()
+	assert.Equal(PNAccessDeniedCategory, status.Category)
+	accessDenied, ok := err.(*pnerr.AccessDeniedError)
+	assert.True(ok)
+	assert.Equal("write", accessDenied.Permission)
+	assert.Equal("channel ch", accessDenied.Resource)
+	assert.Contains(err.Error(), "Forbidden: Publish requires the write permission on channel ch")
+}
@@ -109,3 +109,84 @@ func NewResponseParsingError(msg string,
 		OrigError: origError,
 	}
 }
+
+// Request known to be forbidden by the cached Access Manager permissions,
+// it was not sent to the server.
+type AccessDeniedError struct {
+	Operation  string
+	Permission string
+	Resource   string
+}
+
+func (e AccessDeniedError) Error() string {
+	return fmt.Sprintf("pubnub/access: Forbidden: %s requires the %s permission on %s",
+		e.Operation, e.Permission, e.Resource)
+}
+
+func NewAccessDeniedError(operation, permission, resource string) *AccessDeniedError {
+	return &AccessDeniedError{
+		Operation:  operation,
+		Permission: permission,
+		Resource:   resource,
+	}
+}
+
+// Published message not received back through the subscribe loop before the
+// delivery confirmation timeout, the Publish request itself succeeded.
+type DeliveryNotConfirmedError struct {
+	Channel   string
+	Timetoken int64
+}
+
+func (e DeliveryNotConfirmedError) Error() string {
+	return fmt.Sprintf("pubnub/delivery: message %d on %s was not received back before the timeout",
+		e.Timetoken, e.Channel)
+}
+
+func NewDeliveryNotConfirmedError(channel string, timetoken int64) *DeliveryNotConfirmedError {
+	return &DeliveryNotConfirmedError{
+		Channel:   channel,
+		Timetoken: timetoken,
+	}
+}
+
+// Publish request failed while the network was down, the message is stored
+// in the Outbox and published again once the network is back.
+type PublishQueuedError struct {
+	ID        string
+	OrigError error
+}
+
+func (e PublishQueuedError) Error() string {
+	if e.OrigError == nil {
+		return fmt.Sprintf("pubnub/outbox: message queued as %s behind the waiting messages", e.ID)
+	}
+	return fmt.Sprintf("pubnub/outbox: message queued as %s: %s", e.ID, e.OrigError)
+}
+
+func NewPublishQueuedError(id string, origError error) *PublishQueuedError {
+	return &PublishQueuedError{
+		ID:        id,
+		OrigError: origError,
+	}
+}
+
+// Published message larger than the publish limit, the request was not sent.
+type MessageTooLargeError struct {
+	Channel string
+	Size    int
+	Limit   int
+}
+
+func (e MessageTooLargeError) Error() string {
+	return fmt.Sprintf("pubnub/size: message on %s is %d bytes, larger than the limit of %d bytes",
+		e.Channel, e.Size, e.Limit)
+}
+
+func NewMessageTooLargeError(channel string, size, limit int) *MessageTooLargeError {
+	return &MessageTooLargeError{
+		Channel: channel,
+		Size:    size,
+		Limit:   limit,
+	}
+}
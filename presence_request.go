@@ -1,7 +1,7 @@
 package pubnub
 
 import (
-	"strings"
+	"github.com/pubnub/go/utils"
 )
 
 type presenceBuilder struct {
@@ -77,17 +77,13 @@ func (b *presenceBuilder) State(state map[string]interface{}) *presenceBuilder {
 func (b *presenceBuilder) Execute() {
 	if b.opts.connected {
 		for _, ch := range b.opts.channels {
-			if strings.Contains(ch, "-pnpres") {
-				ch = strings.Replace(ch, "-pnpres", "", -1)
-			}
+			ch = utils.ChannelFromPresence(ch)
 			b.opts.pubnub.heartbeatManager.Lock()
 			b.opts.pubnub.heartbeatManager.heartbeatChannels[ch] = newSubscriptionItem(ch)
 			b.opts.pubnub.heartbeatManager.Unlock()
 		}
 		for _, cg := range b.opts.channelGroups {
-			if strings.Contains(cg, "-pnpres") {
-				cg = strings.Replace(cg, "-pnpres", "", -1)
-			}
+			cg = utils.ChannelFromPresence(cg)
 			b.opts.pubnub.heartbeatManager.Lock()
 			b.opts.pubnub.heartbeatManager.heartbeatGroups[cg] = newSubscriptionItem(cg)
 			b.opts.pubnub.heartbeatManager.Unlock()
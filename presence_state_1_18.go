@@ -0,0 +1,92 @@
+//go:build go1.18
+// +build go1.18
+
+package pubnub
+
+// TypedStateChange is a PNStateChange with the state decoded into T.
+type TypedStateChange[T any] struct {
+	UUID              string
+	Channel           string
+	SubscribedChannel string
+	State             T
+	Timetoken         int64
+	Timestamp         int64
+	Error             error
+}
+
+// SetTypedState returns a Set State builder with the state marshalled from a struct.
+// Combined with Merge(true) only the marshalled fields update the cached state,
+// tag the fields with omitempty to send partial updates.
+func SetTypedState[T any](pn *PubNub, state T) (*setStateBuilder, error) {
+	return setTypedState(newSetStateBuilder(pn), state)
+}
+
+// SetTypedStateWithContext is the SetTypedState variant for SetStateWithContext.
+func SetTypedStateWithContext[T any](pn *PubNub, ctx Context, state T) (*setStateBuilder, error) {
+	return setTypedState(newSetStateBuilderWithContext(pn, ctx), state)
+}
+
+func setTypedState[T any](builder *setStateBuilder, state T) (*setStateBuilder, error) {
+	m, err := stateAsMap(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.State(m), nil
+}
+
+// DecodeState decodes a state returned by GetState or received with a presence event into T.
+func DecodeState[T any](state interface{}) (T, error) {
+	var v T
+	err := decodeState(state, &v)
+
+	return v, err
+}
+
+// GetCachedTypedState returns the last known state of the client UUID on a channel decoded into T.
+func GetCachedTypedState[T any](pn *PubNub, channel string) (T, bool, error) {
+	var v T
+
+	state, ok := pn.GetCachedState(channel)
+	if !ok {
+		return v, false, nil
+	}
+	err := decodeState(state, &v)
+
+	return v, true, err
+}
+
+// TypedStateChanges decodes the state changes received by the listener into T.
+// The returned channel is closed when done is closed.
+func TypedStateChanges[T any](listener *StateChangeListener,
+	done <-chan struct{}) <-chan *TypedStateChange[T] {
+	changes := make(chan *TypedStateChange[T])
+
+	go func() {
+		defer close(changes)
+
+		for {
+			select {
+			case <-done:
+				return
+			case stateChange := <-listener.StateChange:
+				change := &TypedStateChange[T]{
+					UUID:              stateChange.UUID,
+					Channel:           stateChange.Channel,
+					SubscribedChannel: stateChange.SubscribedChannel,
+					Timetoken:         stateChange.Timetoken,
+					Timestamp:         stateChange.Timestamp,
+				}
+				change.Error = decodeState(stateChange.State, &change.State)
+
+				select {
+				case changes <- change:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return changes
+}
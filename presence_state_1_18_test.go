@@ -0,0 +1,75 @@
+//go:build go1.18
+// +build go1.18
+
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedState struct {
+	Mood  string `json:"mood,omitempty"`
+	Level int    `json:"level,omitempty"`
+}
+
+func TestSetTypedState(t *testing.T) {
+	assert := assert.New(t)
+
+	o, err := SetTypedState(pubnub, typedState{Mood: "happy"})
+	assert.Nil(err)
+	o.Channels([]string{"ch"})
+
+	assert.Nil(o.opts.validate())
+	assert.Equal(`{"mood":"happy"}`, o.opts.stringState)
+}
+
+func TestDecodeState(t *testing.T) {
+	assert := assert.New(t)
+
+	state, err := DecodeState[typedState](map[string]interface{}{"mood": "happy", "level": float64(3)})
+	assert.Nil(err)
+	assert.Equal(typedState{Mood: "happy", Level: 3}, state)
+}
+
+func TestGetCachedTypedState(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, ok, err := GetCachedTypedState[typedState](pn, "ch")
+	assert.False(ok)
+	assert.Nil(err)
+
+	pn.subscriptionManager.stateManager.updateChannelState("ch", map[string]interface{}{"level": 2})
+
+	state, ok, err := GetCachedTypedState[typedState](pn, "ch")
+	assert.True(ok)
+	assert.Nil(err)
+	assert.Equal(typedState{Level: 2}, state)
+}
+
+func TestTypedStateChanges(t *testing.T) {
+	assert := assert.New(t)
+	listener := NewStateChangeListener()
+	done := make(chan struct{})
+
+	changes := TypedStateChanges[typedState](listener, done)
+
+	go func() {
+		listener.StateChange <- &PNStateChange{
+			UUID:    "uuid",
+			Channel: "ch",
+			State:   map[string]interface{}{"mood": "sad"},
+		}
+	}()
+
+	change := <-changes
+	assert.Nil(change.Error)
+	assert.Equal("uuid", change.UUID)
+	assert.Equal(typedState{Mood: "sad"}, change.State)
+
+	close(done)
+	_, open := <-changes
+	assert.False(open)
+}
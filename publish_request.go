@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
@@ -28,24 +29,58 @@ type publishOpts struct {
 	Message interface{}
 	Meta    interface{}
 
+	PushPayload *PushPayload
+
 	UsePost        bool
 	ShouldStore    bool
+	CipherKey      string
 	Serialize      bool
 	DoNotReplicate bool
 	QueryParam     map[string]string
 
+	// ConfirmTimeout is the time waited for the message to be received back
+	// through the subscribe loop, 0 disables the delivery confirmation.
+	ConfirmTimeout time.Duration
+
 	Transport http.RoundTripper
 
 	ctx Context
 
+	// seqn is assigned once, the signed URL of a request waiting for a
+	// worker or a retried request keeps the same sequence number
+	seqn int
+
 	// nil hacks
 	setTTL         bool
 	setShouldStore bool
+	setCipherKey   bool
 }
 
 // PublishResponse is the response after the execution on Publish and Fire operations.
 type PublishResponse struct {
 	Timestamp int64
+	// SequenceNumber is the seqn sent with the message, the subscribers
+	// receive it in PNMessage.
+	SequenceNumber int
+	// Confirmed is true when the message published with ConfirmDelivery was
+	// received back through the subscribe loop.
+	Confirmed bool
+}
+
+// PublishSize is the size of a Publish request as sent to the server, after
+// the encryption and the URL encoding.
+type PublishSize struct {
+	// Message is the size counted against Config.MaxMessageSize: the URL
+	// encoded channel and the message, URL encoded in the path of a GET
+	// request or as is in the body of a POST request.
+	Message int
+	// URL is the length of the request URL, with the meta and the signature.
+	URL int
+	// Body is the length of the body of a POST request.
+	Body int
+	// UsePost is true when the request is sent with POST, set with UsePost
+	// or because the GET URL is longer than Config.MaxURLLength.
+	UsePost bool
 }
 
 type publishBuilder struct {
@@ -124,13 +159,33 @@ func (b *publishBuilder) Message(msg interface{}) *publishBuilder {
 	return b
 }
 
-// Meta sets the Meta Payload for the Publish request.
+// Meta sets the Meta Payload for the Publish request, the Meta is never
+// encrypted.
 func (b *publishBuilder) Meta(meta interface{}) *publishBuilder {
 	b.opts.Meta = meta
 
 	return b
 }
 
+// PushPayload sets the push notification payloads published with the
+// Message. The Message is published under the pn_other key, encrypted when
+// a CipherKey is set, and can be omitted to send only the notifications.
+func (b *publishBuilder) PushPayload(payload *PushPayload) *publishBuilder {
+	b.opts.PushPayload = payload
+
+	return b
+}
+
+// CipherKey encrypts the message with the key instead of the key of the
+// channel in Config.CipherKeys or the Config.CipherKey, an empty key publishes
+// the message unencrypted.
+func (b *publishBuilder) CipherKey(key string) *publishBuilder {
+	b.opts.CipherKey = key
+	b.opts.setCipherKey = true
+
+	return b
+}
+
 // UsePost sends the Publish request using HTTP POST.
 func (b *publishBuilder) UsePost(post bool) *publishBuilder {
 	b.opts.UsePost = post
@@ -165,6 +220,19 @@ func (b *publishBuilder) DoNotReplicate(repl bool) *publishBuilder {
 	return b
 }
 
+// ConfirmDelivery makes Execute wait until the published message is received
+// back through the subscribe loop, as an end to end delivery confirmation.
+// The client must be subscribed to the channel, directly or through a
+// wildcard channel. The channel groups are not checked, the delivery of a
+// channel which is not in the subscribed groups times out. When the message
+// is not received before the timeout, Execute returns the PublishResponse
+// with a PNTimeoutCategory status and a DeliveryNotConfirmedError.
+func (b *publishBuilder) ConfirmDelivery(timeout time.Duration) *publishBuilder {
+	b.opts.ConfirmTimeout = timeout
+
+	return b
+}
+
 // Transport sets the Transport for the Publish request.
 func (b *publishBuilder) Transport(tr http.RoundTripper) *publishBuilder {
 	b.opts.Transport = tr
@@ -181,12 +249,120 @@ func (b *publishBuilder) QueryParam(queryParam map[string]string) *publishBuilde
 
 // Execute runs the Publish request.
 func (b *publishBuilder) Execute() (*PublishResponse, StatusResponse, error) {
-	rawJSON, status, err := executeRequest(b.opts)
+	outbox := b.opts.pubnub.GetOutbox()
+
+	// the Outbox doesn't store the confirmed publishes and the push payloads,
+	// they can't be published before the waiting messages
+	if b.opts.ConfirmTimeout > 0 || b.opts.PushPayload != nil {
+		if outbox != nil && outbox.Len() > 0 {
+			err := newValidationError(b.opts, StrOutboxWaiting)
+			return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+		}
+	}
+
+	if b.opts.ConfirmTimeout > 0 {
+		return b.executeConfirmed()
+	}
+
+	if outbox != nil && b.opts.PushPayload == nil {
+		return outbox.publish(b.opts)
+	}
+
+	return b.opts.send()
+}
+
+// send runs the Publish request.
+func (o *publishOpts) send() (*PublishResponse, StatusResponse, error) {
+	if err := o.checkSize(); err != nil {
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	rawJSON, status, err := executeRequest(o)
 	if err != nil {
 		return emptyPublishResponse, status, err
 	}
 
-	return newPublishResponse(rawJSON, status)
+	resp, status, err := newPublishResponse(rawJSON, status)
+	if err != nil {
+		return resp, status, err
+	}
+	resp.SequenceNumber = o.seqn
+
+	return resp, status, nil
+}
+
+// executeConfirmed runs the Publish request and waits for the message to be
+// received back, the message can be received before the response.
+func (b *publishBuilder) executeConfirmed() (*PublishResponse, StatusResponse, error) {
+	o := b.opts
+
+	if !o.isChannelSubscribed() {
+		err := newValidationError(o, "Delivery confirmation requires a subscription to the channel")
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	if err := o.checkSize(); err != nil {
+		return emptyPublishResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	if o.seqn == 0 {
+		o.seqn = o.pubnub.getPublishSequence()
+	}
+	timetokens := o.pubnub.deliveries.expect(o.Channel, o.seqn)
+	defer o.pubnub.deliveries.remove(o.Channel, o.seqn)
+
+	rawJSON, status, err := executeRequest(o)
+	if err != nil {
+		return emptyPublishResponse, status, err
+	}
+
+	resp, status, err := newPublishResponse(rawJSON, status)
+	if err != nil {
+		return resp, status, err
+	}
+	resp.SequenceNumber = o.seqn
+
+	var ctxDone <-chan struct{}
+	if o.ctx != nil {
+		ctxDone = o.ctx.Done()
+	}
+
+	timer := time.NewTimer(o.ConfirmTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case timetoken := <-timetokens:
+			if timetoken == resp.Timestamp {
+				resp.Confirmed = true
+				return resp, status, nil
+			}
+		case <-timer.C:
+			e := pnerr.NewDeliveryNotConfirmedError(o.Channel, resp.Timestamp)
+			status.Category = PNTimeoutCategory
+			status.Error = e
+			return resp, status, e
+		case <-ctxDone:
+			status.Category = PNCancelledCategory
+			status.Error = o.ctx.Err()
+			return resp, status, o.ctx.Err()
+		}
+	}
+}
+
+// Size returns the size of the Publish request with the current config,
+// without sending it. The request is reported as POST when the GET URL is
+// longer than Config.MaxURLLength, as Execute would send it.
+func (b *publishBuilder) Size() (*PublishSize, error) {
+	return b.opts.size()
+}
+
+// ExecuteAsync runs the Publish request asynchronously, the result of the Future is a *PublishResponse.
+func (b *publishBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
 }
 
 func (o *publishOpts) config() Config {
@@ -201,6 +377,11 @@ func (o *publishOpts) context() Context {
 	return o.ctx
 }
 
+// cancelled returns true if the context of the request is done.
+func (o *publishOpts) cancelled() bool {
+	return o.ctx != nil && o.ctx.Err() != nil
+}
+
 func (o *publishOpts) validate() error {
 	if o.config().PublishKey == "" {
 		return newValidationError(o, StrMissingPubKey)
@@ -214,6 +395,10 @@ func (o *publishOpts) validate() error {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if o.PushPayload != nil {
+		return o.PushPayload.validate(o)
+	}
+
 	if o.Message == nil {
 		return newValidationError(o, StrMissingMessage)
 	}
@@ -221,6 +406,123 @@ func (o *publishOpts) validate() error {
 	return nil
 }
 
+// checkSize fails when the message is larger than Config.MaxMessageSize and
+// sends the request with POST when the GET URL is longer than
+// Config.MaxURLLength.
+func (o *publishOpts) checkSize() error {
+	config := o.pubnub.Config
+	if config.MaxMessageSize <= 0 && config.MaxURLLength <= 0 {
+		return nil
+	}
+
+	size, err := o.size()
+	if err != nil {
+		return err
+	}
+
+	if config.MaxMessageSize > 0 && size.Message > config.MaxMessageSize {
+		return pnerr.NewMessageTooLargeError(o.Channel, size.Message, config.MaxMessageSize)
+	}
+
+	if size.UsePost && !o.UsePost {
+		config.Log.Println("Publish URL length", size.URL, "exceeds MaxURLLength, using POST")
+		o.UsePost = true
+	}
+
+	return nil
+}
+
+func (o *publishOpts) size() (*PublishSize, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	// the size is computed with the sequence number sent with the request
+	if o.seqn == 0 {
+		o.seqn = o.pubnub.getPublishSequence()
+	}
+
+	size, err := o.sizeWithMethod(o.UsePost)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxURLLength := o.pubnub.Config.MaxURLLength; !size.UsePost && maxURLLength > 0 && size.URL > maxURLLength {
+		return o.sizeWithMethod(true)
+	}
+
+	return size, nil
+}
+
+func (o *publishOpts) sizeWithMethod(post bool) (*PublishSize, error) {
+	opts := *o
+	opts.UsePost = post
+
+	u, err := buildURL(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := opts.serializedMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	size := &PublishSize{
+		Message: len(utils.URLEncode(o.Channel)),
+		URL:     len(u.String()),
+		UsePost: post,
+	}
+
+	if post {
+		size.Body = len(msg)
+		size.Message += len(msg)
+	} else {
+		size.Message += len(utils.URLEncode(msg))
+	}
+
+	return size, nil
+}
+
+// isChannelSubscribed returns true if the channel is subscribed directly or
+// through a wildcard channel. The channels of the channel groups are not
+// known by the client, the channel is assumed subscribed while a channel
+// group is.
+func (o *publishOpts) isChannelSubscribed() bool {
+	for _, ch := range o.pubnub.GetSubscribedChannels() {
+		if utils.MatchChannel(ch, o.Channel) {
+			return true
+		}
+	}
+
+	return len(o.pubnub.GetSubscribedGroups()) > 0
+}
+
+// cipherKey returns the key encrypting the message: the key of the request,
+// of the channel or of the config.
+func (o *publishOpts) cipherKey() string {
+	if o.setCipherKey {
+		return o.CipherKey
+	}
+
+	return o.pubnub.Config.cipherKeyForChannel(o.Channel)
+}
+
+func (o *publishOpts) pushPayloadProcessing() (string, error) {
+	composed, err := o.PushPayload.compose(o.Message, o.Serialize, o.cipherKey())
+	if err != nil {
+		return "", err
+	}
+
+	jsonEncBytes, errEnc := json.Marshal(composed)
+	if errEnc != nil {
+		o.pubnub.Config.Log.Printf("ERROR: Publish error: %s\n", errEnc.Error())
+		return "", errEnc
+	}
+
+	return string(jsonEncBytes), nil
+}
+
 func (o *publishOpts) encryptProcessing(cipherKey string) (string, error) {
 	var msg string
 	var errJSONMarshal error
@@ -246,8 +548,14 @@ func (o *publishOpts) encryptProcessing(cipherKey string) (string, error) {
 					o.pubnub.Config.Log.Printf("error in serializing: %v\n", errJSONMarshal)
 					return "", errJSONMarshal
 				}
-				v["pn_other"] = encMsg
-				jsonEncBytes, errEnc := json.Marshal(v)
+				// the message of the builder is kept as is, the request
+				// can be built again
+				encrypted := make(map[string]interface{}, len(v))
+				for key, value := range v {
+					encrypted[key] = value
+				}
+				encrypted["pn_other"] = encMsg
+				jsonEncBytes, errEnc := json.Marshal(encrypted)
 				if errEnc != nil {
 					o.pubnub.Config.Log.Printf("ERROR: Publish error: %s\n", errEnc.Error())
 					return "", errEnc
@@ -281,30 +589,9 @@ func (o *publishOpts) buildPath() (string, error) {
 			"0"), nil
 	}
 
-	var msg string
-	var errJSONMarshal error
-
-	if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
-		if msg, errJSONMarshal = o.encryptProcessing(cipherKey); errJSONMarshal != nil {
-			return "", errJSONMarshal
-		}
-
-		o.pubnub.Config.Log.Println("EncryptString: encrypted", msg)
-	} else {
-		if o.Serialize {
-			jsonEncBytes, errEnc := json.Marshal(o.Message)
-			if errEnc != nil {
-				o.pubnub.Config.Log.Printf("ERROR: Publish error: %s\n", errEnc.Error())
-				return "", errEnc
-			}
-			msg = string(jsonEncBytes)
-		} else {
-			if serializedMsg, ok := o.Message.(string); ok {
-				msg = serializedMsg
-			} else {
-				return "", pnerr.NewBuildRequestError("buildpath: Message is not JSON serialized.")
-			}
-		}
+	msg, err := o.serializedMessage()
+	if err != nil {
+		return "", err
 	}
 
 	return fmt.Sprintf(publishGetPath,
@@ -341,7 +628,10 @@ func (o *publishOpts) buildQuery() (*url.Values, error) {
 		}
 	}
 
-	seqn := strconv.Itoa(o.pubnub.getPublishSequence())
+	if o.seqn == 0 {
+		o.seqn = o.pubnub.getPublishSequence()
+	}
+	seqn := strconv.Itoa(o.seqn)
 	o.pubnub.Config.Log.Println("seqn:", seqn)
 	q.Set("seqn", seqn)
 
@@ -361,28 +651,46 @@ func (o *publishOpts) jobQueue() chan *JobQItem {
 
 func (o *publishOpts) buildBody() ([]byte, error) {
 	if o.UsePost {
-		if cipherKey := o.pubnub.Config.CipherKey; cipherKey != "" {
-			msg, errJSONMarshal := o.encryptProcessing(cipherKey)
-			if errJSONMarshal != nil {
-				return []byte{}, errJSONMarshal
-			}
-			return []byte(msg), nil
+		msg, err := o.serializedMessage()
+		if err != nil {
+			return []byte{}, err
 		}
-		if o.Serialize {
-			jsonEncBytes, errEnc := json.Marshal(o.Message)
-			if errEnc != nil {
-				o.pubnub.Config.Log.Printf("ERROR: Publish error: %s\n", errEnc.Error())
-				return []byte{}, errEnc
-			}
-			return jsonEncBytes, nil
+		return []byte(msg), nil
+	}
+	return []byte{}, nil
+}
+
+// serializedMessage returns the message as sent: the JSON with the push
+// payloads, encrypted when a cipher key is set.
+func (o *publishOpts) serializedMessage() (string, error) {
+	if o.PushPayload != nil {
+		return o.pushPayloadProcessing()
+	}
+
+	if cipherKey := o.cipherKey(); cipherKey != "" {
+		msg, err := o.encryptProcessing(cipherKey)
+		if err != nil {
+			return "", err
 		}
-		serializedMsg, ok := o.Message.(string)
-		if ok {
-			return []byte(serializedMsg), nil
+
+		o.pubnub.Config.Log.Println("EncryptString: encrypted", msg)
+		return msg, nil
+	}
+
+	if o.Serialize {
+		jsonEncBytes, errEnc := json.Marshal(o.Message)
+		if errEnc != nil {
+			o.pubnub.Config.Log.Printf("ERROR: Publish error: %s\n", errEnc.Error())
+			return "", errEnc
 		}
-		return []byte{}, pnerr.NewBuildRequestError("buildBody: Message is not JSON serialized.")
+		return string(jsonEncBytes), nil
 	}
-	return []byte{}, nil
+
+	if serializedMsg, ok := o.Message.(string); ok {
+		return serializedMsg, nil
+	}
+
+	return "", pnerr.NewBuildRequestError("Message is not JSON serialized.")
 }
 
 func (o *publishOpts) httpMethod() string {
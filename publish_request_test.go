@@ -1,11 +1,15 @@
 package pubnub
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/pubnub/go/pnerr"
 	h "github.com/pubnub/go/tests/helpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -542,3 +546,230 @@ func TestPublishValidateSubscribeKey(t *testing.T) {
 
 	assert.Equal("pubnub/validation: pubnub: \x03: Missing Subscribe Key", opts.validate().Error())
 }
+
+func TestPublishPushPayload(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &publishOpts{
+		Channel:     "ch",
+		Message:     map[string]interface{}{"text": "hello"},
+		PushPayload: NewPushPayload().FCM(&PNFCMPayload{Notification: &PNFCMNotification{Body: "hello"}}),
+		pubnub:      pubnub,
+		Serialize:   true,
+		UsePost:     true,
+	}
+
+	assert.Nil(opts.validate())
+
+	body, err := opts.buildBody()
+	assert.Nil(err)
+	assert.JSONEq(`{"pn_gcm":{"notification":{"body":"hello"}},"pn_other":{"text":"hello"}}`, string(body))
+
+	opts.Message = nil
+	assert.Nil(opts.validate())
+
+	opts.UsePost = false
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/pub_key/sub_key/0/ch/0/%7B%22pn_gcm%22%3A%7B%22notification%22%3A%7B%22body%22%3A%22hello%22%7D%7D%7D", path)
+}
+
+func TestPublishPushPayloadEncrypt(t *testing.T) {
+	assert := assert.New(t)
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+
+	opts := &publishOpts{
+		Channel:     "ch",
+		Message:     map[string]interface{}{"text": "hello"},
+		PushPayload: NewPushPayload().APNS(&PNAPNSPayload{Alert: &PNAPNSAlert{Body: "hello"}}),
+		pubnub:      pn,
+		Serialize:   true,
+		UsePost:     true,
+	}
+
+	body, err := opts.buildBody()
+	assert.Nil(err)
+
+	var published map[string]interface{}
+	assert.Nil(json.Unmarshal(body, &published))
+	assert.Equal(map[string]interface{}{"aps": map[string]interface{}{"alert": map[string]interface{}{"body": "hello"}}},
+		published["pn_apns"])
+	assert.IsType("", published["pn_other"])
+
+	// The push keys are readable and the subscribers decrypt the message
+	received, err := parseCipherInterface(published, pn.Config)
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"text": "hello"}, received.(map[string]interface{})["pn_other"])
+}
+
+func TestPublishSequenceNumberKeptOnRebuild(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	opts := &publishOpts{
+		Channel: "ch",
+		Message: "hey",
+		pubnub:  pn,
+	}
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+	seqn := query.Get("seqn")
+
+	// the worker signs the request again when it is sent
+	query, err = opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(seqn, query.Get("seqn"))
+	assert.Equal(seqn, strconv.Itoa(opts.seqn))
+}
+
+func TestPublishSizeGet(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	builder := newPublishBuilder(pn).Channel("ch").Message("hey").Meta(map[string]string{"m": "1"})
+	size, err := builder.Size()
+	assert.Nil(err)
+
+	u, err := buildURL(builder.opts)
+	assert.Nil(err)
+
+	assert.False(size.UsePost)
+	assert.Equal(len("ch")+len("%22hey%22"), size.Message)
+	assert.Equal(len(u.String()), size.URL)
+	assert.Equal(0, size.Body)
+}
+
+func TestPublishSizePost(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	size, err := newPublishBuilder(pn).Channel("ch").Message(map[string]int{"a": 1}).UsePost(true).Size()
+	assert.Nil(err)
+
+	assert.True(size.UsePost)
+	assert.Equal(len(`{"a":1}`), size.Body)
+	assert.Equal(len("ch")+len(`{"a":1}`), size.Message)
+}
+
+func TestPublishSizeEncrypt(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "testCipher"
+
+	size, err := newPublishBuilder(pn).Channel("ch").Message("test").UsePost(true).Size()
+	assert.Nil(err)
+	assert.Equal(len(`"+c52pEK3TCTpuEjEFzukRw=="`), size.Body)
+}
+
+func TestPublishSizeValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	size, err := newPublishBuilder(pn).Channel("ch").Size()
+	assert.Nil(size)
+	assert.Contains(err.Error(), StrMissingMessage)
+}
+
+func TestPublishSwitchesToPost(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.MaxURLLength = 300
+
+	builder := newPublishBuilder(pn).Channel("ch").Message(strings.Repeat("a", 300))
+	size, err := builder.Size()
+	assert.Nil(err)
+	assert.True(size.UsePost)
+	assert.Equal(302, size.Body)
+	assert.False(builder.opts.UsePost)
+
+	assert.Nil(builder.opts.checkSize())
+	assert.True(builder.opts.UsePost)
+
+	pn.Config.MaxURLLength = 0
+	builder = newPublishBuilder(pn).Channel("ch").Message(strings.Repeat("a", 300))
+	assert.Nil(builder.opts.checkSize())
+	assert.False(builder.opts.UsePost)
+}
+
+func TestPublishMessageTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.MaxMessageSize = 100
+
+	_, status, err := newPublishBuilder(pn).Channel("ch").Message(strings.Repeat("a", 100)).UsePost(true).Execute()
+	tooLarge, ok := err.(*pnerr.MessageTooLargeError)
+	assert.True(ok)
+	assert.Equal("ch", tooLarge.Channel)
+	assert.Equal(104, tooLarge.Size)
+	assert.Equal(100, tooLarge.Limit)
+	assert.Equal(PNUnknownCategory, status.Category)
+}
+
+func TestPublishEncryptPNOtherKeepsMessage(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+
+	s := map[string]interface{}{
+		"pn_other": "yay!",
+	}
+
+	opts := &publishOpts{
+		Channel:   "ch",
+		Message:   s,
+		pubnub:    pn,
+		Serialize: true,
+	}
+
+	first, err := opts.buildPath()
+	assert.Nil(err)
+	second, err := opts.buildPath()
+	assert.Nil(err)
+
+	assert.Equal(first, second)
+	assert.Equal("yay!", s["pn_other"])
+}
+
+func TestPublishCipherKeyOverride(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+
+	opts := newPublishBuilder(pn).Channel("ch").Message("test").CipherKey("").opts
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/ch/0/%22test%22", path)
+
+	opts = newPublishBuilder(pn).Channel("ch").Message("test").CipherKey("testCipher").opts
+	path, err = opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/ch/0/%22jC%2FyJ2y99BeYFYMQ7c53pg%3D%3D%22", path)
+}
+
+func TestPublishChannelCipherKeys(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+	pn.Config.CipherKeys = map[string]string{
+		"secret": "testCipher",
+		"public": "",
+	}
+
+	opts := newPublishBuilder(pn).Channel("secret").Message("test").opts
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/publish/demo/demo/0/secret/0/%22jC%2FyJ2y99BeYFYMQ7c53pg%3D%3D%22", path)
+
+	opts = newPublishBuilder(pn).Channel("public").Message("test").Meta(map[string]string{"m": "test"}).UsePost(true).opts
+	body, err := opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`"test"`, string(body))
+
+	// the meta is never encrypted
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal(`{"m":"test"}`, query.Get("meta"))
+}
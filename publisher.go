@@ -0,0 +1,401 @@
+package pubnub
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pubnub/go/pnerr"
+)
+
+// publisherMaxBatchSize is the default number of messages of a channel
+// published by a batch.
+const publisherMaxBatchSize = 100
+
+// StrPublisherClosed is the error of the messages added to a closed Publisher.
+const StrPublisherClosed = "Publisher is closed"
+
+// Publisher publishes messages asynchronously. The messages are batched per
+// channel: a batch starts when the Linger time of its first message is over
+// or when MaxBatchSize messages are waiting. The messages of a channel are
+// published in the order they were added, one batch of a channel at a time,
+// while up to Concurrency channels are published in parallel.
+//
+//	publisher, err := pn.Publisher().Linger(50 * time.Millisecond).Execute()
+//	future := publisher.Publish("telemetry", msg)
+//	resp, status, err := future.Get()
+type Publisher struct {
+	sync.Mutex
+
+	pubnub *PubNub
+	ctx    Context
+
+	linger       time.Duration
+	maxBatchSize int
+	queryParam   map[string]string
+
+	ttl            int
+	shouldStore    bool
+	setTTL         bool
+	setShouldStore bool
+
+	queues map[string]*publisherQueue
+	// slots limits the number of channels published in parallel
+	slots  chan bool
+	closed bool
+}
+
+type publisherQueue struct {
+	channel  string
+	pending  []*PublishFuture
+	inflight []*PublishFuture
+	timer    *time.Timer
+	// running is true while a batch of the channel is published
+	running bool
+}
+
+// PublisherMessage is a message added to a Publisher.
+type PublisherMessage struct {
+	Channel string
+	Message interface{}
+	Meta    interface{}
+}
+
+// PublishFuture is the pending result of a message added to a Publisher.
+type PublishFuture struct {
+	message PublisherMessage
+	done    chan struct{}
+
+	resp   *PublishResponse
+	status StatusResponse
+	err    error
+}
+
+type publisherBuilder struct {
+	opts *publisherOpts
+}
+
+type publisherOpts struct {
+	pubnub *PubNub
+
+	// default: 0, each message starts a batch
+	Linger time.Duration
+	// default: publisherMaxBatchSize
+	MaxBatchSize int
+	// default: MaxWorkers in the config
+	Concurrency int
+	QueryParam  map[string]string
+
+	TTL         int
+	ShouldStore bool
+
+	// nil hacks
+	setTTL         bool
+	setShouldStore bool
+
+	ctx Context
+}
+
+func newPublisherBuilder(pubnub *PubNub) *publisherBuilder {
+	builder := publisherBuilder{
+		opts: &publisherOpts{
+			pubnub:       pubnub,
+			MaxBatchSize: publisherMaxBatchSize,
+			Concurrency:  pubnub.Config.MaxWorkers,
+		},
+	}
+
+	return &builder
+}
+
+func newPublisherBuilderWithContext(pubnub *PubNub,
+	context Context) *publisherBuilder {
+	builder := newPublisherBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Linger sets how long the first message of a batch waits for more messages
+// of the same channel before the batch is published.
+func (b *publisherBuilder) Linger(linger time.Duration) *publisherBuilder {
+	b.opts.Linger = linger
+
+	return b
+}
+
+// MaxBatchSize sets the number of waiting messages of a channel which starts
+// a batch before the Linger time is over.
+func (b *publisherBuilder) MaxBatchSize(size int) *publisherBuilder {
+	b.opts.MaxBatchSize = size
+
+	return b
+}
+
+// Concurrency sets the number of channels published in parallel.
+func (b *publisherBuilder) Concurrency(concurrency int) *publisherBuilder {
+	b.opts.Concurrency = concurrency
+
+	return b
+}
+
+// TTL sets the TTL (hours) of the published messages.
+func (b *publisherBuilder) TTL(ttl int) *publisherBuilder {
+	b.opts.TTL = ttl
+	b.opts.setTTL = true
+
+	return b
+}
+
+// ShouldStore if true the published messages are stored in History.
+func (b *publisherBuilder) ShouldStore(store bool) *publisherBuilder {
+	b.opts.ShouldStore = store
+	b.opts.setShouldStore = true
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URLs called by the API.
+func (b *publisherBuilder) QueryParam(queryParam map[string]string) *publisherBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute validates the options and starts the Publisher.
+func (b *publisherBuilder) Execute() (*Publisher, error) {
+	if err := b.opts.validate(); err != nil {
+		return nil, err
+	}
+
+	publisher := newPublisher(b.opts)
+	b.opts.pubnub.addPublisher(publisher)
+
+	return publisher, nil
+}
+
+func (o *publisherOpts) validate() error {
+	if o.pubnub.Config.PublishKey == "" {
+		return pnerr.NewValidationError(PNPublishOperation.String(), StrMissingPubKey)
+	}
+
+	if o.pubnub.Config.SubscribeKey == "" {
+		return pnerr.NewValidationError(PNPublishOperation.String(), StrMissingSubKey)
+	}
+
+	if o.Linger < 0 {
+		return pnerr.NewValidationError(PNPublishOperation.String(), "Linger must not be negative")
+	}
+
+	if o.MaxBatchSize <= 0 {
+		return pnerr.NewValidationError(PNPublishOperation.String(), "MaxBatchSize must be greater than 0")
+	}
+
+	if o.Concurrency <= 0 {
+		return pnerr.NewValidationError(PNPublishOperation.String(), "Concurrency must be greater than 0")
+	}
+
+	return nil
+}
+
+func newPublisher(opts *publisherOpts) *Publisher {
+	return &Publisher{
+		pubnub:         opts.pubnub,
+		ctx:            opts.ctx,
+		linger:         opts.Linger,
+		maxBatchSize:   opts.MaxBatchSize,
+		queryParam:     opts.QueryParam,
+		ttl:            opts.TTL,
+		shouldStore:    opts.ShouldStore,
+		setTTL:         opts.setTTL,
+		setShouldStore: opts.setShouldStore,
+		queues:         make(map[string]*publisherQueue),
+		slots:          make(chan bool, opts.Concurrency),
+	}
+}
+
+// Publish adds a message of the channel, the returned future is resolved
+// once the message is published.
+func (p *Publisher) Publish(channel string, message interface{}) *PublishFuture {
+	return p.PublishMessage(PublisherMessage{
+		Channel: channel,
+		Message: message,
+	})
+}
+
+// PublishMessage adds a message with its Meta, the returned future is
+// resolved once the message is published.
+func (p *Publisher) PublishMessage(message PublisherMessage) *PublishFuture {
+	future := &PublishFuture{
+		message: message,
+		done:    make(chan struct{}),
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.closed {
+		future.resolve(emptyPublishResponse, StatusResponse{},
+			pnerr.NewValidationError(PNPublishOperation.String(), StrPublisherClosed))
+		return future
+	}
+
+	q, ok := p.queues[message.Channel]
+	if !ok {
+		q = &publisherQueue{channel: message.Channel}
+		p.queues[message.Channel] = q
+	}
+	q.pending = append(q.pending, future)
+
+	if q.running {
+		// the pending messages are published once the running batch is done
+		return future
+	}
+
+	if p.linger <= 0 || len(q.pending) >= p.maxBatchSize {
+		p.startBatch(q)
+	} else if q.timer == nil {
+		var timer *time.Timer
+		timer = time.AfterFunc(p.linger, func() {
+			p.Lock()
+			defer p.Unlock()
+
+			// the batch was already started by a full queue or a Flush
+			if q.timer != timer {
+				return
+			}
+			q.timer = nil
+			if !q.running && len(q.pending) > 0 {
+				p.startBatch(q)
+			}
+		})
+		q.timer = timer
+	}
+
+	return future
+}
+
+// Flush publishes the waiting messages without waiting for their Linger time
+// and blocks until all the messages added before the call are published.
+func (p *Publisher) Flush() {
+	p.Lock()
+	futures := []*PublishFuture{}
+	for _, q := range p.queues {
+		futures = append(futures, q.inflight...)
+		futures = append(futures, q.pending...)
+		if !q.running && len(q.pending) > 0 {
+			p.startBatch(q)
+		}
+	}
+	p.Unlock()
+
+	for _, future := range futures {
+		<-future.done
+	}
+}
+
+// Close stops accepting messages and flushes the waiting ones. Destroy
+// closes the running publishers.
+func (p *Publisher) Close() {
+	p.Lock()
+	p.closed = true
+	p.Unlock()
+
+	p.Flush()
+	p.pubnub.removePublisher(p)
+}
+
+// IsClosed returns true once the Publisher is closed.
+func (p *Publisher) IsClosed() bool {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.closed
+}
+
+// startBatch takes the next batch of the queue, the Publisher must be locked.
+func (p *Publisher) startBatch(q *publisherQueue) {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+
+	size := len(q.pending)
+	if size > p.maxBatchSize {
+		size = p.maxBatchSize
+	}
+
+	q.inflight = q.pending[:size:size]
+	q.pending = q.pending[size:]
+	q.running = true
+
+	go p.runBatch(q, q.inflight)
+}
+
+func (p *Publisher) runBatch(q *publisherQueue, batch []*PublishFuture) {
+	p.slots <- true
+	for _, future := range batch {
+		future.resolve(p.publish(future.message))
+	}
+	<-p.slots
+
+	p.Lock()
+	defer p.Unlock()
+
+	q.inflight = nil
+	q.running = false
+
+	if len(q.pending) > 0 {
+		// these messages already waited for the running batch
+		p.startBatch(q)
+	} else if q.timer == nil {
+		delete(p.queues, q.channel)
+	}
+}
+
+func (p *Publisher) publish(message PublisherMessage) (*PublishResponse, StatusResponse, error) {
+	builder := newPublishBuilder(p.pubnub)
+	if p.ctx != nil {
+		builder = newPublishBuilderWithContext(p.pubnub, p.ctx)
+	}
+
+	builder.
+		Channel(message.Channel).
+		Message(message.Message).
+		Meta(message.Meta).
+		QueryParam(p.queryParam)
+
+	if p.setTTL {
+		builder.TTL(p.ttl)
+	}
+
+	if p.setShouldStore {
+		builder.ShouldStore(p.shouldStore)
+	}
+
+	return builder.Execute()
+}
+
+func (f *PublishFuture) resolve(resp *PublishResponse, status StatusResponse, err error) {
+	f.resp = resp
+	f.status = status
+	f.err = err
+	close(f.done)
+}
+
+// Channel returns the channel of the message.
+func (f *PublishFuture) Channel() string {
+	return f.message.Channel
+}
+
+// Done is closed once the message is published or failed.
+func (f *PublishFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get blocks until the message is published and returns the result of its
+// Publish request, the publish timetoken is in the PublishResponse.
+func (f *PublishFuture) Get() (*PublishResponse, StatusResponse, error) {
+	<-f.done
+
+	return f.resp, f.status, f.err
+}
@@ -0,0 +1,223 @@
+package pubnub
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// publisherTransport answers every Publish request and records the published
+// messages per channel.
+type publisherTransport struct {
+	sync.Mutex
+
+	messages map[string][]string
+	active   int
+	maxCalls int
+	delay    time.Duration
+}
+
+func newPublisherTransport(delay time.Duration) *publisherTransport {
+	return &publisherTransport{
+		messages: make(map[string][]string),
+		delay:    delay,
+	}
+}
+
+func (t *publisherTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// /publish/<pub>/<sub>/0/<channel>/0/<message>
+	parsedURL, _ := req.URL.Parse(req.URL.String())
+	parts := strings.Split(parsedURL.EscapedPath(), "/")
+	if len(parts) < 8 || parts[1] != "publish" {
+		return nil, fmt.Errorf("unexpected request %s", req.URL.String())
+	}
+
+	t.Lock()
+	t.active++
+	if t.active > t.maxCalls {
+		t.maxCalls = t.active
+	}
+	t.messages[parts[5]] = append(t.messages[parts[5]], parts[7])
+	t.Unlock()
+
+	time.Sleep(t.delay)
+
+	t.Lock()
+	t.active--
+	t.Unlock()
+
+	return &http.Response{
+		StatusCode: 200,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`[1,"Sent","15191382475826880"]`)),
+		Request:    req,
+	}, nil
+}
+
+func (t *publisherTransport) published(channel string) []string {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.messages[channel]
+}
+
+func newPublisherTestPubNub(transport *publisherTransport) *PubNub {
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(&http.Client{Transport: transport})
+
+	return pn
+}
+
+func TestPublisherValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	publisher, err := newPublisherBuilder(pubnub).MaxBatchSize(0).Execute()
+	assert.Nil(publisher)
+	assert.Contains(err.Error(), "MaxBatchSize must be greater than 0")
+
+	publisher, err = newPublisherBuilder(pubnub).Concurrency(0).Execute()
+	assert.Nil(publisher)
+	assert.Contains(err.Error(), "Concurrency must be greater than 0")
+
+	publisher, err = newPublisherBuilder(pubnub).Linger(-time.Second).Execute()
+	assert.Nil(publisher)
+	assert.Contains(err.Error(), "Linger must not be negative")
+}
+
+func TestPublisherDefaults(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	publisher, err := newPublisherBuilder(pn).Execute()
+	assert.Nil(err)
+	assert.Equal(publisherMaxBatchSize, publisher.maxBatchSize)
+	assert.Equal(pn.Config.MaxWorkers, cap(publisher.slots))
+	assert.Equal([]*Publisher{publisher}, pn.GetPublishers())
+
+	publisher.Close()
+	assert.True(publisher.IsClosed())
+	assert.Equal(0, len(pn.GetPublishers()))
+}
+
+func TestPublisherPreservesChannelOrder(t *testing.T) {
+	assert := assert.New(t)
+	transport := newPublisherTransport(time.Millisecond)
+	pn := newPublisherTestPubNub(transport)
+
+	publisher, err := pn.Publisher().
+		Linger(20 * time.Millisecond).
+		MaxBatchSize(5).
+		Concurrency(4).
+		Execute()
+	assert.Nil(err)
+
+	futures := []*PublishFuture{}
+	for i := 0; i < 12; i++ {
+		futures = append(futures, publisher.Publish("ch1", i))
+		futures = append(futures, publisher.Publish("ch2", i))
+	}
+
+	for _, future := range futures {
+		resp, _, err := future.Get()
+		assert.Nil(err)
+		assert.Equal(int64(15191382475826880), resp.Timestamp)
+	}
+
+	expected := []string{}
+	for i := 0; i < 12; i++ {
+		expected = append(expected, fmt.Sprintf("%d", i))
+	}
+	assert.Equal(expected, transport.published("ch1"))
+	assert.Equal(expected, transport.published("ch2"))
+
+	publisher.Close()
+}
+
+func TestPublisherLinger(t *testing.T) {
+	assert := assert.New(t)
+	transport := newPublisherTransport(0)
+	pn := newPublisherTestPubNub(transport)
+
+	publisher, _ := pn.Publisher().Linger(time.Hour).MaxBatchSize(3).Execute()
+
+	first := publisher.Publish("ch", "a")
+	publisher.Publish("ch", "b")
+
+	select {
+	case <-first.Done():
+		assert.Fail("the batch started before the linger time")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the third message fills the batch
+	third := publisher.Publish("ch", "c")
+	_, _, err := third.Get()
+	assert.Nil(err)
+	assert.Equal([]string{"%22a%22", "%22b%22", "%22c%22"}, transport.published("ch"))
+
+	publisher.Close()
+}
+
+func TestPublisherFlush(t *testing.T) {
+	assert := assert.New(t)
+	transport := newPublisherTransport(0)
+	pn := newPublisherTestPubNub(transport)
+
+	publisher, _ := pn.Publisher().Linger(time.Hour).Execute()
+
+	future := publisher.Publish("ch", "a")
+	publisher.Flush()
+
+	select {
+	case <-future.Done():
+	default:
+		assert.Fail("the message is not published by Flush")
+	}
+	assert.Equal("ch", future.Channel())
+
+	publisher.Close()
+}
+
+func TestPublisherConcurrency(t *testing.T) {
+	assert := assert.New(t)
+	transport := newPublisherTransport(20 * time.Millisecond)
+	pn := newPublisherTestPubNub(transport)
+
+	publisher, _ := pn.Publisher().Concurrency(2).Execute()
+
+	for i := 0; i < 6; i++ {
+		publisher.Publish(fmt.Sprintf("ch%d", i), "msg")
+	}
+	publisher.Flush()
+
+	transport.Lock()
+	assert.Equal(2, transport.maxCalls)
+	transport.Unlock()
+
+	publisher.Close()
+}
+
+func TestPublisherClosePublishers(t *testing.T) {
+	assert := assert.New(t)
+	transport := newPublisherTransport(0)
+	pn := newPublisherTestPubNub(transport)
+
+	publisher, _ := pn.Publisher().Linger(time.Hour).Execute()
+	pending := publisher.Publish("ch", "a")
+
+	pn.closePublishers()
+
+	_, _, err := pending.Get()
+	assert.Nil(err)
+	assert.True(publisher.IsClosed())
+
+	_, _, err = publisher.Publish("ch", "b").Get()
+	assert.Contains(err.Error(), StrPublisherClosed)
+}
@@ -13,7 +13,7 @@ import (
 const (
 	// Version :the version of the SDK
 	Version = "4.2.1"
-	// MaxSequence for publish messages
+	// MaxSequence for publish messages, the sequence numbers wrap to 1 after it
 	MaxSequence = 65535
 )
 
@@ -36,6 +36,8 @@ const (
 	StrMissingDeviceID = "Missing Device ID"
 	// StrMissingPushType shows Missing Push Type message
 	StrMissingPushType = "Missing Push Type"
+	// StrMissingPushTopic shows Missing Push Topic message
+	StrMissingPushTopic = "Missing Push Topic"
 	// StrChannelsTimetoken shows Missing Channels Timetoken message
 	StrChannelsTimetoken = "Missing Channels Timetoken"
 	// StrChannelsTimetokenLength shows Length of Channels Timetoken message
@@ -53,10 +55,15 @@ type PubNub struct {
 	subscriptionManager  *SubscriptionManager
 	telemetryManager     *TelemetryManager
 	heartbeatManager     *HeartbeatManager
+	permissionCache      *PermissionCache
 	client               *http.Client
 	subscribeClient      *http.Client
 	requestWorkers       *RequestWorkers
 	jobQueue             chan *JobQItem
+	publishers           map[*Publisher]bool
+	publishersMutex      sync.RWMutex
+	deliveries           *deliveryConfirmations
+	outbox               *Outbox
 	ctx                  Context
 	cancel               func()
 }
@@ -78,10 +85,171 @@ func (pn *PubNub) FireWithContext(ctx Context) *fireBuilder {
 	return newFireBuilderWithContext(pn, ctx)
 }
 
+// Signal sends a lightweight message which is not stored in History, received on the Signal channel of the listeners.
+func (pn *PubNub) Signal() *signalBuilder {
+	return newSignalBuilder(pn)
+}
+
+// SignalWithContext sends a lightweight message which is not stored in History, received on the Signal channel of the listeners.
+func (pn *PubNub) SignalWithContext(ctx Context) *signalBuilder {
+	return newSignalBuilderWithContext(pn, ctx)
+}
+
+// SetUUIDMetadata sets the metadata of a UUID.
+func (pn *PubNub) SetUUIDMetadata() *setUUIDMetadataBuilder {
+	return newSetUUIDMetadataBuilder(pn)
+}
+
+// SetUUIDMetadataWithContext sets the metadata of a UUID.
+func (pn *PubNub) SetUUIDMetadataWithContext(ctx Context) *setUUIDMetadataBuilder {
+	return newSetUUIDMetadataBuilderWithContext(pn, ctx)
+}
+
+// GetUUIDMetadata returns the metadata of a UUID.
+func (pn *PubNub) GetUUIDMetadata() *getUUIDMetadataBuilder {
+	return newGetUUIDMetadataBuilder(pn)
+}
+
+// GetUUIDMetadataWithContext returns the metadata of a UUID.
+func (pn *PubNub) GetUUIDMetadataWithContext(ctx Context) *getUUIDMetadataBuilder {
+	return newGetUUIDMetadataBuilderWithContext(pn, ctx)
+}
+
+// RemoveUUIDMetadata removes the metadata of a UUID.
+func (pn *PubNub) RemoveUUIDMetadata() *removeUUIDMetadataBuilder {
+	return newRemoveUUIDMetadataBuilder(pn)
+}
+
+// RemoveUUIDMetadataWithContext removes the metadata of a UUID.
+func (pn *PubNub) RemoveUUIDMetadataWithContext(ctx Context) *removeUUIDMetadataBuilder {
+	return newRemoveUUIDMetadataBuilderWithContext(pn, ctx)
+}
+
+// GetAllUUIDMetadata returns the metadata of the UUIDs, a page at a time.
+func (pn *PubNub) GetAllUUIDMetadata() *getAllUUIDMetadataBuilder {
+	return newGetAllUUIDMetadataBuilder(pn)
+}
+
+// GetAllUUIDMetadataWithContext returns the metadata of the UUIDs, a page at a time.
+func (pn *PubNub) GetAllUUIDMetadataWithContext(ctx Context) *getAllUUIDMetadataBuilder {
+	return newGetAllUUIDMetadataBuilderWithContext(pn, ctx)
+}
+
+// SetChannelMetadata sets the metadata of a channel.
+func (pn *PubNub) SetChannelMetadata() *setChannelMetadataBuilder {
+	return newSetChannelMetadataBuilder(pn)
+}
+
+// SetChannelMetadataWithContext sets the metadata of a channel.
+func (pn *PubNub) SetChannelMetadataWithContext(ctx Context) *setChannelMetadataBuilder {
+	return newSetChannelMetadataBuilderWithContext(pn, ctx)
+}
+
+// GetChannelMetadata returns the metadata of a channel.
+func (pn *PubNub) GetChannelMetadata() *getChannelMetadataBuilder {
+	return newGetChannelMetadataBuilder(pn)
+}
+
+// GetChannelMetadataWithContext returns the metadata of a channel.
+func (pn *PubNub) GetChannelMetadataWithContext(ctx Context) *getChannelMetadataBuilder {
+	return newGetChannelMetadataBuilderWithContext(pn, ctx)
+}
+
+// RemoveChannelMetadata removes the metadata of a channel.
+func (pn *PubNub) RemoveChannelMetadata() *removeChannelMetadataBuilder {
+	return newRemoveChannelMetadataBuilder(pn)
+}
+
+// RemoveChannelMetadataWithContext removes the metadata of a channel.
+func (pn *PubNub) RemoveChannelMetadataWithContext(ctx Context) *removeChannelMetadataBuilder {
+	return newRemoveChannelMetadataBuilderWithContext(pn, ctx)
+}
+
+// GetAllChannelMetadata returns the metadata of the channels, a page at a time.
+func (pn *PubNub) GetAllChannelMetadata() *getAllChannelMetadataBuilder {
+	return newGetAllChannelMetadataBuilder(pn)
+}
+
+// GetAllChannelMetadataWithContext returns the metadata of the channels, a page at a time.
+func (pn *PubNub) GetAllChannelMetadataWithContext(ctx Context) *getAllChannelMetadataBuilder {
+	return newGetAllChannelMetadataBuilderWithContext(pn, ctx)
+}
+
+// GetMemberships returns the channel memberships of a UUID, a page at a time.
+func (pn *PubNub) GetMemberships() *getMembershipsBuilder {
+	return newGetMembershipsBuilder(pn)
+}
+
+// GetMembershipsWithContext returns the channel memberships of a UUID, a page at a time.
+func (pn *PubNub) GetMembershipsWithContext(ctx Context) *getMembershipsBuilder {
+	return newGetMembershipsBuilderWithContext(pn, ctx)
+}
+
+// SetMemberships adds or updates channel memberships of a UUID.
+func (pn *PubNub) SetMemberships() *setMembershipsBuilder {
+	return newSetMembershipsBuilder(pn)
+}
+
+// SetMembershipsWithContext adds or updates channel memberships of a UUID.
+func (pn *PubNub) SetMembershipsWithContext(ctx Context) *setMembershipsBuilder {
+	return newSetMembershipsBuilderWithContext(pn, ctx)
+}
+
+// RemoveMemberships removes channel memberships of a UUID.
+func (pn *PubNub) RemoveMemberships() *removeMembershipsBuilder {
+	return newRemoveMembershipsBuilder(pn)
+}
+
+// RemoveMembershipsWithContext removes channel memberships of a UUID.
+func (pn *PubNub) RemoveMembershipsWithContext(ctx Context) *removeMembershipsBuilder {
+	return newRemoveMembershipsBuilderWithContext(pn, ctx)
+}
+
+// GetChannelMembers returns the UUID members of a channel, a page at a time.
+func (pn *PubNub) GetChannelMembers() *getChannelMembersBuilder {
+	return newGetChannelMembersBuilder(pn)
+}
+
+// GetChannelMembersWithContext returns the UUID members of a channel, a page at a time.
+func (pn *PubNub) GetChannelMembersWithContext(ctx Context) *getChannelMembersBuilder {
+	return newGetChannelMembersBuilderWithContext(pn, ctx)
+}
+
+// SetChannelMembers adds or updates UUID members of a channel.
+func (pn *PubNub) SetChannelMembers() *setChannelMembersBuilder {
+	return newSetChannelMembersBuilder(pn)
+}
+
+// SetChannelMembersWithContext adds or updates UUID members of a channel.
+func (pn *PubNub) SetChannelMembersWithContext(ctx Context) *setChannelMembersBuilder {
+	return newSetChannelMembersBuilderWithContext(pn, ctx)
+}
+
+// RemoveChannelMembers removes UUID members of a channel.
+func (pn *PubNub) RemoveChannelMembers() *removeChannelMembersBuilder {
+	return newRemoveChannelMembersBuilder(pn)
+}
+
+// RemoveChannelMembersWithContext removes UUID members of a channel.
+func (pn *PubNub) RemoveChannelMembersWithContext(ctx Context) *removeChannelMembersBuilder {
+	return newRemoveChannelMembersBuilderWithContext(pn, ctx)
+}
+
 func (pn *PubNub) Subscribe() *subscribeBuilder {
 	return newSubscribeBuilder(pn)
 }
 
+// Subscription creates a subscription handle with its own listener and filter,
+// which shares the subscribe loop with the other subscriptions.
+func (pn *PubNub) Subscription() *subscriptionBuilder {
+	return newSubscriptionBuilder(pn)
+}
+
+// GetSubscriptions returns the active subscription handles.
+func (pn *PubNub) GetSubscriptions() []*Subscription {
+	return pn.subscriptionManager.getSubscriptions()
+}
+
 func (pn *PubNub) History() *historyBuilder {
 	return newHistoryBuilder(pn)
 }
@@ -90,6 +258,59 @@ func (pn *PubNub) HistoryWithContext(ctx Context) *historyBuilder {
 	return newHistoryBuilderWithContext(pn, ctx)
 }
 
+// HistoryIterator pages through the history of one or more channels in a time window.
+func (pn *PubNub) HistoryIterator() *historyIteratorBuilder {
+	return newHistoryIteratorBuilder(pn)
+}
+
+func (pn *PubNub) HistoryIteratorWithContext(ctx Context) *historyIteratorBuilder {
+	return newHistoryIteratorBuilderWithContext(pn, ctx)
+}
+
+func (pn *PubNub) AddMessageAction() *addMessageActionBuilder {
+	return newAddMessageActionBuilder(pn)
+}
+
+func (pn *PubNub) AddMessageActionWithContext(ctx Context) *addMessageActionBuilder {
+	return newAddMessageActionBuilderWithContext(pn, ctx)
+}
+
+func (pn *PubNub) GetMessageActions() *getMessageActionsBuilder {
+	return newGetMessageActionsBuilder(pn)
+}
+
+func (pn *PubNub) GetMessageActionsWithContext(ctx Context) *getMessageActionsBuilder {
+	return newGetMessageActionsBuilderWithContext(pn, ctx)
+}
+
+func (pn *PubNub) RemoveMessageAction() *removeMessageActionBuilder {
+	return newRemoveMessageActionBuilder(pn)
+}
+
+func (pn *PubNub) RemoveMessageActionWithContext(ctx Context) *removeMessageActionBuilder {
+	return newRemoveMessageActionBuilderWithContext(pn, ctx)
+}
+
+// EditMessage publishes a record replacing the content of a published message,
+// the records are folded into the latest version of the messages by a MessageFolder.
+func (pn *PubNub) EditMessage() *messageUpdateBuilder {
+	return newMessageUpdateBuilder(pn, PNMessageUpdateEdit)
+}
+
+func (pn *PubNub) EditMessageWithContext(ctx Context) *messageUpdateBuilder {
+	return newMessageUpdateBuilderWithContext(pn, ctx, PNMessageUpdateEdit)
+}
+
+// SoftDeleteMessage publishes a record hiding a published message, the message
+// stays in the history.
+func (pn *PubNub) SoftDeleteMessage() *messageUpdateBuilder {
+	return newMessageUpdateBuilder(pn, PNMessageUpdateDelete)
+}
+
+func (pn *PubNub) SoftDeleteMessageWithContext(ctx Context) *messageUpdateBuilder {
+	return newMessageUpdateBuilderWithContext(pn, ctx, PNMessageUpdateDelete)
+}
+
 func (pn *PubNub) Fetch() *fetchBuilder {
 	return newFetchBuilder(pn)
 }
@@ -122,6 +343,95 @@ func (pn *PubNub) GrantWithContext(ctx Context) *grantBuilder {
 	return newGrantBuilderWithContext(pn, ctx)
 }
 
+// GrantBatch grants the permissions to large sets of auth keys, channels and channel groups,
+// split in several Grant requests.
+func (pn *PubNub) GrantBatch() *grantBatchBuilder {
+	return newGrantBatchBuilder(pn)
+}
+
+func (pn *PubNub) GrantBatchWithContext(ctx Context) *grantBatchBuilder {
+	return newGrantBatchBuilderWithContext(pn, ctx)
+}
+
+func (pn *PubNub) GrantToken() *grantTokenBuilder {
+	return newGrantTokenBuilder(pn)
+}
+
+func (pn *PubNub) GrantTokenWithContext(ctx Context) *grantTokenBuilder {
+	return newGrantTokenBuilderWithContext(pn, ctx)
+}
+
+// SetToken sets the token returned by GrantToken, it is sent with every request instead of the AuthKey.
+// An empty token restores the AuthKey. The permissions of the token are added to the permission cache.
+func (pn *PubNub) SetToken(token string) {
+	pn.Config.authToken.set(token)
+
+	if token == "" {
+		return
+	}
+	if parsed, err := ParseToken(token); err == nil {
+		pn.permissionCache.addToken(token, parsed)
+	} else {
+		pn.Config.Log.Println("SetToken:", err)
+	}
+}
+
+// GetToken returns the token set with SetToken.
+func (pn *PubNub) GetToken() string {
+	return pn.Config.authToken.get()
+}
+
+// GetPermissionCache returns the permissions cached from the Grant, Revoke and Audit responses and the tokens.
+func (pn *PubNub) GetPermissionCache() *PermissionCache {
+	return pn.permissionCache
+}
+
+// CanRead returns false if the cached permissions of the current auth key deny reading the channel.
+// Unknown permissions are allowed.
+func (pn *PubNub) CanRead(channel string) bool {
+	return pn.permissionCache.allows(pn.Config.currentAuthKey(), pnResourceChannel, channel, pnPermissionRead)
+}
+
+// CanWrite returns false if the cached permissions of the current auth key deny writing to the channel.
+// Unknown permissions are allowed.
+func (pn *PubNub) CanWrite(channel string) bool {
+	return pn.permissionCache.allows(pn.Config.currentAuthKey(), pnResourceChannel, channel, pnPermissionWrite)
+}
+
+// CanManage returns false if the cached permissions of the current auth key deny managing the channel.
+// Unknown permissions are allowed.
+func (pn *PubNub) CanManage(channel string) bool {
+	return pn.permissionCache.allows(pn.Config.currentAuthKey(), pnResourceChannel, channel, pnPermissionManage)
+}
+
+// CanDelete returns false if the cached permissions of the current auth key deny deleting from the channel.
+// Unknown permissions are allowed.
+func (pn *PubNub) CanDelete(channel string) bool {
+	return pn.permissionCache.allows(pn.Config.currentAuthKey(), pnResourceChannel, channel, pnPermissionDelete)
+}
+
+// CanReadChannelGroup returns false if the cached permissions of the current auth key deny reading the channel group.
+// Unknown permissions are allowed.
+func (pn *PubNub) CanReadChannelGroup(group string) bool {
+	return pn.permissionCache.allows(pn.Config.currentAuthKey(), pnResourceGroup, group, pnPermissionRead)
+}
+
+func (pn *PubNub) Revoke() *revokeBuilder {
+	return newRevokeBuilder(pn)
+}
+
+func (pn *PubNub) RevokeWithContext(ctx Context) *revokeBuilder {
+	return newRevokeBuilderWithContext(pn, ctx)
+}
+
+func (pn *PubNub) Audit() *auditBuilder {
+	return newAuditBuilder(pn)
+}
+
+func (pn *PubNub) AuditWithContext(ctx Context) *auditBuilder {
+	return newAuditBuilderWithContext(pn, ctx)
+}
+
 func (pn *PubNub) Unsubscribe() *unsubscribeBuilder {
 	return newUnsubscribeBuilder(pn)
 }
@@ -138,6 +448,63 @@ func (pn *PubNub) GetListeners() map[*Listener]bool {
 	return pn.subscriptionManager.GetListeners()
 }
 
+// AddMessageActionsListener adds a listener for the message actions added or removed on the subscribed channels.
+func (pn *PubNub) AddMessageActionsListener(listener *MessageActionsListener) {
+	pn.subscriptionManager.listenerManager.addMessageActionsListener(listener)
+}
+
+// RemoveMessageActionsListener removes a listener added with AddMessageActionsListener.
+func (pn *PubNub) RemoveMessageActionsListener(listener *MessageActionsListener) {
+	pn.subscriptionManager.listenerManager.removeMessageActionsListener(listener)
+}
+
+// AddSignalListener adds a listener for the messages sent with Signal.
+func (pn *PubNub) AddSignalListener(listener *SignalListener) {
+	pn.subscriptionManager.listenerManager.addSignalListener(listener)
+}
+
+// RemoveSignalListener removes a listener added with AddSignalListener.
+func (pn *PubNub) RemoveSignalListener(listener *SignalListener) {
+	pn.subscriptionManager.listenerManager.removeSignalListener(listener)
+}
+
+// AddObjectsListener adds a listener for the changes of the UUID metadata,
+// the channel metadata and the memberships.
+func (pn *PubNub) AddObjectsListener(listener *ObjectsListener) {
+	pn.subscriptionManager.listenerManager.addObjectsListener(listener)
+}
+
+// RemoveObjectsListener removes a listener added with AddObjectsListener.
+func (pn *PubNub) RemoveObjectsListener(listener *ObjectsListener) {
+	pn.subscriptionManager.listenerManager.removeObjectsListener(listener)
+}
+
+// AddStateChangeListener adds a listener for the state changes received with the presence events.
+func (pn *PubNub) AddStateChangeListener(listener *StateChangeListener) {
+	pn.subscriptionManager.listenerManager.addStateChangeListener(listener)
+}
+
+// RemoveStateChangeListener removes a listener added with AddStateChangeListener.
+func (pn *PubNub) RemoveStateChangeListener(listener *StateChangeListener) {
+	pn.subscriptionManager.listenerManager.removeStateChangeListener(listener)
+}
+
+// GetCachedState returns the last known state of the client UUID on a channel.
+func (pn *PubNub) GetCachedState(channel string) (map[string]interface{}, bool) {
+	return pn.subscriptionManager.stateManager.getChannelState(channel)
+}
+
+// GetCachedGroupState returns the last known state of the client UUID on a channel group.
+func (pn *PubNub) GetCachedGroupState(group string) (map[string]interface{}, bool) {
+	return pn.subscriptionManager.stateManager.getGroupState(group)
+}
+
+// ClearCachedState removes the cached state of all the channels and channel groups,
+// the following subscribe and heartbeat requests are sent without state.
+func (pn *PubNub) ClearCachedState() {
+	pn.subscriptionManager.stateManager.clearStates()
+}
+
 func (pn *PubNub) Leave() *leaveBuilder {
 	return newLeaveBuilder(pn)
 }
@@ -154,6 +521,111 @@ func (pn *PubNub) PresenceWithContext(ctx Context) *presenceBuilder {
 	return newPresenceBuilderWithContext(pn, ctx)
 }
 
+// StartHeartbeatSession starts a heartbeat loop for a UUID, independent of the subscribe loop.
+func (pn *PubNub) StartHeartbeatSession() *heartbeatSessionBuilder {
+	return newHeartbeatSessionBuilder(pn)
+}
+
+// StartHeartbeatSessionWithContext starts a heartbeat loop for a UUID, which stops when the context is done.
+func (pn *PubNub) StartHeartbeatSessionWithContext(ctx Context) *heartbeatSessionBuilder {
+	return newHeartbeatSessionBuilderWithContext(pn, ctx)
+}
+
+// GetHeartbeatSessions returns the running heartbeat sessions.
+func (pn *PubNub) GetHeartbeatSessions() []*HeartbeatSession {
+	return pn.heartbeatManager.getSessions()
+}
+
+// StopHeartbeatSessions stops all the running heartbeat sessions.
+func (pn *PubNub) StopHeartbeatSessions() {
+	for _, session := range pn.heartbeatManager.getSessions() {
+		session.Stop()
+	}
+}
+
+// Publisher starts a Publisher which batches the messages published asynchronously.
+func (pn *PubNub) Publisher() *publisherBuilder {
+	return newPublisherBuilder(pn)
+}
+
+// PublisherWithContext starts a Publisher whose Publish requests use the context.
+func (pn *PubNub) PublisherWithContext(ctx Context) *publisherBuilder {
+	return newPublisherBuilderWithContext(pn, ctx)
+}
+
+// GetPublishers returns the publishers which are not closed.
+func (pn *PubNub) GetPublishers() []*Publisher {
+	pn.publishersMutex.RLock()
+	defer pn.publishersMutex.RUnlock()
+
+	publishers := []*Publisher{}
+	for publisher := range pn.publishers {
+		publishers = append(publishers, publisher)
+	}
+
+	return publishers
+}
+
+func (pn *PubNub) addPublisher(publisher *Publisher) {
+	pn.publishersMutex.Lock()
+	defer pn.publishersMutex.Unlock()
+
+	if pn.publishers == nil {
+		pn.publishers = make(map[*Publisher]bool)
+	}
+	pn.publishers[publisher] = true
+}
+
+func (pn *PubNub) removePublisher(publisher *Publisher) {
+	pn.publishersMutex.Lock()
+	defer pn.publishersMutex.Unlock()
+
+	delete(pn.publishers, publisher)
+}
+
+// Outbox enables the Outbox of the client, which stores the messages
+// published while the network is down and publishes them again once it is back.
+func (pn *PubNub) Outbox() *outboxBuilder {
+	return newOutboxBuilder(pn)
+}
+
+// GetOutbox returns the Outbox of the client, nil when it is not enabled.
+func (pn *PubNub) GetOutbox() *Outbox {
+	pn.RLock()
+	defer pn.RUnlock()
+
+	return pn.outbox
+}
+
+// setOutbox returns false if the client already has an Outbox.
+func (pn *PubNub) setOutbox(outbox *Outbox) bool {
+	pn.Lock()
+	defer pn.Unlock()
+
+	if pn.outbox != nil {
+		return false
+	}
+	pn.outbox = outbox
+
+	return true
+}
+
+func (pn *PubNub) removeOutbox(outbox *Outbox) {
+	pn.Lock()
+	defer pn.Unlock()
+
+	if pn.outbox == outbox {
+		pn.outbox = nil
+	}
+}
+
+// closePublishers publishes the waiting messages of the publishers and closes them.
+func (pn *PubNub) closePublishers() {
+	for _, publisher := range pn.GetPublishers() {
+		publisher.Close()
+	}
+}
+
 func (pn *PubNub) heartbeat() *heartbeatBuilder {
 	return newHeartbeatBuilder(pn)
 }
@@ -339,6 +811,11 @@ func (pn *PubNub) DeleteMessagesWithContext(ctx Context) *historyDeleteBuilder {
 
 func (pn *PubNub) Destroy() {
 	pn.Config.Log.Println("Calling Destroy")
+	pn.Config.Log.Println("calling closePublishers")
+	pn.closePublishers()
+	if outbox := pn.GetOutbox(); outbox != nil {
+		outbox.Close()
+	}
 	pn.cancel()
 	pn.Config.Log.Println("calling RemoveAllListeners")
 	pn.subscriptionManager.RemoveAllListeners()
@@ -377,6 +854,9 @@ func NewPubNub(pnconf *Config) *PubNub {
 	if pnconf.Log == nil {
 		pnconf.Log = log.New(ioutil.Discard, "", log.Ldate|log.Ltime|log.Lshortfile)
 	}
+	if pnconf.authToken == nil {
+		pnconf.authToken = &tokenStore{}
+	}
 	pnconf.Log.Println(fmt.Sprintf("PubNub Go v4 SDK: %s\npnconf: %v\n%s\n%s\n%s", Version, pnconf, runtime.Version(), runtime.GOARCH, runtime.GOOS))
 
 	pn := &PubNub{
@@ -388,6 +868,8 @@ func NewPubNub(pnconf *Config) *PubNub {
 
 	pn.subscriptionManager = newSubscriptionManager(pn, ctx)
 	pn.heartbeatManager = newHeartbeatManager(pn, ctx)
+	pn.permissionCache = newPermissionCache()
+	pn.deliveries = newDeliveryConfirmations()
 	pn.telemetryManager = newTelemetryManager(pnconf.MaximumLatencyDataAge, ctx)
 	pn.jobQueue = make(chan *JobQItem)
 	pn.requestWorkers = pn.newNonSubQueueProcessor(pnconf.MaxWorkers)
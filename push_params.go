@@ -0,0 +1,44 @@
+package pubnub
+
+import (
+	"net/url"
+)
+
+// validatePushParams checks the extra params required by the push type,
+// APNS2 needs a topic and accepts an environment, the other types accept none.
+func validatePushParams(o endpointOpts, pushType PNPushType, topic string, environment PNPushEnvironment) error {
+	if pushType != PNPushTypeAPNS2 {
+		if topic != "" || environment != "" {
+			return newValidationError(o, "Topic and Environment are only supported by APNS2")
+		}
+
+		return nil
+	}
+
+	if topic == "" {
+		return newValidationError(o, StrMissingPushTopic)
+	}
+
+	if environment != "" &&
+		environment != PNPushEnvironmentDevelopment &&
+		environment != PNPushEnvironmentProduction {
+		return newValidationError(o, "Invalid Push Environment")
+	}
+
+	return nil
+}
+
+// setPushQuery sets the push type, or the APNS2 topic and environment.
+func setPushQuery(q *url.Values, pushType PNPushType, topic string, environment PNPushEnvironment) {
+	if pushType != PNPushTypeAPNS2 {
+		q.Set("type", pushType.String())
+		return
+	}
+
+	if environment == "" {
+		environment = PNPushEnvironmentDevelopment
+	}
+
+	q.Set("environment", string(environment))
+	q.Set("topic", topic)
+}
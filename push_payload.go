@@ -0,0 +1,228 @@
+package pubnub
+
+import (
+	"encoding/json"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+const (
+	pushPayloadAPNSKey  = "pn_apns"
+	pushPayloadFCMKey   = "pn_gcm"
+	pushPayloadDebugKey = "pn_debug"
+	// pushPayloadMessageKey is the key of the realtime message, the only
+	// part of the payload encrypted with a CipherKey.
+	pushPayloadMessageKey = "pn_other"
+)
+
+// PushPayload composes the push notification payloads published with a
+// message, see the PushPayload method of Publish.
+//
+//	payload := pubnub.NewPushPayload().
+//		APNS(&pubnub.PNAPNSPayload{
+//			Alert: &pubnub.PNAPNSAlert{Title: "New message", Body: "Hello"},
+//			Targets: []pubnub.PNAPNS2Target{
+//				{Topic: "com.example.app", Environment: pubnub.PNPushEnvironmentProduction},
+//			},
+//		}).
+//		FCM(&pubnub.PNFCMPayload{
+//			Notification: &pubnub.PNFCMNotification{Title: "New message", Body: "Hello"},
+//		})
+type PushPayload struct {
+	apns  *PNAPNSPayload
+	fcm   *PNFCMPayload
+	debug bool
+}
+
+// PNAPNSPayload is the APNS section of a PushPayload.
+type PNAPNSPayload struct {
+	Alert *PNAPNSAlert
+	// Badge is not sent when nil, 0 clears the badge.
+	Badge            *int
+	Sound            string
+	ContentAvailable bool
+	// Custom keys sent next to the aps dictionary.
+	Custom map[string]interface{}
+	// Targets of the devices registered with PNPushTypeAPNS2, the payload is
+	// sent to the devices registered with PNPushTypeAPNS when empty.
+	Targets []PNAPNS2Target
+}
+
+// PNAPNSAlert is the alert of an APNS notification.
+type PNAPNSAlert struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Body     string `json:"body,omitempty"`
+}
+
+// PNAPNS2Target is a topic and environment of the devices registered with
+// PNPushTypeAPNS2 which receive the notification.
+type PNAPNS2Target struct {
+	Topic string
+	// default: PNPushEnvironmentDevelopment
+	Environment     PNPushEnvironment
+	ExcludedDevices []string
+}
+
+// PNFCMPayload is the FCM section of a PushPayload.
+type PNFCMPayload struct {
+	Notification *PNFCMNotification
+	Data         map[string]interface{}
+}
+
+// PNFCMNotification is the notification of an FCM message.
+type PNFCMNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Sound string `json:"sound,omitempty"`
+	Icon  string `json:"icon,omitempty"`
+}
+
+// NewPushPayload initiates an empty PushPayload.
+func NewPushPayload() *PushPayload {
+	return &PushPayload{}
+}
+
+// APNS sets the APNS section of the payload.
+func (p *PushPayload) APNS(apns *PNAPNSPayload) *PushPayload {
+	p.apns = apns
+	return p
+}
+
+// FCM sets the FCM section of the payload.
+func (p *PushPayload) FCM(fcm *PNFCMPayload) *PushPayload {
+	p.fcm = fcm
+	return p
+}
+
+// Debug sends the push delivery details to the `<channel>-pndebug` channel.
+func (p *PushPayload) Debug(debug bool) *PushPayload {
+	p.debug = debug
+	return p
+}
+
+func (p *PushPayload) validate(o endpointOpts) error {
+	if p.apns == nil && p.fcm == nil {
+		return newValidationError(o, "Missing APNS or FCM Push Payload")
+	}
+
+	if p.apns != nil {
+		for _, target := range p.apns.Targets {
+			if err := validatePushParams(o, PNPushTypeAPNS2, target.Topic, target.Environment); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// envelope returns the push keys of the published message.
+func (p *PushPayload) envelope() map[string]interface{} {
+	envelope := make(map[string]interface{})
+
+	if p.apns != nil {
+		envelope[pushPayloadAPNSKey] = p.apns.toMap()
+	}
+
+	if p.fcm != nil {
+		envelope[pushPayloadFCMKey] = p.fcm.toMap()
+	}
+
+	if p.debug {
+		envelope[pushPayloadDebugKey] = true
+	}
+
+	return envelope
+}
+
+// compose returns the published message, the push keys with the realtime
+// message under pn_other, encrypted when cipherKey is set.
+func (p *PushPayload) compose(message interface{}, serialize bool, cipherKey string) (map[string]interface{}, error) {
+	composed := p.envelope()
+	if message == nil {
+		return composed, nil
+	}
+
+	if cipherKey != "" {
+		encrypted, err := utils.SerializeAndEncrypt(message, cipherKey, serialize)
+		if err != nil {
+			return nil, err
+		}
+		composed[pushPayloadMessageKey] = encrypted
+
+		return composed, nil
+	}
+
+	if !serialize {
+		serializedMsg, ok := message.(string)
+		if !ok {
+			return nil, pnerr.NewBuildRequestError("Message is not JSON serialized.")
+		}
+		message = json.RawMessage(serializedMsg)
+	}
+	composed[pushPayloadMessageKey] = message
+
+	return composed, nil
+}
+
+func (a *PNAPNSPayload) toMap() map[string]interface{} {
+	aps := make(map[string]interface{})
+	if a.Alert != nil {
+		aps["alert"] = a.Alert
+	}
+	if a.Badge != nil {
+		aps["badge"] = *a.Badge
+	}
+	if a.Sound != "" {
+		aps["sound"] = a.Sound
+	}
+	if a.ContentAvailable {
+		aps["content-available"] = 1
+	}
+
+	m := make(map[string]interface{})
+	for k, v := range a.Custom {
+		m[k] = v
+	}
+	m["aps"] = aps
+
+	if len(a.Targets) > 0 {
+		targets := make([]map[string]interface{}, len(a.Targets))
+		for i, target := range a.Targets {
+			environment := target.Environment
+			if environment == "" {
+				environment = PNPushEnvironmentDevelopment
+			}
+
+			targets[i] = map[string]interface{}{
+				"topic":       target.Topic,
+				"environment": string(environment),
+			}
+			if len(target.ExcludedDevices) > 0 {
+				targets[i]["excluded_devices"] = target.ExcludedDevices
+			}
+		}
+
+		m["pn_push"] = []map[string]interface{}{{
+			"auth_method": "token",
+			"targets":     targets,
+			"version":     "v2",
+		}}
+	}
+
+	return m
+}
+
+func (f *PNFCMPayload) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if f.Notification != nil {
+		m["notification"] = f.Notification
+	}
+	if len(f.Data) > 0 {
+		m["data"] = f.Data
+	}
+
+	return m
+}
@@ -0,0 +1,97 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushPayloadEnvelope(t *testing.T) {
+	assert := assert.New(t)
+	badge := 0
+
+	p := NewPushPayload().
+		APNS(&PNAPNSPayload{
+			Alert:  &PNAPNSAlert{Title: "title", Body: "body"},
+			Badge:  &badge,
+			Sound:  "default",
+			Custom: map[string]interface{}{"id": "1"},
+			Targets: []PNAPNS2Target{
+				{Topic: "com.example.app", ExcludedDevices: []string{"device"}},
+				{Topic: "com.example.app", Environment: PNPushEnvironmentProduction},
+			},
+		}).
+		FCM(&PNFCMPayload{
+			Notification: &PNFCMNotification{Title: "title", Body: "body"},
+			Data:         map[string]interface{}{"id": "1"},
+		}).
+		Debug(true)
+
+	b, err := json.Marshal(p.envelope())
+	assert.Nil(err)
+	assert.JSONEq(`{
+		"pn_apns": {
+			"aps": {"alert": {"title": "title", "body": "body"}, "badge": 0, "sound": "default"},
+			"id": "1",
+			"pn_push": [{
+				"auth_method": "token",
+				"version": "v2",
+				"targets": [
+					{"topic": "com.example.app", "environment": "development", "excluded_devices": ["device"]},
+					{"topic": "com.example.app", "environment": "production"}
+				]
+			}]
+		},
+		"pn_gcm": {
+			"notification": {"title": "title", "body": "body"},
+			"data": {"id": "1"}
+		},
+		"pn_debug": true
+	}`, string(b))
+}
+
+func TestPushPayloadAPNSWithoutTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	m := (&PNAPNSPayload{ContentAvailable: true}).toMap()
+
+	assert.Equal(map[string]interface{}{"content-available": 1}, m["aps"])
+	assert.Nil(m["pn_push"])
+}
+
+func TestPushPayloadValidate(t *testing.T) {
+	assert := assert.New(t)
+	opts := &publishOpts{pubnub: pubnub}
+
+	assert.Contains(NewPushPayload().validate(opts).Error(), "Missing APNS or FCM Push Payload")
+
+	p := NewPushPayload().APNS(&PNAPNSPayload{Targets: []PNAPNS2Target{{}}})
+	assert.Contains(p.validate(opts).Error(), "Missing Push Topic")
+
+	p = NewPushPayload().APNS(&PNAPNSPayload{Targets: []PNAPNS2Target{{Topic: "t", Environment: "staging"}}})
+	assert.Contains(p.validate(opts).Error(), "Invalid Push Environment")
+
+	p = NewPushPayload().FCM(&PNFCMPayload{})
+	assert.Nil(p.validate(opts))
+}
+
+func TestPushPayloadCompose(t *testing.T) {
+	assert := assert.New(t)
+	p := NewPushPayload().FCM(&PNFCMPayload{Data: map[string]interface{}{"a": 1}})
+
+	composed, err := p.compose(nil, true, "")
+	assert.Nil(err)
+	assert.Nil(composed["pn_other"])
+
+	composed, err = p.compose("hello", true, "")
+	assert.Nil(err)
+	assert.Equal("hello", composed["pn_other"])
+
+	composed, err = p.compose(`{"text":"hello"}`, false, "")
+	assert.Nil(err)
+	assert.Equal(json.RawMessage(`{"text":"hello"}`), composed["pn_other"])
+
+	_, err = p.compose(1, false, "")
+	assert.Contains(err.Error(), "Message is not JSON serialized")
+}
@@ -10,6 +10,8 @@ import (
 
 const removeAllPushChannelsForDevicePath = "/v1/push/sub-key/%s/devices/%s/remove"
 
+const removeAllPushChannelsForDevicePathAPNS2 = "/v2/push/sub-key/%s/devices-apns2/%s/remove"
+
 var emptyRemoveAllPushChannelsForDeviceResponse *RemoveAllPushChannelsForDeviceResponse
 
 type removeAllPushChannelsForDeviceBuilder struct {
@@ -52,6 +54,18 @@ func (b *removeAllPushChannelsForDeviceBuilder) DeviceIDForPush(
 	return b
 }
 
+// Topic sets the APNS topic (bundle ID) of the app, required with PNPushTypeAPNS2.
+func (b *removeAllPushChannelsForDeviceBuilder) Topic(topic string) *removeAllPushChannelsForDeviceBuilder {
+	b.opts.Topic = topic
+	return b
+}
+
+// Environment sets the APNS environment of the device, development (the default) or production, only used with PNPushTypeAPNS2.
+func (b *removeAllPushChannelsForDeviceBuilder) Environment(env PNPushEnvironment) *removeAllPushChannelsForDeviceBuilder {
+	b.opts.Environment = env
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *removeAllPushChannelsForDeviceBuilder) QueryParam(queryParam map[string]string) *removeAllPushChannelsForDeviceBuilder {
 	b.opts.QueryParam = queryParam
@@ -70,10 +84,20 @@ func (b *removeAllPushChannelsForDeviceBuilder) Execute() (
 	return emptyRemoveAllPushChannelsForDeviceResponse, status, err
 }
 
+// ExecuteAsync runs the RemoveAllPushNotifications request asynchronously, the result of the Future is a *RemoveAllPushChannelsForDeviceResponse.
+func (b *removeAllPushChannelsForDeviceBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type removeAllPushChannelsForDeviceOpts struct {
 	pubnub *PubNub
 
 	PushType        PNPushType
+	Topic           string
+	Environment     PNPushEnvironment
 	QueryParam      map[string]string
 	DeviceIDForPush string
 
@@ -107,6 +131,10 @@ func (o *removeAllPushChannelsForDeviceOpts) validate() error {
 		return newValidationError(o, StrMissingPushType)
 	}
 
+	if err := validatePushParams(o, o.PushType, o.Topic, o.Environment); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -114,6 +142,12 @@ func (o *removeAllPushChannelsForDeviceOpts) validate() error {
 type RemoveAllPushChannelsForDeviceResponse struct{}
 
 func (o *removeAllPushChannelsForDeviceOpts) buildPath() (string, error) {
+	if o.PushType == PNPushTypeAPNS2 {
+		return fmt.Sprintf(removeAllPushChannelsForDevicePathAPNS2,
+			o.pubnub.Config.SubscribeKey,
+			utils.URLEncode(o.DeviceIDForPush)), nil
+	}
+
 	return fmt.Sprintf(removeAllPushChannelsForDevicePath,
 		o.pubnub.Config.SubscribeKey,
 		utils.URLEncode(o.DeviceIDForPush)), nil
@@ -121,7 +155,7 @@ func (o *removeAllPushChannelsForDeviceOpts) buildPath() (string, error) {
 
 func (o *removeAllPushChannelsForDeviceOpts) buildQuery() (*url.Values, error) {
 	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
-	q.Set("type", o.PushType.String())
+	setPushQuery(q, o.PushType, o.Topic, o.Environment)
 	SetQueryParam(q, o.QueryParam)
 	return q, nil
 }
@@ -140,3 +140,25 @@ func TestRemoveAllPushChannelsForDeviceOptsValidateSub(t *testing.T) {
 
 	assert.Equal("pubnub/validation: pubnub: \x0e: Missing Subscribe Key", opts.validate().Error())
 }
+
+func TestRemoveAllPushNotificationsAPNS2(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveAllPushChannelsForDeviceBuilder(pubnub)
+	o.DeviceIDForPush("deviceID")
+	o.PushType(PNPushTypeAPNS2)
+
+	assert.Contains(o.opts.validate().Error(), "Missing Push Topic")
+
+	o.Topic("com.example.app")
+	assert.Nil(o.opts.validate())
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/push/sub-key/sub_key/devices-apns2/deviceID/remove", path)
+
+	u, err := o.opts.buildQuery()
+	assert.Nil(err)
+	assert.Equal("com.example.app", u.Get("topic"))
+	assert.Equal("development", u.Get("environment"))
+}
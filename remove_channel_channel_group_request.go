@@ -72,6 +72,14 @@ func (b *removeChannelFromChannelGroupBuilder) Execute() (
 	return newRemoveChannelFromChannelGroupResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the RemoveChannelFromChannelGroup request asynchronously, the result of the Future is a *RemoveChannelFromChannelGroupResponse.
+func (b *removeChannelFromChannelGroupBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type removeChannelOpts struct {
 	pubnub *PubNub
 
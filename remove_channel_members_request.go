@@ -0,0 +1,268 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const removeChannelMembersPath = "/v2/objects/%s/channels/%s/uuids"
+
+var emptyRemoveChannelMembersResponse *PNRemoveChannelMembersResponse
+
+type removeChannelMembersBuilder struct {
+	opts *removeChannelMembersOpts
+}
+
+func newRemoveChannelMembersBuilder(pubnub *PubNub) *removeChannelMembersBuilder {
+	builder := removeChannelMembersBuilder{
+		opts: &removeChannelMembersOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newRemoveChannelMembersBuilderWithContext(pubnub *PubNub,
+	context Context) *removeChannelMembersBuilder {
+	builder := newRemoveChannelMembersBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose members are removed.
+func (b *removeChannelMembersBuilder) Channel(ch string) *removeChannelMembersBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// Remove sets the UUIDs which are no longer members of the channel.
+func (b *removeChannelMembersBuilder) Remove(uuids []string) *removeChannelMembersBuilder {
+	b.opts.Remove = uuids
+	return b
+}
+
+// IncludeCustom returns the custom fields of the members.
+func (b *removeChannelMembersBuilder) IncludeCustom(include bool) *removeChannelMembersBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeUUID returns the metadata of the UUIDs, only their ID otherwise.
+func (b *removeChannelMembersBuilder) IncludeUUID(include bool) *removeChannelMembersBuilder {
+	b.opts.IncludeUUID = include
+	return b
+}
+
+// IncludeUUIDCustom returns the custom fields of the UUIDs, with IncludeUUID.
+func (b *removeChannelMembersBuilder) IncludeUUIDCustom(include bool) *removeChannelMembersBuilder {
+	b.opts.IncludeUUIDCustom = include
+	return b
+}
+
+// Limit sets the number of members returned, at most 100 (default).
+func (b *removeChannelMembersBuilder) Limit(limit int) *removeChannelMembersBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of members.
+func (b *removeChannelMembersBuilder) Start(start string) *removeChannelMembersBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of members.
+func (b *removeChannelMembersBuilder) End(end string) *removeChannelMembersBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *removeChannelMembersBuilder) Count(count bool) *removeChannelMembersBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *removeChannelMembersBuilder) Sort(sort []string) *removeChannelMembersBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *removeChannelMembersBuilder) Filter(filter string) *removeChannelMembersBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *removeChannelMembersBuilder) QueryParam(queryParam map[string]string) *removeChannelMembersBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Remove Channel Members request.
+func (b *removeChannelMembersBuilder) Transport(tr http.RoundTripper) *removeChannelMembersBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Remove Channel Members request.
+func (b *removeChannelMembersBuilder) Execute() (*PNRemoveChannelMembersResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyRemoveChannelMembersResponse, status, err
+	}
+
+	return newRemoveChannelMembersResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Remove Channel Members request asynchronously, the result of the Future is a *PNRemoveChannelMembersResponse.
+func (b *removeChannelMembersBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type removeChannelMembersOpts struct {
+	pubnub *PubNub
+
+	Channel           string
+	Remove            []string
+	IncludeCustom     bool
+	IncludeUUID       bool
+	IncludeUUIDCustom bool
+	Limit             int
+	Start             string
+	End               string
+	Count             bool
+	Sort              []string
+	Filter            string
+	QueryParam        map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *removeChannelMembersOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *removeChannelMembersOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *removeChannelMembersOpts) context() Context {
+	return o.ctx
+}
+
+func (o *removeChannelMembersOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if len(o.Remove) == 0 {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *removeChannelMembersOpts) buildPath() (string, error) {
+	return fmt.Sprintf(removeChannelMembersPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *removeChannelMembersOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":      o.IncludeCustom,
+		"uuid":        o.IncludeUUID,
+		"uuid.custom": o.IncludeUUIDCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *removeChannelMembersOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *removeChannelMembersOpts) buildBody() ([]byte, error) {
+	items := make([]map[string]interface{}, len(o.Remove))
+	for i, uuid := range o.Remove {
+		items[i] = objectsChangeItem("uuid", uuid, nil)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"delete": items,
+	})
+}
+
+func (o *removeChannelMembersOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *removeChannelMembersOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *removeChannelMembersOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *removeChannelMembersOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *removeChannelMembersOpts) operationType() OperationType {
+	return PNRemoveChannelMembersOperation
+}
+
+func (o *removeChannelMembersOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNRemoveChannelMembersResponse is the response to the Remove Channel Members request.
+type PNRemoveChannelMembersResponse struct {
+	Status     int               `json:"status"`
+	Data       []PNChannelMember `json:"data"`
+	TotalCount int               `json:"totalCount"`
+	Next       string            `json:"next"`
+	Prev       string            `json:"prev"`
+}
+
+func newRemoveChannelMembersResponse(jsonBytes []byte, status StatusResponse) (
+	*PNRemoveChannelMembersResponse, StatusResponse, error) {
+	resp := &PNRemoveChannelMembersResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyRemoveChannelMembersResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,27 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveChannelMembersValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newRemoveChannelMembersBuilder(pubnub).Channel("ch").opts.validate().Error(), "Missing UUID")
+	assert.Nil(newRemoveChannelMembersBuilder(pubnub).Channel("ch").Remove([]string{"uuid"}).opts.validate())
+}
+
+func TestRemoveChannelMembersBuildBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveChannelMembersBuilderWithContext(pubnub, backgroundContext).
+		Channel("ch").
+		Remove([]string{"uuid1", "uuid2"})
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"delete":[{"uuid":{"id":"uuid1"}},{"uuid":{"id":"uuid2"}}]}`, string(body))
+	assert.Equal("PATCH", o.opts.httpMethod())
+}
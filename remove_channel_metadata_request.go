@@ -0,0 +1,170 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const removeChannelMetadataPath = "/v2/objects/%s/channels/%s"
+
+var emptyRemoveChannelMetadataResponse *PNRemoveChannelMetadataResponse
+
+type removeChannelMetadataBuilder struct {
+	opts *removeChannelMetadataOpts
+}
+
+func newRemoveChannelMetadataBuilder(pubnub *PubNub) *removeChannelMetadataBuilder {
+	builder := removeChannelMetadataBuilder{
+		opts: &removeChannelMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newRemoveChannelMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *removeChannelMetadataBuilder {
+	builder := newRemoveChannelMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose metadata is removed.
+func (b *removeChannelMetadataBuilder) Channel(ch string) *removeChannelMetadataBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *removeChannelMetadataBuilder) QueryParam(queryParam map[string]string) *removeChannelMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Remove Channel Metadata request.
+func (b *removeChannelMetadataBuilder) Transport(tr http.RoundTripper) *removeChannelMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Remove Channel Metadata request.
+func (b *removeChannelMetadataBuilder) Execute() (*PNRemoveChannelMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyRemoveChannelMetadataResponse, status, err
+	}
+
+	return newRemoveChannelMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Remove Channel Metadata request asynchronously, the result of the Future is a *PNRemoveChannelMetadataResponse.
+func (b *removeChannelMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type removeChannelMetadataOpts struct {
+	pubnub *PubNub
+
+	Channel    string
+	QueryParam map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *removeChannelMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *removeChannelMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *removeChannelMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *removeChannelMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	return nil
+}
+
+func (o *removeChannelMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(removeChannelMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *removeChannelMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *removeChannelMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *removeChannelMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *removeChannelMetadataOpts) httpMethod() string {
+	return "DELETE"
+}
+
+func (o *removeChannelMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *removeChannelMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *removeChannelMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *removeChannelMetadataOpts) operationType() OperationType {
+	return PNRemoveChannelMetadataOperation
+}
+
+func (o *removeChannelMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNRemoveChannelMetadataResponse is the response to the Remove Channel Metadata request.
+type PNRemoveChannelMetadataResponse struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+func newRemoveChannelMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNRemoveChannelMetadataResponse, StatusResponse, error) {
+	resp := &PNRemoveChannelMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyRemoveChannelMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
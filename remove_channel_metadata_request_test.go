@@ -0,0 +1,24 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveChannelMetadataValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newRemoveChannelMetadataBuilder(pubnub).opts.validate().Error(), "Missing Channel")
+}
+
+func TestRemoveChannelMetadataBuildPath(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveChannelMetadataBuilderWithContext(pubnub, backgroundContext).Channel("ch")
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels/ch", path)
+	assert.Equal("DELETE", o.opts.httpMethod())
+}
@@ -11,6 +11,8 @@ import (
 
 const removeChannelsFromPushPath = "/v1/push/sub-key/%s/devices/%s"
 
+const removeChannelsFromPushPathAPNS2 = "/v2/push/sub-key/%s/devices-apns2/%s"
+
 var emptyRemoveChannelsFromPushResponse *RemoveChannelsFromPushResponse
 
 type removeChannelsFromPushBuilder struct {
@@ -60,6 +62,18 @@ func (b *removeChannelsFromPushBuilder) DeviceIDForPush(
 	return b
 }
 
+// Topic sets the APNS topic (bundle ID) of the app, required with PNPushTypeAPNS2.
+func (b *removeChannelsFromPushBuilder) Topic(topic string) *removeChannelsFromPushBuilder {
+	b.opts.Topic = topic
+	return b
+}
+
+// Environment sets the APNS environment of the device, development (the default) or production, only used with PNPushTypeAPNS2.
+func (b *removeChannelsFromPushBuilder) Environment(env PNPushEnvironment) *removeChannelsFromPushBuilder {
+	b.opts.Environment = env
+	return b
+}
+
 // QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
 func (b *removeChannelsFromPushBuilder) QueryParam(queryParam map[string]string) *removeChannelsFromPushBuilder {
 	b.opts.QueryParam = queryParam
@@ -78,12 +92,22 @@ func (b *removeChannelsFromPushBuilder) Execute() (
 	return emptyRemoveChannelsFromPushResponse, status, err
 }
 
+// ExecuteAsync runs the RemovePushNotificationsFromChannels request asynchronously, the result of the Future is a *RemoveChannelsFromPushResponse.
+func (b *removeChannelsFromPushBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type removeChannelsFromPushOpts struct {
 	pubnub *PubNub
 
 	Channels        []string
 	QueryParam      map[string]string
 	PushType        PNPushType
+	Topic           string
+	Environment     PNPushEnvironment
 	DeviceIDForPush string
 
 	Transport http.RoundTripper
@@ -120,6 +144,10 @@ func (o *removeChannelsFromPushOpts) validate() error {
 		return newValidationError(o, StrMissingPushType)
 	}
 
+	if err := validatePushParams(o, o.PushType, o.Topic, o.Environment); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -127,6 +155,12 @@ func (o *removeChannelsFromPushOpts) validate() error {
 type RemoveChannelsFromPushResponse struct{}
 
 func (o *removeChannelsFromPushOpts) buildPath() (string, error) {
+	if o.PushType == PNPushTypeAPNS2 {
+		return fmt.Sprintf(removeChannelsFromPushPathAPNS2,
+			o.pubnub.Config.SubscribeKey,
+			utils.URLEncode(o.DeviceIDForPush)), nil
+	}
+
 	return fmt.Sprintf(removeChannelsFromPushPath,
 		o.pubnub.Config.SubscribeKey,
 		utils.URLEncode(o.DeviceIDForPush)), nil
@@ -134,7 +168,7 @@ func (o *removeChannelsFromPushOpts) buildPath() (string, error) {
 
 func (o *removeChannelsFromPushOpts) buildQuery() (*url.Values, error) {
 	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
-	q.Set("type", o.PushType.String())
+	setPushQuery(q, o.PushType, o.Topic, o.Environment)
 	var channels []string
 
 	for _, v := range o.Channels {
@@ -0,0 +1,276 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const removeMembershipsPath = "/v2/objects/%s/uuids/%s/channels"
+
+var emptyRemoveMembershipsResponse *PNRemoveMembershipsResponse
+
+type removeMembershipsBuilder struct {
+	opts *removeMembershipsOpts
+}
+
+func newRemoveMembershipsBuilder(pubnub *PubNub) *removeMembershipsBuilder {
+	builder := removeMembershipsBuilder{
+		opts: &removeMembershipsOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newRemoveMembershipsBuilderWithContext(pubnub *PubNub,
+	context Context) *removeMembershipsBuilder {
+	builder := newRemoveMembershipsBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose channel memberships are removed, the UUID of the config by default.
+func (b *removeMembershipsBuilder) UUID(uuid string) *removeMembershipsBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// Remove sets the channels the UUID is no longer a member of.
+func (b *removeMembershipsBuilder) Remove(channels []string) *removeMembershipsBuilder {
+	b.opts.Remove = channels
+	return b
+}
+
+// IncludeCustom returns the custom fields of the memberships.
+func (b *removeMembershipsBuilder) IncludeCustom(include bool) *removeMembershipsBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeChannel returns the metadata of the channels, only their ID otherwise.
+func (b *removeMembershipsBuilder) IncludeChannel(include bool) *removeMembershipsBuilder {
+	b.opts.IncludeChannel = include
+	return b
+}
+
+// IncludeChannelCustom returns the custom fields of the channels, with IncludeChannel.
+func (b *removeMembershipsBuilder) IncludeChannelCustom(include bool) *removeMembershipsBuilder {
+	b.opts.IncludeChannelCustom = include
+	return b
+}
+
+// Limit sets the number of memberships returned, at most 100 (default).
+func (b *removeMembershipsBuilder) Limit(limit int) *removeMembershipsBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of memberships.
+func (b *removeMembershipsBuilder) Start(start string) *removeMembershipsBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of memberships.
+func (b *removeMembershipsBuilder) End(end string) *removeMembershipsBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *removeMembershipsBuilder) Count(count bool) *removeMembershipsBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *removeMembershipsBuilder) Sort(sort []string) *removeMembershipsBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *removeMembershipsBuilder) Filter(filter string) *removeMembershipsBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *removeMembershipsBuilder) QueryParam(queryParam map[string]string) *removeMembershipsBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Remove Memberships request.
+func (b *removeMembershipsBuilder) Transport(tr http.RoundTripper) *removeMembershipsBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Remove Memberships request.
+func (b *removeMembershipsBuilder) Execute() (*PNRemoveMembershipsResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyRemoveMembershipsResponse, status, err
+	}
+
+	return newRemoveMembershipsResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Remove Memberships request asynchronously, the result of the Future is a *PNRemoveMembershipsResponse.
+func (b *removeMembershipsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type removeMembershipsOpts struct {
+	pubnub *PubNub
+
+	UUID                 string
+	Remove               []string
+	IncludeCustom        bool
+	IncludeChannel       bool
+	IncludeChannelCustom bool
+	Limit                int
+	Start                string
+	End                  string
+	Count                bool
+	Sort                 []string
+	Filter               string
+	QueryParam           map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *removeMembershipsOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *removeMembershipsOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *removeMembershipsOpts) context() Context {
+	return o.ctx
+}
+
+func (o *removeMembershipsOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	if len(o.Remove) == 0 {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *removeMembershipsOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *removeMembershipsOpts) buildPath() (string, error) {
+	return fmt.Sprintf(removeMembershipsPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *removeMembershipsOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":         o.IncludeCustom,
+		"channel":        o.IncludeChannel,
+		"channel.custom": o.IncludeChannelCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *removeMembershipsOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *removeMembershipsOpts) buildBody() ([]byte, error) {
+	items := make([]map[string]interface{}, len(o.Remove))
+	for i, ch := range o.Remove {
+		items[i] = objectsChangeItem("channel", ch, nil)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"delete": items,
+	})
+}
+
+func (o *removeMembershipsOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *removeMembershipsOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *removeMembershipsOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *removeMembershipsOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *removeMembershipsOpts) operationType() OperationType {
+	return PNRemoveMembershipsOperation
+}
+
+func (o *removeMembershipsOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNRemoveMembershipsResponse is the response to the Remove Memberships request.
+type PNRemoveMembershipsResponse struct {
+	Status     int            `json:"status"`
+	Data       []PNMembership `json:"data"`
+	TotalCount int            `json:"totalCount"`
+	Next       string         `json:"next"`
+	Prev       string         `json:"prev"`
+}
+
+func newRemoveMembershipsResponse(jsonBytes []byte, status StatusResponse) (
+	*PNRemoveMembershipsResponse, StatusResponse, error) {
+	resp := &PNRemoveMembershipsResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyRemoveMembershipsResponse, status, err
+	}
+
+	return resp, status, nil
+}
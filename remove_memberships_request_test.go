@@ -0,0 +1,30 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveMembershipsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newRemoveMembershipsBuilder(pubnub).opts.validate().Error(), "Missing Channel")
+	assert.Nil(newRemoveMembershipsBuilder(pubnub).Remove([]string{"ch"}).opts.validate())
+}
+
+func TestRemoveMembershipsBuildPathAndBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveMembershipsBuilderWithContext(pubnub, backgroundContext).
+		UUID("uuid").
+		Remove([]string{"ch1", "ch2"})
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/uuids/uuid/channels", path)
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"delete":[{"channel":{"id":"ch1"}},{"channel":{"id":"ch2"}}]}`, string(body))
+}
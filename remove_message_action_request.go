@@ -0,0 +1,186 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pubnub/go/utils"
+)
+
+const removeMessageActionPath = "/v1/message-actions/%s/channel/%s/message/%s/action/%s"
+
+var emptyRemoveMessageActionResponse *PNRemoveMessageActionResponse
+
+type removeMessageActionBuilder struct {
+	opts *removeMessageActionOpts
+}
+
+func newRemoveMessageActionBuilder(pubnub *PubNub) *removeMessageActionBuilder {
+	builder := removeMessageActionBuilder{
+		opts: &removeMessageActionOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newRemoveMessageActionBuilderWithContext(pubnub *PubNub,
+	context Context) *removeMessageActionBuilder {
+	builder := removeMessageActionBuilder{
+		opts: &removeMessageActionOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// Channel sets the Channel of the message.
+func (b *removeMessageActionBuilder) Channel(ch string) *removeMessageActionBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// MessageTimetoken sets the timetoken of the message the action is removed from.
+func (b *removeMessageActionBuilder) MessageTimetoken(timetoken int64) *removeMessageActionBuilder {
+	b.opts.MessageTimetoken = timetoken
+	return b
+}
+
+// ActionTimetoken sets the timetoken of the action to remove.
+func (b *removeMessageActionBuilder) ActionTimetoken(timetoken int64) *removeMessageActionBuilder {
+	b.opts.ActionTimetoken = timetoken
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *removeMessageActionBuilder) QueryParam(queryParam map[string]string) *removeMessageActionBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Remove Message Action request.
+func (b *removeMessageActionBuilder) Transport(tr http.RoundTripper) *removeMessageActionBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Remove Message Action request.
+func (b *removeMessageActionBuilder) Execute() (*PNRemoveMessageActionResponse, StatusResponse, error) {
+	_, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyRemoveMessageActionResponse, status, err
+	}
+
+	return &PNRemoveMessageActionResponse{}, status, nil
+}
+
+// ExecuteAsync runs the Remove Message Action request asynchronously, the result of the Future is a *PNRemoveMessageActionResponse.
+func (b *removeMessageActionBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type removeMessageActionOpts struct {
+	pubnub *PubNub
+
+	Channel          string
+	MessageTimetoken int64
+	ActionTimetoken  int64
+	QueryParam       map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *removeMessageActionOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *removeMessageActionOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *removeMessageActionOpts) context() Context {
+	return o.ctx
+}
+
+func (o *removeMessageActionOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if o.MessageTimetoken <= 0 {
+		return newValidationError(o, "Missing Message Timetoken")
+	}
+
+	if o.ActionTimetoken <= 0 {
+		return newValidationError(o, "Missing Action Timetoken")
+	}
+
+	return nil
+}
+
+func (o *removeMessageActionOpts) buildPath() (string, error) {
+	return fmt.Sprintf(removeMessageActionPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel),
+		strconv.FormatInt(o.MessageTimetoken, 10),
+		strconv.FormatInt(o.ActionTimetoken, 10)), nil
+}
+
+func (o *removeMessageActionOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *removeMessageActionOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *removeMessageActionOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *removeMessageActionOpts) httpMethod() string {
+	return "DELETE"
+}
+
+func (o *removeMessageActionOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *removeMessageActionOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *removeMessageActionOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *removeMessageActionOpts) operationType() OperationType {
+	return PNRemoveMessageActionOperation
+}
+
+func (o *removeMessageActionOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNRemoveMessageActionResponse is the response to the Remove Message Action request.
+type PNRemoveMessageActionResponse struct {
+}
@@ -0,0 +1,178 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const removeUUIDMetadataPath = "/v2/objects/%s/uuids/%s"
+
+var emptyRemoveUUIDMetadataResponse *PNRemoveUUIDMetadataResponse
+
+type removeUUIDMetadataBuilder struct {
+	opts *removeUUIDMetadataOpts
+}
+
+func newRemoveUUIDMetadataBuilder(pubnub *PubNub) *removeUUIDMetadataBuilder {
+	builder := removeUUIDMetadataBuilder{
+		opts: &removeUUIDMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newRemoveUUIDMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *removeUUIDMetadataBuilder {
+	builder := newRemoveUUIDMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose metadata is removed, the UUID of the config by default.
+func (b *removeUUIDMetadataBuilder) UUID(uuid string) *removeUUIDMetadataBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *removeUUIDMetadataBuilder) QueryParam(queryParam map[string]string) *removeUUIDMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Remove UUID Metadata request.
+func (b *removeUUIDMetadataBuilder) Transport(tr http.RoundTripper) *removeUUIDMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Remove UUID Metadata request.
+func (b *removeUUIDMetadataBuilder) Execute() (*PNRemoveUUIDMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyRemoveUUIDMetadataResponse, status, err
+	}
+
+	return newRemoveUUIDMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Remove UUID Metadata request asynchronously, the result of the Future is a *PNRemoveUUIDMetadataResponse.
+func (b *removeUUIDMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type removeUUIDMetadataOpts struct {
+	pubnub *PubNub
+
+	UUID       string
+	QueryParam map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *removeUUIDMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *removeUUIDMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *removeUUIDMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *removeUUIDMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	return nil
+}
+
+func (o *removeUUIDMetadataOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *removeUUIDMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(removeUUIDMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *removeUUIDMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *removeUUIDMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *removeUUIDMetadataOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *removeUUIDMetadataOpts) httpMethod() string {
+	return "DELETE"
+}
+
+func (o *removeUUIDMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *removeUUIDMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *removeUUIDMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *removeUUIDMetadataOpts) operationType() OperationType {
+	return PNRemoveUUIDMetadataOperation
+}
+
+func (o *removeUUIDMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNRemoveUUIDMetadataResponse is the response to the Remove UUID Metadata request.
+type PNRemoveUUIDMetadataResponse struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+func newRemoveUUIDMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNRemoveUUIDMetadataResponse, StatusResponse, error) {
+	resp := &PNRemoveUUIDMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptyRemoveUUIDMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
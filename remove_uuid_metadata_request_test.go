@@ -0,0 +1,42 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveUUIDMetadataBuildPath(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newRemoveUUIDMetadataBuilderWithContext(pubnub, backgroundContext).UUID("uuid")
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/uuids/uuid", path)
+	assert.Equal("DELETE", o.opts.httpMethod())
+}
+
+func TestRemoveUUIDMetadataExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "DELETE",
+		Path:               "/v2/objects/demo/uuids/uuid",
+		Query:              "",
+		ResponseBody:       `{"status":200,"data":null}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_obj"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.RemoveUUIDMetadata().UUID("uuid").Execute()
+
+	assert.Nil(err)
+	assert.Equal(200, res.Status)
+	assert.Nil(res.Data)
+}
@@ -44,10 +44,34 @@ func addToJobQ(req *http.Request, client *http.Client, opts endpointOpts, j chan
 		Client:      client,
 		JobResponse: j,
 	}
+	if opts.config().SecretKey != "" {
+		jqi.sign = func() (*url.URL, error) {
+			return buildURL(opts)
+		}
+	}
 	opts.jobQueue() <- jqi
 }
 
+// executeRequest runs the request. If the server denies it and the config has
+// a CredentialsProvider the credentials are refreshed and the request is sent
+// once more.
 func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
+	val, status, err := executeRequestOnce(opts)
+
+	if err != nil && status.StatusCode == 403 {
+		if provider := opts.config().CredentialsProvider; provider != nil {
+			opts.config().Log.Println("Access denied, refreshing the credentials", status.AuthKey)
+
+			if provider.Refresh(status.AuthKey) {
+				return executeRequestOnce(opts)
+			}
+		}
+	}
+
+	return val, status, err
+}
+
+func executeRequestOnce(opts endpointOpts) ([]byte, StatusResponse, error) {
 	err := opts.validate()
 
 	if err != nil {
@@ -57,6 +81,15 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 			err
 	}
 
+	if opts.config().PreflightAccessChecks {
+		if err := checkCachedPermissions(opts); err != nil {
+			opts.config().Log.Println("PNAccessDeniedCategory", err)
+			return nil,
+				createStatus(PNAccessDeniedCategory, "", ResponseInfo{Operation: opts.operationType()}, err),
+				err
+		}
+	}
+
 	url, err := buildURL(opts)
 
 	if err != nil {
@@ -70,7 +103,7 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 
 	var req *http.Request
 
-	if opts.httpMethod() == "POST" {
+	if opts.httpMethod() == "POST" || opts.httpMethod() == "PATCH" {
 		b, err := opts.buildBody()
 		if err != nil {
 			opts.config().Log.Println("PNUnknownCategory", err, url)
@@ -80,7 +113,7 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 		}
 
 		body := bytes.NewReader(b)
-		req, err = newRequest("POST", url, body, opts.config().UseHTTP2)
+		req, err = newRequest(opts.httpMethod(), url, body, opts.config().UseHTTP2)
 	} else if opts.httpMethod() == "DELETE" {
 		req, err = newRequest("DELETE", url, nil, opts.config().UseHTTP2)
 	} else {
@@ -120,6 +153,8 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 		close(j)
 		res = jr.Resp
 		err = jr.Error
+		// The worker signs the request again when it is sent
+		url = req.URL
 	} else {
 		res, err = client.Do(req)
 	}
@@ -138,6 +173,9 @@ func executeRequest(opts endpointOpts) ([]byte, StatusResponse, error) {
 	val, status, err := parseResponse(res, opts)
 	// Already wrapped error
 	if err != nil {
+		if auth, ok := url.Query()["auth"]; ok {
+			status.AuthKey = auth[0]
+		}
 		opts.config().Log.Println("res.StatusCode, status, err.Error()", res.StatusCode, status, err.Error())
 		return nil, status, err
 	}
@@ -1,6 +1,9 @@
 package pubnub
 
-import "net/http"
+import (
+	"net/http"
+	"net/url"
+)
 
 type nonSubMsgType int
 
@@ -18,6 +21,10 @@ type JobQItem struct {
 	Req         *http.Request
 	Client      *http.Client
 	JobResponse chan *JobQResponse
+
+	// sign rebuilds the signed URL when the job is sent, the timestamp of a
+	// job waiting in the queue for a worker would be stale otherwise.
+	sign func() (*url.URL, error)
 }
 
 type RequestWorkers struct {
@@ -45,6 +52,14 @@ func (pw Worker) Process(pubnub *PubNub) {
 		for {
 			pw.Workers <- pw.JobChannel
 			job := <-pw.JobChannel
+			if job.sign != nil {
+				u, err := job.sign()
+				if err != nil {
+					job.JobResponse <- &JobQResponse{Error: err}
+					continue
+				}
+				job.Req.URL = u
+			}
 			res, err := job.Client.Do(job.Req)
 			jqr := &JobQResponse{
 				Error: err,
@@ -0,0 +1,188 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type revokeBuilder struct {
+	opts *revokeOpts
+}
+
+func newRevokeBuilder(pubnub *PubNub) *revokeBuilder {
+	builder := revokeBuilder{
+		opts: &revokeOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newRevokeBuilderWithContext(pubnub *PubNub, context Context) *revokeBuilder {
+	builder := revokeBuilder{
+		opts: &revokeOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// AuthKeys sets the AuthKeys for the Revoke request.
+func (b *revokeBuilder) AuthKeys(authKeys []string) *revokeBuilder {
+	b.opts.AuthKeys = authKeys
+
+	return b
+}
+
+// Channels sets the Channels for the Revoke request.
+func (b *revokeBuilder) Channels(channels []string) *revokeBuilder {
+	b.opts.Channels = channels
+
+	return b
+}
+
+// ChannelGroups sets the ChannelGroups for the Revoke request.
+func (b *revokeBuilder) ChannelGroups(groups []string) *revokeBuilder {
+	b.opts.ChannelGroups = groups
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *revokeBuilder) QueryParam(queryParam map[string]string) *revokeBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute runs the Revoke request, the read, write, manage and delete
+// permissions of the channels, channel groups and auth keys are removed.
+func (b *revokeBuilder) Execute() (*GrantResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptyGrantResponse, status, err
+	}
+
+	resp, status, err := newGrantResponse(rawJSON, status)
+	if err == nil {
+		b.opts.pubnub.permissionCache.addGrantResponse(resp)
+	}
+
+	return resp, status, err
+}
+
+// ExecuteAsync runs the Revoke request asynchronously, the result of the Future is a *GrantResponse.
+func (b *revokeBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type revokeOpts struct {
+	pubnub *PubNub
+	ctx    Context
+
+	AuthKeys      []string
+	Channels      []string
+	ChannelGroups []string
+	QueryParam    map[string]string
+}
+
+func (o *revokeOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *revokeOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *revokeOpts) context() Context {
+	return o.ctx
+}
+
+func (o *revokeOpts) validate() error {
+	if o.config().PublishKey == "" {
+		return newValidationError(o, StrMissingPubKey)
+	}
+
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.config().SecretKey == "" {
+		return newValidationError(o, StrMissingSecretKey)
+	}
+
+	return nil
+}
+
+func (o *revokeOpts) buildPath() (string, error) {
+	return fmt.Sprintf(grantPath, o.pubnub.Config.SubscribeKey), nil
+}
+
+func (o *revokeOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	q.Set("r", "0")
+	q.Set("w", "0")
+	q.Set("m", "0")
+	q.Set("d", "0")
+
+	if len(o.AuthKeys) > 0 {
+		q.Set("auth", strings.Join(o.AuthKeys, ","))
+	}
+
+	if len(o.Channels) > 0 {
+		q.Set("channel", strings.Join(o.Channels, ","))
+	}
+
+	if len(o.ChannelGroups) > 0 {
+		q.Set("channel-group", strings.Join(o.ChannelGroups, ","))
+	}
+
+	timestamp := time.Now().Unix()
+	q.Set("timestamp", strconv.Itoa(int(timestamp)))
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *revokeOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *revokeOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *revokeOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *revokeOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *revokeOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *revokeOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *revokeOpts) operationType() OperationType {
+	return PNAccessManagerRevoke
+}
+
+func (o *revokeOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
@@ -0,0 +1,87 @@
+package pubnub
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeRequestBasic(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &revokeOpts{
+		AuthKeys:      []string{"my-auth-key"},
+		Channels:      []string{"ch1", "ch2"},
+		ChannelGroups: []string{"cg"},
+		pubnub:        pubnub,
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	u := &url.URL{
+		Path: path,
+	}
+
+	h.AssertPathsEqual(t,
+		fmt.Sprintf("/v1/auth/grant/sub-key/%s", opts.pubnub.Config.SubscribeKey),
+		u.EscapedPath(), []int{})
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("auth", "my-auth-key")
+	expected.Set("channel", "ch1,ch2")
+	expected.Set("channel-group", "cg")
+	expected.Set("r", "0")
+	expected.Set("w", "0")
+	expected.Set("m", "0")
+	expected.Set("d", "0")
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+
+	body, err := opts.buildBody()
+
+	assert.Nil(err)
+	assert.Equal([]byte{}, body)
+}
+
+func TestNewRevokeBuilderContext(t *testing.T) {
+	assert := assert.New(t)
+	o := newRevokeBuilderWithContext(pubnub, backgroundContext)
+	o.AuthKeys([]string{"my-auth-key"})
+	o.Channels([]string{"ch"})
+	o.QueryParam(map[string]string{
+		"q1": "v1",
+	})
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("auth", "my-auth-key")
+	expected.Set("channel", "ch")
+	expected.Set("r", "0")
+	expected.Set("w", "0")
+	expected.Set("m", "0")
+	expected.Set("d", "0")
+	expected.Set("q1", "v1")
+	h.AssertQueriesEqual(t, expected, query,
+		[]string{"pnsdk", "uuid", "timestamp"}, []string{})
+	assert.Equal(PNAccessManagerRevoke, o.opts.operationType())
+}
+
+func TestRevokeOptsValidateSec(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	opts := &revokeOpts{
+		Channels: []string{"ch"},
+		pubnub:   pn,
+	}
+
+	assert.Contains(opts.validate().Error(), "Missing Secret Key")
+}
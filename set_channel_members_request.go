@@ -0,0 +1,274 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const setChannelMembersPath = "/v2/objects/%s/channels/%s/uuids"
+
+var emptySetChannelMembersResponse *PNSetChannelMembersResponse
+
+type setChannelMembersBuilder struct {
+	opts *setChannelMembersOpts
+}
+
+func newSetChannelMembersBuilder(pubnub *PubNub) *setChannelMembersBuilder {
+	builder := setChannelMembersBuilder{
+		opts: &setChannelMembersOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newSetChannelMembersBuilderWithContext(pubnub *PubNub,
+	context Context) *setChannelMembersBuilder {
+	builder := newSetChannelMembersBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose members are set.
+func (b *setChannelMembersBuilder) Channel(ch string) *setChannelMembersBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// Set sets the UUIDs members of the channel, with the custom fields of the members.
+func (b *setChannelMembersBuilder) Set(members []PNChannelMembersSet) *setChannelMembersBuilder {
+	b.opts.Set = members
+	return b
+}
+
+// IncludeCustom returns the custom fields of the members.
+func (b *setChannelMembersBuilder) IncludeCustom(include bool) *setChannelMembersBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeUUID returns the metadata of the UUIDs, only their ID otherwise.
+func (b *setChannelMembersBuilder) IncludeUUID(include bool) *setChannelMembersBuilder {
+	b.opts.IncludeUUID = include
+	return b
+}
+
+// IncludeUUIDCustom returns the custom fields of the UUIDs, with IncludeUUID.
+func (b *setChannelMembersBuilder) IncludeUUIDCustom(include bool) *setChannelMembersBuilder {
+	b.opts.IncludeUUIDCustom = include
+	return b
+}
+
+// Limit sets the number of members returned, at most 100 (default).
+func (b *setChannelMembersBuilder) Limit(limit int) *setChannelMembersBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of members.
+func (b *setChannelMembersBuilder) Start(start string) *setChannelMembersBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of members.
+func (b *setChannelMembersBuilder) End(end string) *setChannelMembersBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *setChannelMembersBuilder) Count(count bool) *setChannelMembersBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *setChannelMembersBuilder) Sort(sort []string) *setChannelMembersBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *setChannelMembersBuilder) Filter(filter string) *setChannelMembersBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *setChannelMembersBuilder) QueryParam(queryParam map[string]string) *setChannelMembersBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Set Channel Members request.
+func (b *setChannelMembersBuilder) Transport(tr http.RoundTripper) *setChannelMembersBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Set Channel Members request.
+func (b *setChannelMembersBuilder) Execute() (*PNSetChannelMembersResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptySetChannelMembersResponse, status, err
+	}
+
+	return newSetChannelMembersResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Set Channel Members request asynchronously, the result of the Future is a *PNSetChannelMembersResponse.
+func (b *setChannelMembersBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type setChannelMembersOpts struct {
+	pubnub *PubNub
+
+	Channel           string
+	Set               []PNChannelMembersSet
+	IncludeCustom     bool
+	IncludeUUID       bool
+	IncludeUUIDCustom bool
+	Limit             int
+	Start             string
+	End               string
+	Count             bool
+	Sort              []string
+	Filter            string
+	QueryParam        map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *setChannelMembersOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *setChannelMembersOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *setChannelMembersOpts) context() Context {
+	return o.ctx
+}
+
+func (o *setChannelMembersOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if len(o.Set) == 0 {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	for _, member := range o.Set {
+		if member.UUID == "" {
+			return newValidationError(o, StrMissingUUID)
+		}
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *setChannelMembersOpts) buildPath() (string, error) {
+	return fmt.Sprintf(setChannelMembersPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *setChannelMembersOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":      o.IncludeCustom,
+		"uuid":        o.IncludeUUID,
+		"uuid.custom": o.IncludeUUIDCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *setChannelMembersOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *setChannelMembersOpts) buildBody() ([]byte, error) {
+	items := make([]map[string]interface{}, len(o.Set))
+	for i, member := range o.Set {
+		items[i] = objectsChangeItem("uuid", member.UUID, member.Custom)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"set": items,
+	})
+}
+
+func (o *setChannelMembersOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *setChannelMembersOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *setChannelMembersOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *setChannelMembersOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *setChannelMembersOpts) operationType() OperationType {
+	return PNSetChannelMembersOperation
+}
+
+func (o *setChannelMembersOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNSetChannelMembersResponse is the response to the Set Channel Members request.
+type PNSetChannelMembersResponse struct {
+	Status     int               `json:"status"`
+	Data       []PNChannelMember `json:"data"`
+	TotalCount int               `json:"totalCount"`
+	Next       string            `json:"next"`
+	Prev       string            `json:"prev"`
+}
+
+func newSetChannelMembersResponse(jsonBytes []byte, status StatusResponse) (
+	*PNSetChannelMembersResponse, StatusResponse, error) {
+	resp := &PNSetChannelMembersResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptySetChannelMembersResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,34 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChannelMembersValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newSetChannelMembersBuilder(pubnub).Channel("ch").opts.validate().Error(), "Missing UUID")
+	assert.Contains(newSetChannelMembersBuilder(pubnub).Channel("ch").Set([]PNChannelMembersSet{{}}).opts.validate().Error(), "Missing UUID")
+	assert.Contains(newSetChannelMembersBuilder(pubnub).Set([]PNChannelMembersSet{{UUID: "uuid"}}).opts.validate().Error(), "Missing Channel")
+}
+
+func TestSetChannelMembersBuildBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newSetChannelMembersBuilderWithContext(pubnub, backgroundContext).
+		Channel("ch").
+		Set([]PNChannelMembersSet{
+			{UUID: "uuid1", Custom: map[string]interface{}{"role": "owner"}},
+			{UUID: "uuid2"},
+		})
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels/ch/uuids", path)
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"set":[{"custom":{"role":"owner"},"uuid":{"id":"uuid1"}},{"uuid":{"id":"uuid2"}}]}`, string(body))
+}
@@ -0,0 +1,214 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const setChannelMetadataPath = "/v2/objects/%s/channels/%s"
+
+var emptySetChannelMetadataResponse *PNSetChannelMetadataResponse
+
+type setChannelMetadataBuilder struct {
+	opts *setChannelMetadataOpts
+}
+
+func newSetChannelMetadataBuilder(pubnub *PubNub) *setChannelMetadataBuilder {
+	builder := setChannelMetadataBuilder{
+		opts: &setChannelMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newSetChannelMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *setChannelMetadataBuilder {
+	builder := newSetChannelMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// Channel sets the Channel whose metadata is set.
+func (b *setChannelMetadataBuilder) Channel(ch string) *setChannelMetadataBuilder {
+	b.opts.Channel = ch
+	return b
+}
+
+// Name sets the display name of the channel.
+func (b *setChannelMetadataBuilder) Name(name string) *setChannelMetadataBuilder {
+	b.opts.Name = name
+	return b
+}
+
+// Description sets the description of the channel.
+func (b *setChannelMetadataBuilder) Description(description string) *setChannelMetadataBuilder {
+	b.opts.Description = description
+	return b
+}
+
+// Custom sets the custom fields of the channel, the values are strings, numbers or booleans.
+func (b *setChannelMetadataBuilder) Custom(custom map[string]interface{}) *setChannelMetadataBuilder {
+	b.opts.Custom = custom
+	return b
+}
+
+// IncludeCustom returns the custom fields in the response.
+func (b *setChannelMetadataBuilder) IncludeCustom(include bool) *setChannelMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *setChannelMetadataBuilder) QueryParam(queryParam map[string]string) *setChannelMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Set Channel Metadata request.
+func (b *setChannelMetadataBuilder) Transport(tr http.RoundTripper) *setChannelMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Set Channel Metadata request.
+func (b *setChannelMetadataBuilder) Execute() (*PNSetChannelMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptySetChannelMetadataResponse, status, err
+	}
+
+	return newSetChannelMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Set Channel Metadata request asynchronously, the result of the Future is a *PNSetChannelMetadataResponse.
+func (b *setChannelMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type setChannelMetadataOpts struct {
+	pubnub *PubNub
+
+	Channel       string
+	Name          string
+	Description   string
+	Custom        map[string]interface{}
+	IncludeCustom bool
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *setChannelMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *setChannelMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *setChannelMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *setChannelMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	return nil
+}
+
+func (o *setChannelMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(setChannelMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel)), nil
+}
+
+func (o *setChannelMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *setChannelMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *setChannelMetadataOpts) buildBody() ([]byte, error) {
+	body := map[string]interface{}{}
+	if o.Name != "" {
+		body["name"] = o.Name
+	}
+	if o.Description != "" {
+		body["description"] = o.Description
+	}
+	if o.Custom != nil {
+		body["custom"] = o.Custom
+	}
+
+	return json.Marshal(body)
+}
+
+func (o *setChannelMetadataOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *setChannelMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *setChannelMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *setChannelMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *setChannelMetadataOpts) operationType() OperationType {
+	return PNSetChannelMetadataOperation
+}
+
+func (o *setChannelMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNSetChannelMetadataResponse is the response to the Set Channel Metadata request.
+type PNSetChannelMetadataResponse struct {
+	Status int       `json:"status"`
+	Data   PNChannel `json:"data"`
+}
+
+func newSetChannelMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNSetChannelMetadataResponse, StatusResponse, error) {
+	resp := &PNSetChannelMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptySetChannelMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
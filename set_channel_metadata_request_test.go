@@ -0,0 +1,60 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChannelMetadataValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newSetChannelMetadataBuilder(pubnub).opts.validate().Error(), "Missing Channel")
+	assert.Nil(newSetChannelMetadataBuilder(pubnub).Channel("ch").opts.validate())
+}
+
+func TestSetChannelMetadataBuildPathAndBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newSetChannelMetadataBuilderWithContext(pubnub, backgroundContext).
+		Channel("my ch").
+		Name("name").
+		Description("description")
+
+	path, err := o.opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/sub_key/channels/my%20ch", path)
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"description":"description","name":"name"}`, string(body))
+	assert.Equal("PATCH", o.opts.httpMethod())
+}
+
+func TestSetChannelMetadataExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "PATCH",
+		Path:               "/v2/objects/demo/channels/ch",
+		Query:              "",
+		ResponseBody:       `{"status":200,"data":{"id":"ch","name":"name","description":"description","updated":"2020-06-17T16:28:14.060718Z","eTag":"AbO7ys6S2pH8cQ"}}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_obj"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.SetChannelMetadata().
+		Channel("ch").
+		Name("name").
+		Description("description").
+		Execute()
+
+	assert.Nil(err)
+	assert.Equal("ch", res.Data.ID)
+	assert.Equal("description", res.Data.Description)
+}
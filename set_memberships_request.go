@@ -0,0 +1,282 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const setMembershipsPath = "/v2/objects/%s/uuids/%s/channels"
+
+var emptySetMembershipsResponse *PNSetMembershipsResponse
+
+type setMembershipsBuilder struct {
+	opts *setMembershipsOpts
+}
+
+func newSetMembershipsBuilder(pubnub *PubNub) *setMembershipsBuilder {
+	builder := setMembershipsBuilder{
+		opts: &setMembershipsOpts{
+			pubnub: pubnub,
+			Limit:  objectsLimit,
+		},
+	}
+
+	return &builder
+}
+
+func newSetMembershipsBuilderWithContext(pubnub *PubNub,
+	context Context) *setMembershipsBuilder {
+	builder := newSetMembershipsBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose channel memberships are set, the UUID of the config by default.
+func (b *setMembershipsBuilder) UUID(uuid string) *setMembershipsBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// Set sets the channels the UUID is a member of, with the custom fields of the memberships.
+func (b *setMembershipsBuilder) Set(memberships []PNMembershipsSet) *setMembershipsBuilder {
+	b.opts.Set = memberships
+	return b
+}
+
+// IncludeCustom returns the custom fields of the memberships.
+func (b *setMembershipsBuilder) IncludeCustom(include bool) *setMembershipsBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// IncludeChannel returns the metadata of the channels, only their ID otherwise.
+func (b *setMembershipsBuilder) IncludeChannel(include bool) *setMembershipsBuilder {
+	b.opts.IncludeChannel = include
+	return b
+}
+
+// IncludeChannelCustom returns the custom fields of the channels, with IncludeChannel.
+func (b *setMembershipsBuilder) IncludeChannelCustom(include bool) *setMembershipsBuilder {
+	b.opts.IncludeChannelCustom = include
+	return b
+}
+
+// Limit sets the number of memberships returned, at most 100 (default).
+func (b *setMembershipsBuilder) Limit(limit int) *setMembershipsBuilder {
+	b.opts.Limit = limit
+	return b
+}
+
+// Start sets the Next cursor of a previous response to return the next page of memberships.
+func (b *setMembershipsBuilder) Start(start string) *setMembershipsBuilder {
+	b.opts.Start = start
+	return b
+}
+
+// End sets the Prev cursor of a previous response to return the previous page of memberships.
+func (b *setMembershipsBuilder) End(end string) *setMembershipsBuilder {
+	b.opts.End = end
+	return b
+}
+
+// Count returns the total number of matching objects in TotalCount.
+func (b *setMembershipsBuilder) Count(count bool) *setMembershipsBuilder {
+	b.opts.Count = count
+	return b
+}
+
+// Sort sets the sort order, a list of fields with an optional direction, for ex. "name:desc".
+func (b *setMembershipsBuilder) Sort(sort []string) *setMembershipsBuilder {
+	b.opts.Sort = sort
+	return b
+}
+
+// Filter sets the filter expression the returned objects match, for ex. `name like "a*"`.
+func (b *setMembershipsBuilder) Filter(filter string) *setMembershipsBuilder {
+	b.opts.Filter = filter
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *setMembershipsBuilder) QueryParam(queryParam map[string]string) *setMembershipsBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Set Memberships request.
+func (b *setMembershipsBuilder) Transport(tr http.RoundTripper) *setMembershipsBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Set Memberships request.
+func (b *setMembershipsBuilder) Execute() (*PNSetMembershipsResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptySetMembershipsResponse, status, err
+	}
+
+	return newSetMembershipsResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Set Memberships request asynchronously, the result of the Future is a *PNSetMembershipsResponse.
+func (b *setMembershipsBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type setMembershipsOpts struct {
+	pubnub *PubNub
+
+	UUID                 string
+	Set                  []PNMembershipsSet
+	IncludeCustom        bool
+	IncludeChannel       bool
+	IncludeChannelCustom bool
+	Limit                int
+	Start                string
+	End                  string
+	Count                bool
+	Sort                 []string
+	Filter               string
+	QueryParam           map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *setMembershipsOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *setMembershipsOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *setMembershipsOpts) context() Context {
+	return o.ctx
+}
+
+func (o *setMembershipsOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	if len(o.Set) == 0 {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	for _, membership := range o.Set {
+		if membership.Channel == "" {
+			return newValidationError(o, StrMissingChannel)
+		}
+	}
+
+	if err := validateObjectsLimit(o, o.Limit); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *setMembershipsOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *setMembershipsOpts) buildPath() (string, error) {
+	return fmt.Sprintf(setMembershipsPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *setMembershipsOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom":         o.IncludeCustom,
+		"channel":        o.IncludeChannel,
+		"channel.custom": o.IncludeChannelCustom,
+	})
+
+	setObjectsListQuery(q, o.Limit, o.Start, o.End, o.Count, o.Sort, o.Filter)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *setMembershipsOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *setMembershipsOpts) buildBody() ([]byte, error) {
+	items := make([]map[string]interface{}, len(o.Set))
+	for i, membership := range o.Set {
+		items[i] = objectsChangeItem("channel", membership.Channel, membership.Custom)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"set": items,
+	})
+}
+
+func (o *setMembershipsOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *setMembershipsOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *setMembershipsOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *setMembershipsOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *setMembershipsOpts) operationType() OperationType {
+	return PNSetMembershipsOperation
+}
+
+func (o *setMembershipsOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNSetMembershipsResponse is the response to the Set Memberships request.
+type PNSetMembershipsResponse struct {
+	Status     int            `json:"status"`
+	Data       []PNMembership `json:"data"`
+	TotalCount int            `json:"totalCount"`
+	Next       string         `json:"next"`
+	Prev       string         `json:"prev"`
+}
+
+func newSetMembershipsResponse(jsonBytes []byte, status StatusResponse) (
+	*PNSetMembershipsResponse, StatusResponse, error) {
+	resp := &PNSetMembershipsResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptySetMembershipsResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,62 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMembershipsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Contains(newSetMembershipsBuilder(pubnub).opts.validate().Error(), "Missing Channel")
+	assert.Contains(newSetMembershipsBuilder(pubnub).Set([]PNMembershipsSet{{}}).opts.validate().Error(), "Missing Channel")
+	assert.Nil(newSetMembershipsBuilder(pubnub).Set([]PNMembershipsSet{{Channel: "ch"}}).opts.validate())
+}
+
+func TestSetMembershipsBuildBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newSetMembershipsBuilderWithContext(pubnub, backgroundContext).Set([]PNMembershipsSet{
+		{Channel: "ch1", Custom: map[string]interface{}{"role": "admin"}},
+		{Channel: "ch2"},
+	})
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"set":[{"channel":{"id":"ch1"},"custom":{"role":"admin"}},{"channel":{"id":"ch2"}}]}`, string(body))
+	assert.Equal("PATCH", o.opts.httpMethod())
+}
+
+func TestSetMembershipsExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "PATCH",
+		Path:               "/v2/objects/demo/uuids/uuid/channels",
+		Query:              "include=custom&limit=100",
+		ResponseBody:       `{"status":200,"data":[{"channel":{"id":"ch1"},"custom":{"role":"admin"}},{"channel":{"id":"ch2"},"custom":null}],"next":"Mg"}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_obj"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.SetMemberships().
+		UUID("uuid").
+		Set([]PNMembershipsSet{
+			{Channel: "ch1", Custom: map[string]interface{}{"role": "admin"}},
+			{Channel: "ch2"},
+		}).
+		IncludeCustom(true).
+		Execute()
+
+	assert.Nil(err)
+	assert.Equal(2, len(res.Data))
+	assert.Equal("admin", res.Data[0].Custom["role"])
+	assert.Equal("ch2", res.Data[1].Channel.ID)
+	assert.Equal("Mg", res.Next)
+}
@@ -66,21 +66,122 @@ func (b *setStateBuilder) QueryParam(queryParam map[string]string) *setStateBuil
 	return b
 }
 
+// Merge as true treats the State as a partial update, which is merged with the last known state
+// of each channel and channel group instead of replacing it. Keys set to nil are removed from the state.
+func (b *setStateBuilder) Merge(merge bool) *setStateBuilder {
+	b.opts.Merge = merge
+
+	return b
+}
+
 // Execute runs the the Set State request and returns the SetStateResponse
 func (b *setStateBuilder) Execute() (*SetStateResponse, StatusResponse, error) {
 	stateOperation := StateOperation{}
 	stateOperation.channels = b.opts.Channels
 	stateOperation.channelGroups = b.opts.ChannelGroups
 	stateOperation.state = b.opts.State
+	stateOperation.merge = b.opts.Merge
 
-	b.opts.pubnub.subscriptionManager.adaptState(stateOperation)
+	// the cache is updated once the server accepted the state
+	channelStates, groupStates := b.opts.pubnub.subscriptionManager.mergedState(stateOperation)
+
+	if b.opts.Merge && b.opts.State != nil {
+		return b.executeMerged(channelStates, groupStates)
+	}
 
 	rawJSON, status, err := executeRequest(b.opts)
 	if err != nil {
 		return emptySetStateResponse, status, err
 	}
 
-	return newSetStateResponse(rawJSON, status)
+	resp, status, err := newSetStateResponse(rawJSON, status)
+	if err != nil {
+		return resp, status, err
+	}
+	b.opts.pubnub.subscriptionManager.setStates(channelStates, groupStates)
+
+	return resp, status, nil
+}
+
+// ExecuteAsync runs the Set State request asynchronously, the result of the Future is a *SetStateResponse.
+func (b *setStateBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type mergedStateBatch struct {
+	state         map[string]interface{}
+	channels      []string
+	channelGroups []string
+}
+
+// executeMerged sends one Set State request per distinct merged state, so
+// channels which had a different state before the update keep their own keys.
+func (b *setStateBuilder) executeMerged(channelStates map[string]map[string]interface{},
+	groupStates map[string]map[string]interface{}) (*SetStateResponse, StatusResponse, error) {
+	if err := b.opts.validate(); err != nil {
+		return emptySetStateResponse, createStatus(PNUnknownCategory, "", ResponseInfo{}, err), err
+	}
+
+	batches := make(map[string]*mergedStateBatch)
+	keys := []string{}
+
+	batchFor := func(state map[string]interface{}) *mergedStateBatch {
+		key, _ := json.Marshal(state)
+		batch, ok := batches[string(key)]
+		if !ok {
+			batch = &mergedStateBatch{state: state}
+			batches[string(key)] = batch
+			keys = append(keys, string(key))
+		}
+		return batch
+	}
+
+	for _, ch := range b.opts.Channels {
+		batch := batchFor(channelStates[ch])
+		batch.channels = append(batch.channels, ch)
+	}
+
+	for _, cg := range b.opts.ChannelGroups {
+		batch := batchFor(groupStates[cg])
+		batch.channelGroups = append(batch.channelGroups, cg)
+	}
+
+	resp := emptySetStateResponse
+	status := StatusResponse{}
+	for _, key := range keys {
+		batch := batches[key]
+		opts := *b.opts
+		opts.State = batch.state
+		opts.Channels = batch.channels
+		opts.ChannelGroups = batch.channelGroups
+
+		rawJSON, batchStatus, err := executeRequest(&opts)
+		if err != nil {
+			return emptySetStateResponse, batchStatus, err
+		}
+
+		resp, status, err = newSetStateResponse(rawJSON, batchStatus)
+		if err != nil {
+			return emptySetStateResponse, status, err
+		}
+		b.opts.pubnub.subscriptionManager.setStates(
+			batchStates(batch.channels, channelStates), batchStates(batch.channelGroups, groupStates))
+	}
+
+	return resp, status, nil
+}
+
+// batchStates returns the states of the names of a batch.
+func batchStates(names []string, states map[string]map[string]interface{}) map[string]map[string]interface{} {
+	selected := make(map[string]map[string]interface{}, len(names))
+	for _, name := range names {
+		selected[name] = states[name]
+	}
+
+	return selected
 }
 
 type setStateOpts struct {
@@ -88,6 +189,7 @@ type setStateOpts struct {
 	Channels      []string
 	ChannelGroups []string
 	QueryParam    map[string]string
+	Merge         bool
 	pubnub        *PubNub
 	stringState   string
 	ctx           Context
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/tests/stubs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -221,3 +222,101 @@ func TestNewSetStateResponseValueError(t *testing.T) {
 	_, _, err := newSetStateResponse([]byte(b), StatusResponse{})
 	assert.Equal("", err.Error())
 }
+
+func TestSetStateMerge(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/presence/sub-key/demo/channel/ch1,ch2/uuid/merge-uuid/data",
+		ResponseBody:       `{"status": 200, "message": "OK", "payload": {"a": 1, "b": 2}, "service": "Presence"}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "state", "l_pres"},
+		ResponseStatusCode: 200,
+	})
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/presence/sub-key/demo/channel/ch1/uuid/merge-uuid/data",
+		ResponseBody:       `{"status": 200, "message": "OK", "payload": {"a": 1, "c": 3}, "service": "Presence"}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "state", "l_pres"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.Config.UUID = "merge-uuid"
+	pn.SetClient(interceptor.GetClient())
+
+	_, _, err := pn.SetState().
+		Channels([]string{"ch1", "ch2"}).
+		State(map[string]interface{}{"a": 1, "b": 2}).
+		Execute()
+	assert.Nil(err)
+
+	res, _, err := pn.SetState().
+		Channels([]string{"ch1"}).
+		State(map[string]interface{}{"b": nil, "c": 3}).
+		Merge(true).
+		Execute()
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"a": float64(1), "c": float64(3)}, res.State)
+
+	state, ok := pn.GetCachedState("ch1")
+	assert.True(ok)
+	assert.Equal(map[string]interface{}{"a": 1, "c": 3}, state)
+
+	state, ok = pn.GetCachedState("ch2")
+	assert.True(ok)
+	assert.Equal(map[string]interface{}{"a": 1, "b": 2}, state)
+
+	_, ok = pn.GetCachedState("ch3")
+	assert.False(ok)
+}
+
+func TestSetStateFailureKeepsCachedState(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/v2/presence/sub-key/demo/channel/ch1/uuid/denied-uuid/data",
+		ResponseBody:       `{"status": 403, "message": "Forbidden", "error": true, "service": "Access Manager"}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "state", "l_pres"},
+		ResponseStatusCode: 403,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.Config.UUID = "denied-uuid"
+	pn.SetClient(interceptor.GetClient())
+	pn.subscriptionManager.stateManager.adaptStateOperation(StateOperation{
+		channels: []string{"ch1"},
+		state:    map[string]interface{}{"a": 1},
+	})
+
+	_, _, err := pn.SetState().
+		Channels([]string{"ch1"}).
+		State(map[string]interface{}{"b": 2}).
+		Execute()
+	assert.NotNil(err)
+
+	_, _, err = pn.SetState().
+		Channels([]string{"ch1"}).
+		State(map[string]interface{}{"a": nil, "c": 3}).
+		Merge(true).
+		Execute()
+	assert.NotNil(err)
+
+	state, ok := pn.GetCachedState("ch1")
+	assert.True(ok)
+	assert.Equal(map[string]interface{}{"a": 1}, state)
+}
+
+func TestSetStateMergeValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	_, _, err := pn.SetState().
+		State(map[string]interface{}{"a": 1}).
+		Merge(true).
+		Execute()
+	assert.Contains(err.Error(), "Missing Channel or Channel Group")
+}
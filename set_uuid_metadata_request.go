@@ -0,0 +1,242 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const setUUIDMetadataPath = "/v2/objects/%s/uuids/%s"
+
+var emptySetUUIDMetadataResponse *PNSetUUIDMetadataResponse
+
+type setUUIDMetadataBuilder struct {
+	opts *setUUIDMetadataOpts
+}
+
+func newSetUUIDMetadataBuilder(pubnub *PubNub) *setUUIDMetadataBuilder {
+	builder := setUUIDMetadataBuilder{
+		opts: &setUUIDMetadataOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newSetUUIDMetadataBuilderWithContext(pubnub *PubNub,
+	context Context) *setUUIDMetadataBuilder {
+	builder := newSetUUIDMetadataBuilder(pubnub)
+	builder.opts.ctx = context
+
+	return builder
+}
+
+// UUID sets the UUID whose metadata is set, the UUID of the config by default.
+func (b *setUUIDMetadataBuilder) UUID(uuid string) *setUUIDMetadataBuilder {
+	b.opts.UUID = uuid
+	return b
+}
+
+// Name sets the display name of the UUID.
+func (b *setUUIDMetadataBuilder) Name(name string) *setUUIDMetadataBuilder {
+	b.opts.Name = name
+	return b
+}
+
+// ExternalID sets the ID of the UUID in an external system.
+func (b *setUUIDMetadataBuilder) ExternalID(externalID string) *setUUIDMetadataBuilder {
+	b.opts.ExternalID = externalID
+	return b
+}
+
+// ProfileURL sets the URL of the profile picture of the UUID.
+func (b *setUUIDMetadataBuilder) ProfileURL(profileURL string) *setUUIDMetadataBuilder {
+	b.opts.ProfileURL = profileURL
+	return b
+}
+
+// Email sets the email address of the UUID.
+func (b *setUUIDMetadataBuilder) Email(email string) *setUUIDMetadataBuilder {
+	b.opts.Email = email
+	return b
+}
+
+// Custom sets the custom fields of the UUID, the values are strings, numbers or booleans.
+func (b *setUUIDMetadataBuilder) Custom(custom map[string]interface{}) *setUUIDMetadataBuilder {
+	b.opts.Custom = custom
+	return b
+}
+
+// IncludeCustom returns the custom fields in the response.
+func (b *setUUIDMetadataBuilder) IncludeCustom(include bool) *setUUIDMetadataBuilder {
+	b.opts.IncludeCustom = include
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *setUUIDMetadataBuilder) QueryParam(queryParam map[string]string) *setUUIDMetadataBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Transport sets the Transport for the Set UUID Metadata request.
+func (b *setUUIDMetadataBuilder) Transport(tr http.RoundTripper) *setUUIDMetadataBuilder {
+	b.opts.Transport = tr
+	return b
+}
+
+// Execute runs the Set UUID Metadata request.
+func (b *setUUIDMetadataBuilder) Execute() (*PNSetUUIDMetadataResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptySetUUIDMetadataResponse, status, err
+	}
+
+	return newSetUUIDMetadataResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Set UUID Metadata request asynchronously, the result of the Future is a *PNSetUUIDMetadataResponse.
+func (b *setUUIDMetadataBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type setUUIDMetadataOpts struct {
+	pubnub *PubNub
+
+	UUID          string
+	Name          string
+	ExternalID    string
+	ProfileURL    string
+	Email         string
+	Custom        map[string]interface{}
+	IncludeCustom bool
+	QueryParam    map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+func (o *setUUIDMetadataOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *setUUIDMetadataOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *setUUIDMetadataOpts) context() Context {
+	return o.ctx
+}
+
+func (o *setUUIDMetadataOpts) validate() error {
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.uuid() == "" {
+		return newValidationError(o, StrMissingUUID)
+	}
+
+	return nil
+}
+
+func (o *setUUIDMetadataOpts) uuid() string {
+	if o.UUID != "" {
+		return o.UUID
+	}
+
+	return o.pubnub.Config.UUID
+}
+
+func (o *setUUIDMetadataOpts) buildPath() (string, error) {
+	return fmt.Sprintf(setUUIDMetadataPath,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.uuid())), nil
+}
+
+func (o *setUUIDMetadataOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	setObjectsInclude(q, map[string]bool{
+		"custom": o.IncludeCustom,
+	})
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *setUUIDMetadataOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *setUUIDMetadataOpts) buildBody() ([]byte, error) {
+	body := map[string]interface{}{}
+	if o.Name != "" {
+		body["name"] = o.Name
+	}
+	if o.ExternalID != "" {
+		body["externalId"] = o.ExternalID
+	}
+	if o.ProfileURL != "" {
+		body["profileUrl"] = o.ProfileURL
+	}
+	if o.Email != "" {
+		body["email"] = o.Email
+	}
+	if o.Custom != nil {
+		body["custom"] = o.Custom
+	}
+
+	return json.Marshal(body)
+}
+
+func (o *setUUIDMetadataOpts) httpMethod() string {
+	return "PATCH"
+}
+
+func (o *setUUIDMetadataOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *setUUIDMetadataOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *setUUIDMetadataOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *setUUIDMetadataOpts) operationType() OperationType {
+	return PNSetUUIDMetadataOperation
+}
+
+func (o *setUUIDMetadataOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
+
+// PNSetUUIDMetadataResponse is the response to the Set UUID Metadata request.
+type PNSetUUIDMetadataResponse struct {
+	Status int    `json:"status"`
+	Data   PNUUID `json:"data"`
+}
+
+func newSetUUIDMetadataResponse(jsonBytes []byte, status StatusResponse) (
+	*PNSetUUIDMetadataResponse, StatusResponse, error) {
+	resp := &PNSetUUIDMetadataResponse{}
+
+	if err := parseObjectsResponse(jsonBytes, resp); err != nil {
+		return emptySetUUIDMetadataResponse, status, err
+	}
+
+	return resp, status, nil
+}
@@ -0,0 +1,91 @@
+package pubnub
+
+import (
+	"net/url"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetUUIDMetadataValidate(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	assert.Nil(newSetUUIDMetadataBuilder(pn).opts.validate())
+
+	pn.Config.SubscribeKey = ""
+	assert.Contains(newSetUUIDMetadataBuilder(pn).opts.validate().Error(), "Missing Subscribe Key")
+}
+
+func TestSetUUIDMetadataBuildPath(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UUID = "config-uuid"
+
+	path, err := newSetUUIDMetadataBuilder(pn).opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/demo/uuids/config-uuid", path)
+
+	path, err = newSetUUIDMetadataBuilder(pn).UUID("my uuid").opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/v2/objects/demo/uuids/my%20uuid", path)
+}
+
+func TestSetUUIDMetadataBuildQueryAndBody(t *testing.T) {
+	assert := assert.New(t)
+
+	o := newSetUUIDMetadataBuilderWithContext(pubnub, backgroundContext).
+		Name("name").
+		Email("uuid@example.com").
+		Custom(map[string]interface{}{"a": "b"}).
+		IncludeCustom(true).
+		QueryParam(map[string]string{"q1": "v1"})
+
+	query, err := o.opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("include", "custom")
+	expected.Set("q1", "v1")
+	h.AssertQueriesEqual(t, expected, query, []string{"pnsdk", "uuid"}, []string{})
+
+	body, err := o.opts.buildBody()
+	assert.Nil(err)
+	assert.Equal(`{"custom":{"a":"b"},"email":"uuid@example.com","name":"name"}`, string(body))
+	assert.Equal("PATCH", o.opts.httpMethod())
+}
+
+func TestSetUUIDMetadataExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "PATCH",
+		Path:               "/v2/objects/demo/uuids/uuid",
+		Query:              "include=custom",
+		ResponseBody:       `{"status":200,"data":{"id":"uuid","name":"name","externalId":null,"profileUrl":null,"email":"uuid@example.com","custom":{"a":"b"},"updated":"2020-06-17T16:28:14.060718Z","eTag":"AbO7ys6S2pH8cQ"}}`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_obj"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.SetUUIDMetadata().
+		UUID("uuid").
+		Name("name").
+		Email("uuid@example.com").
+		Custom(map[string]interface{}{"a": "b"}).
+		IncludeCustom(true).
+		Execute()
+
+	assert.Nil(err)
+	assert.Equal(200, res.Status)
+	assert.Equal("uuid", res.Data.ID)
+	assert.Equal("name", res.Data.Name)
+	assert.Equal("", res.Data.ExternalID)
+	assert.Equal("b", res.Data.Custom["a"])
+	assert.Equal("AbO7ys6S2pH8cQ", res.Data.ETag)
+}
@@ -0,0 +1,211 @@
+package pubnub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pubnub/go/utils"
+)
+
+const signalPath = "/signal/%s/%s/0/%s/0/%s"
+
+// maxSignalSize is the max size in bytes of the serialized message of a Signal.
+const maxSignalSize = 64
+
+var emptySignalResponse *SignalResponse
+
+type signalBuilder struct {
+	opts *signalOpts
+}
+
+func newSignalBuilder(pubnub *PubNub) *signalBuilder {
+	builder := signalBuilder{
+		opts: &signalOpts{
+			pubnub: pubnub,
+		},
+	}
+
+	return &builder
+}
+
+func newSignalBuilderWithContext(pubnub *PubNub, context Context) *signalBuilder {
+	builder := signalBuilder{
+		opts: &signalOpts{
+			pubnub: pubnub,
+			ctx:    context,
+		},
+	}
+
+	return &builder
+}
+
+// Channel sets the Channel for the Signal request.
+func (b *signalBuilder) Channel(ch string) *signalBuilder {
+	b.opts.Channel = ch
+
+	return b
+}
+
+// Message sets the Payload for the Signal request, at most 64 bytes once serialized.
+func (b *signalBuilder) Message(msg interface{}) *signalBuilder {
+	b.opts.Message = msg
+
+	return b
+}
+
+// Transport sets the Transport for the Signal request.
+func (b *signalBuilder) Transport(tr http.RoundTripper) *signalBuilder {
+	b.opts.Transport = tr
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *signalBuilder) QueryParam(queryParam map[string]string) *signalBuilder {
+	b.opts.QueryParam = queryParam
+
+	return b
+}
+
+// Execute runs the Signal request.
+func (b *signalBuilder) Execute() (*SignalResponse, StatusResponse, error) {
+	rawJSON, status, err := executeRequest(b.opts)
+	if err != nil {
+		return emptySignalResponse, status, err
+	}
+
+	return newSignalResponse(rawJSON, status)
+}
+
+// ExecuteAsync runs the Signal request asynchronously, the result of the Future is a *SignalResponse.
+func (b *signalBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
+type signalOpts struct {
+	pubnub *PubNub
+
+	Channel    string
+	Message    interface{}
+	QueryParam map[string]string
+
+	Transport http.RoundTripper
+
+	ctx Context
+}
+
+// SignalResponse is the response of the Signal request.
+type SignalResponse struct {
+	Timestamp int64
+}
+
+func newSignalResponse(jsonBytes []byte, status StatusResponse) (
+	*SignalResponse, StatusResponse, error) {
+	resp, status, err := newPublishResponse(jsonBytes, status)
+	if err != nil {
+		return emptySignalResponse, status, err
+	}
+
+	return &SignalResponse{
+		Timestamp: resp.Timestamp,
+	}, status, nil
+}
+
+func (o *signalOpts) config() Config {
+	return *o.pubnub.Config
+}
+
+func (o *signalOpts) client() *http.Client {
+	return o.pubnub.GetClient()
+}
+
+func (o *signalOpts) context() Context {
+	return o.ctx
+}
+
+func (o *signalOpts) validate() error {
+	if o.config().PublishKey == "" {
+		return newValidationError(o, StrMissingPubKey)
+	}
+
+	if o.config().SubscribeKey == "" {
+		return newValidationError(o, StrMissingSubKey)
+	}
+
+	if o.Channel == "" {
+		return newValidationError(o, StrMissingChannel)
+	}
+
+	if o.Message == nil {
+		return newValidationError(o, StrMissingMessage)
+	}
+
+	message, err := json.Marshal(o.Message)
+	if err != nil {
+		return newValidationError(o, err.Error())
+	}
+
+	if len(message) > maxSignalSize {
+		return newValidationError(o, fmt.Sprintf("Signal message size is %d bytes, the max size is %d bytes", len(message), maxSignalSize))
+	}
+
+	return nil
+}
+
+func (o *signalOpts) buildPath() (string, error) {
+	message, err := json.Marshal(o.Message)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(signalPath,
+		o.pubnub.Config.PublishKey,
+		o.pubnub.Config.SubscribeKey,
+		utils.URLEncode(o.Channel),
+		utils.URLEncode(string(message))), nil
+}
+
+func (o *signalOpts) buildQuery() (*url.Values, error) {
+	q := defaultQuery(o.pubnub.Config.UUID, o.pubnub.telemetryManager)
+
+	SetQueryParam(q, o.QueryParam)
+
+	return q, nil
+}
+
+func (o *signalOpts) jobQueue() chan *JobQItem {
+	return o.pubnub.jobQueue
+}
+
+func (o *signalOpts) buildBody() ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (o *signalOpts) httpMethod() string {
+	return "GET"
+}
+
+func (o *signalOpts) isAuthRequired() bool {
+	return true
+}
+
+func (o *signalOpts) requestTimeout() int {
+	return o.pubnub.Config.NonSubscribeRequestTimeout
+}
+
+func (o *signalOpts) connectTimeout() int {
+	return o.pubnub.Config.ConnectTimeout
+}
+
+func (o *signalOpts) operationType() OperationType {
+	return PNSignalOperation
+}
+
+func (o *signalOpts) telemetryManager() *TelemetryManager {
+	return o.pubnub.telemetryManager
+}
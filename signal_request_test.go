@@ -0,0 +1,97 @@
+package pubnub
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	h "github.com/pubnub/go/tests/helpers"
+	"github.com/pubnub/go/tests/stubs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignalOptsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &signalOpts{
+		Channel: "ch",
+		Message: map[string]interface{}{"typing": true},
+		pubnub:  pubnub,
+	}
+	assert.Nil(opts.validate())
+
+	opts.Message = nil
+	assert.Contains(opts.validate().Error(), "Missing Message")
+
+	opts.Message = "ok"
+	opts.Channel = ""
+	assert.Contains(opts.validate().Error(), "Missing Channel")
+}
+
+func TestSignalOptsValidateSize(t *testing.T) {
+	assert := assert.New(t)
+
+	// 62 characters and the quotes
+	opts := &signalOpts{
+		Channel: "ch",
+		Message: strings.Repeat("a", 62),
+		pubnub:  pubnub,
+	}
+	assert.Nil(opts.validate())
+
+	opts.Message = strings.Repeat("a", 63)
+	assert.Contains(opts.validate().Error(), "Signal message size is 65 bytes, the max size is 64 bytes")
+}
+
+func TestSignalOptsBuildPath(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &signalOpts{
+		Channel: "ch",
+		Message: map[string]interface{}{"typing": true},
+		pubnub:  pubnub,
+	}
+
+	path, err := opts.buildPath()
+	assert.Nil(err)
+	assert.Equal("/signal/pub_key/sub_key/0/ch/0/%7B%22typing%22%3Atrue%7D", path)
+}
+
+func TestSignalOptsBuildQuery(t *testing.T) {
+	assert := assert.New(t)
+
+	opts := &signalOpts{
+		Channel:    "ch",
+		Message:    "typing",
+		QueryParam: map[string]string{"q1": "v1"},
+		pubnub:     pubnub,
+	}
+
+	query, err := opts.buildQuery()
+	assert.Nil(err)
+
+	expected := &url.Values{}
+	expected.Set("q1", "v1")
+	h.AssertQueriesEqual(t, expected, query, []string{"pnsdk", "uuid"}, []string{})
+}
+
+func TestSignalExecute(t *testing.T) {
+	assert := assert.New(t)
+	interceptor := stubs.NewInterceptor()
+	interceptor.AddStub(&stubs.Stub{
+		Method:             "GET",
+		Path:               "/signal/demo/demo/0/ch/0/%22typing%22",
+		Query:              "",
+		ResponseBody:       `[1,"Sent","15610547826970050"]`,
+		IgnoreQueryKeys:    []string{"pnsdk", "uuid", "l_sig"},
+		ResponseStatusCode: 200,
+	})
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.SecretKey = ""
+	pn.SetClient(interceptor.GetClient())
+
+	res, _, err := pn.Signal().Channel("ch").Message("typing").Execute()
+	assert.Nil(err)
+	assert.Equal(int64(15610547826970050), res.Timestamp)
+}
@@ -1,9 +1,10 @@
 package pubnub
 
 import (
-	"fmt"
-	"strings"
+	"encoding/json"
 	"sync"
+
+	"github.com/pubnub/go/utils"
 )
 
 // StateManager is used to store the subscriptions types
@@ -14,6 +15,11 @@ type StateManager struct {
 	groups           map[string]*SubscriptionItem
 	presenceChannels map[string]*SubscriptionItem
 	presenceGroups   map[string]*SubscriptionItem
+
+	// Last known state of the client UUID per channel and channel group,
+	// replayed with every subscribe and heartbeat request.
+	channelStates map[string]map[string]interface{}
+	groupStates   map[string]map[string]interface{}
 }
 
 // SubscriptionItem is used to store the subscription item's properties.
@@ -28,6 +34,8 @@ func newStateManager() *StateManager {
 		presenceChannels: make(map[string]*SubscriptionItem),
 		groups:           make(map[string]*SubscriptionItem),
 		presenceGroups:   make(map[string]*SubscriptionItem),
+		channelStates:    make(map[string]map[string]interface{}),
+		groupStates:      make(map[string]map[string]interface{}),
 	}
 }
 
@@ -68,7 +76,8 @@ func (m *StateManager) adaptSubscribeOperation(
 	m.Lock()
 
 	for _, ch := range subscribeOperation.Channels {
-		if strings.Contains(ch, "-pnpres") {
+		if utils.IsPresenceChannel(ch) {
+			ch = utils.ChannelFromPresence(ch)
 			if len(subscribeOperation.State) > 0 {
 				m.presenceChannels[ch] = newSubscriptionItemWithState(ch, subscribeOperation.State)
 			} else {
@@ -76,7 +85,10 @@ func (m *StateManager) adaptSubscribeOperation(
 			}
 		} else {
 			if len(subscribeOperation.State) > 0 {
-				m.channels[ch] = newSubscriptionItemWithState(ch, subscribeOperation.State)
+				m.channelStates[ch] = mergeState(m.channelStates[ch], subscribeOperation.State)
+			}
+			if state, ok := m.channelStates[ch]; ok {
+				m.channels[ch] = newSubscriptionItemWithState(ch, state)
 			} else {
 				m.channels[ch] = newSubscriptionItem(ch)
 			}
@@ -89,7 +101,8 @@ func (m *StateManager) adaptSubscribeOperation(
 	}
 
 	for _, cg := range subscribeOperation.ChannelGroups {
-		if strings.Contains(cg, "-pnpres") {
+		if utils.IsPresenceChannel(cg) {
+			cg = utils.ChannelFromPresence(cg)
 			if len(subscribeOperation.State) > 0 {
 				m.presenceGroups[cg] = newSubscriptionItemWithState(cg, subscribeOperation.State)
 			} else {
@@ -97,7 +110,10 @@ func (m *StateManager) adaptSubscribeOperation(
 			}
 		} else {
 			if len(subscribeOperation.State) > 0 {
-				m.groups[cg] = newSubscriptionItemWithState(cg, subscribeOperation.State)
+				m.groupStates[cg] = mergeState(m.groupStates[cg], subscribeOperation.State)
+			}
+			if state, ok := m.groupStates[cg]; ok {
+				m.groups[cg] = newSubscriptionItemWithState(cg, state)
 			} else {
 				m.groups[cg] = newSubscriptionItem(cg)
 			}
@@ -110,45 +126,146 @@ func (m *StateManager) adaptSubscribeOperation(
 	m.Unlock()
 }
 
-func (m *StateManager) adaptStateOperation(stateOperation StateOperation) {
-	m.Lock()
+// adaptStateOperation stores the state in the cache and returns the
+// resulting state of each channel and channel group of the operation.
+func (m *StateManager) adaptStateOperation(stateOperation StateOperation) (
+	map[string]map[string]interface{}, map[string]map[string]interface{}) {
+	channelStates, groupStates := m.mergedStateOperation(stateOperation)
+	m.setStates(channelStates, groupStates)
+
+	return channelStates, groupStates
+}
+
+// mergedStateOperation returns the resulting state of each channel and
+// channel group of the operation without storing it in the cache.
+func (m *StateManager) mergedStateOperation(stateOperation StateOperation) (
+	map[string]map[string]interface{}, map[string]map[string]interface{}) {
+	m.RLock()
+	defer m.RUnlock()
+
+	channelStates := make(map[string]map[string]interface{})
+	groupStates := make(map[string]map[string]interface{})
 
 	for _, ch := range stateOperation.channels {
-		if _, ok := m.channels[ch]; ok {
-			subscribedChannel := m.channels[ch]
+		if stateOperation.merge {
+			channelStates[ch] = mergeState(m.channelStates[ch], stateOperation.state)
+		} else {
+			channelStates[ch] = mergeState(nil, stateOperation.state)
+		}
+	}
 
+	for _, cg := range stateOperation.channelGroups {
+		if stateOperation.merge {
+			groupStates[cg] = mergeState(m.groupStates[cg], stateOperation.state)
+		} else {
+			groupStates[cg] = mergeState(nil, stateOperation.state)
+		}
+	}
+
+	return channelStates, groupStates
+}
+
+// setStates stores the states of the channels and channel groups in the cache.
+func (m *StateManager) setStates(channelStates map[string]map[string]interface{},
+	groupStates map[string]map[string]interface{}) {
+	m.Lock()
+	defer m.Unlock()
+
+	for ch, state := range channelStates {
+		m.channelStates[ch] = mergeState(nil, state)
+
+		if subscribedChannel, ok := m.channels[ch]; ok {
 			if subscribedChannel.name != "" {
-				subscribedChannel.state = stateOperation.state
+				subscribedChannel.state = m.channelStates[ch]
 			}
 		}
 	}
 
-	for _, cg := range stateOperation.channelGroups {
-		if _, ok := m.groups[cg]; ok {
-			subscribedChannelGroup := m.groups[cg]
+	for cg, state := range groupStates {
+		m.groupStates[cg] = mergeState(nil, state)
 
+		if subscribedChannelGroup, ok := m.groups[cg]; ok {
 			if subscribedChannelGroup.name != "" {
-				subscribedChannelGroup.state = stateOperation.state
+				subscribedChannelGroup.state = m.groupStates[cg]
 			}
 		}
 	}
+}
+
+// updateChannelState replaces the cached state of a channel with the state
+// received from the server.
+func (m *StateManager) updateChannelState(channel string, state map[string]interface{}) {
+	m.Lock()
+	m.channelStates[channel] = mergeState(nil, state)
+	if subscribedChannel, ok := m.channels[channel]; ok {
+		subscribedChannel.state = m.channelStates[channel]
+	}
 	m.Unlock()
 }
 
+func (m *StateManager) getChannelState(channel string) (map[string]interface{}, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	state, ok := m.channelStates[channel]
+	return mergeState(nil, state), ok
+}
+
+func (m *StateManager) getGroupState(group string) (map[string]interface{}, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	state, ok := m.groupStates[group]
+	return mergeState(nil, state), ok
+}
+
+func (m *StateManager) clearStates() {
+	m.Lock()
+	m.channelStates = make(map[string]map[string]interface{})
+	m.groupStates = make(map[string]map[string]interface{})
+	for _, ch := range m.channels {
+		ch.state = make(map[string]interface{})
+	}
+	for _, cg := range m.groups {
+		cg.state = make(map[string]interface{})
+	}
+	m.Unlock()
+}
+
+// mergeState returns a copy of the base state with the keys of the update
+// applied on top of it. Keys with a nil value are removed from the result.
+func mergeState(base map[string]interface{}, update map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(update))
+
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, v := range update {
+		if v == nil {
+			delete(merged, k)
+		} else {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
 func (m *StateManager) adaptUnsubscribeOperation(unsubscribeOperation *UnsubscribeOperation) {
 	m.Lock()
 
 	for _, ch := range unsubscribeOperation.Channels {
-		if strings.Contains(ch, "-pnpres") {
-			delete(m.presenceChannels, strings.Replace(ch, "-pnpres", "", -1))
+		if utils.IsPresenceChannel(ch) {
+			delete(m.presenceChannels, utils.ChannelFromPresence(ch))
 		} else {
 			delete(m.channels, ch)
 		}
 	}
 
 	for _, cg := range unsubscribeOperation.ChannelGroups {
-		if strings.Contains(cg, "-pnpres") {
-			delete(m.presenceGroups, strings.Replace(cg, "-pnpres", "", -1))
+		if utils.IsPresenceChannel(cg) {
+			delete(m.presenceGroups, utils.ChannelFromPresence(cg))
 		} else {
 			delete(m.groups, cg)
 		}
@@ -205,14 +322,39 @@ func (m *StateManager) prepareMembershipList(dataStorage map[string]*Subscriptio
 
 	if includePresence {
 		for _, v := range presenceStorage {
-			if !strings.Contains(v.name, "-pnpres") {
-				response = append(response, fmt.Sprintf("%s-pnpres", v.name))
-			} else {
-				response = append(response, v.name)
-			}
+			response = append(response, utils.PresenceChannel(v.name))
 		}
 	}
 	m.Unlock()
 
 	return response
 }
+
+// stateAsMap converts a struct or a map to the JSON object used as state.
+func stateAsMap(state interface{}) (map[string]interface{}, error) {
+	if m, ok := state.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// decodeState decodes a state received from the server into v.
+func decodeState(state interface{}, v interface{}) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
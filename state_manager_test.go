@@ -0,0 +1,64 @@
+package pubnub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeState(t *testing.T) {
+	assert := assert.New(t)
+
+	base := map[string]interface{}{"a": 1, "b": 2}
+	merged := mergeState(base, map[string]interface{}{"b": nil, "c": 3})
+
+	assert.Equal(map[string]interface{}{"a": 1, "c": 3}, merged)
+	assert.Equal(map[string]interface{}{"a": 1, "b": 2}, base)
+}
+
+func TestStateManagerSubscribeMergesCachedState(t *testing.T) {
+	assert := assert.New(t)
+	m := newStateManager()
+
+	m.adaptStateOperation(StateOperation{
+		channels: []string{"ch1"},
+		state:    map[string]interface{}{"a": 1},
+	})
+
+	m.adaptSubscribeOperation(&SubscribeOperation{
+		Channels: []string{"ch1", "ch2"},
+		State:    map[string]interface{}{"b": 2},
+	})
+
+	payload := m.createStatePayload()
+	assert.Equal(map[string]interface{}{"a": 1, "b": 2}, payload["ch1"])
+	assert.Equal(map[string]interface{}{"b": 2}, payload["ch2"])
+}
+
+func TestStateManagerResubscribeReplaysState(t *testing.T) {
+	assert := assert.New(t)
+	m := newStateManager()
+
+	m.adaptSubscribeOperation(&SubscribeOperation{
+		Channels:      []string{"ch1"},
+		ChannelGroups: []string{"cg1"},
+		State:         map[string]interface{}{"a": 1},
+	})
+	m.adaptUnsubscribeOperation(&UnsubscribeOperation{
+		Channels:      []string{"ch1"},
+		ChannelGroups: []string{"cg1"},
+	})
+	assert.Equal(0, len(m.createStatePayload()))
+
+	m.adaptSubscribeOperation(&SubscribeOperation{
+		Channels:      []string{"ch1"},
+		ChannelGroups: []string{"cg1"},
+	})
+
+	payload := m.createStatePayload()
+	assert.Equal(map[string]interface{}{"a": 1}, payload["ch1"])
+	assert.Equal(map[string]interface{}{"a": 1}, payload["cg1"])
+
+	m.clearStates()
+	assert.Equal(0, len(m.createStatePayload()))
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/pubnub/go/pnerr"
 	"github.com/pubnub/go/utils"
 )
 
@@ -94,10 +95,69 @@ func (b *subscribeBuilder) QueryParam(queryParam map[string]string) *subscribeBu
 }
 
 // Execute runs the Subscribe operation.
+// If a channel breaks the wildcard rules or the filter expression is invalid
+// a PNBadRequestCategory status is announced instead.
 func (b *subscribeBuilder) Execute() {
+	err := validateSubscribeChannels(b.operation.Channels, b.operation.ChannelGroups)
+	if err == nil {
+		filterExpression := b.operation.FilterExpression
+		if filterExpression == "" {
+			filterExpression = b.opts.pubnub.Config.FilterExpression
+		}
+		err = validateFilterExpression(filterExpression)
+	}
+
+	if err != nil {
+		pnStatus := &PNStatus{
+			Category:              PNBadRequestCategory,
+			Operation:             PNSubscribeOperation,
+			Error:                 true,
+			ErrorData:             err,
+			AffectedChannels:      b.operation.Channels,
+			AffectedChannelGroups: b.operation.ChannelGroups,
+		}
+		b.opts.pubnub.Config.Log.Println("Subscribe: err", err, pnStatus)
+		b.opts.pubnub.subscriptionManager.listenerManager.announceStatus(pnStatus)
+
+		return
+	}
+
+	b.opts.pubnub.subscriptionManager.addDirectSubscribe(b.operation)
 	b.opts.pubnub.subscriptionManager.adaptSubscribe(b.operation)
 }
 
+// validateSubscribeChannels checks the wildcard rules of the channels,
+// channel groups can't contain a wildcard.
+func validateSubscribeChannels(channels []string, groups []string) error {
+	for _, ch := range channels {
+		if err := utils.ValidateWildcardChannel(ch); err != nil {
+			return pnerr.NewValidationError(PNSubscribeOperation.String(), err.Error())
+		}
+	}
+
+	for _, cg := range groups {
+		if utils.IsWildcardChannel(cg) {
+			return pnerr.NewValidationError(PNSubscribeOperation.String(),
+				fmt.Sprintf("Invalid channel group %s: channel groups can't contain a wildcard", cg))
+		}
+	}
+
+	return nil
+}
+
+// validateFilterExpression parses the filter expression, empty expressions are valid.
+func validateFilterExpression(expr string) error {
+	if expr == "" {
+		return nil
+	}
+
+	if err := utils.ValidateFilterExpression(expr); err != nil {
+		return pnerr.NewValidationError(PNSubscribeOperation.String(), err.Error())
+	}
+
+	return nil
+}
+
 func (o *subscribeOpts) config() Config {
 	return *o.pubnub.Config
 }
@@ -123,6 +183,14 @@ func (o *subscribeOpts) validate() error {
 		return newValidationError(o, StrMissingChannel)
 	}
 
+	if err := validateSubscribeChannels(o.Channels, o.ChannelGroups); err != nil {
+		return err
+	}
+
+	if err := validateFilterExpression(o.FilterExpression); err != nil {
+		return err
+	}
+
 	if o.State != nil {
 		state, err := json.Marshal(o.State)
 		if err != nil {
@@ -194,3 +194,85 @@ func TestSubscribeValidateState(t *testing.T) {
 
 	assert.Nil(opts.validate())
 }
+
+func TestSubscribeValidateWildcard(t *testing.T) {
+	assert := assert.New(t)
+	opts := &subscribeOpts{
+		Channels: []string{"a.*.c"},
+		pubnub:   pubnub,
+	}
+
+	err := opts.validate()
+	assert.Contains(err.Error(), "Invalid wildcard channel a.*.c")
+
+	opts = &subscribeOpts{
+		Channels:      []string{"a.b.*"},
+		ChannelGroups: []string{"cg.*"},
+		pubnub:        pubnub,
+	}
+
+	err = opts.validate()
+	assert.Contains(err.Error(), "Invalid channel group cg.*")
+
+	opts = &subscribeOpts{
+		Channels: []string{"a.b.*", "a.*-pnpres"},
+		pubnub:   pubnub,
+	}
+
+	assert.Nil(opts.validate())
+}
+
+func TestSubscribeWildcardAnnouncesBadRequest(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"a.b.c.*"}).Execute()
+
+	status := <-listener.Status
+	assert.Equal(PNBadRequestCategory, status.Category)
+	assert.True(status.Error)
+	assert.Equal([]string{"a.b.c.*"}, status.AffectedChannels)
+	assert.Equal(0, len(pn.GetSubscribedChannels()))
+}
+
+func TestSubscribeInvalidFilterAnnouncesBadRequest(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	pn.Subscribe().Channels([]string{"ch"}).FilterExpression("meta.a = 1").Execute()
+
+	status := <-listener.Status
+	assert.Equal(PNBadRequestCategory, status.Category)
+	assert.Contains(status.ErrorData.Error(), "Invalid filter expression")
+	assert.Equal(0, len(pn.GetSubscribedChannels()))
+
+	opts := &subscribeOpts{
+		Channels:         []string{"ch"},
+		FilterExpression: "(meta.a == 1",
+		pubnub:           pubnub,
+	}
+	assert.Contains(opts.validate().Error(), "Invalid filter expression")
+}
+
+func TestSubscribeWildcardWithPresence(t *testing.T) {
+	assert := assert.New(t)
+	m := newStateManager()
+
+	m.adaptSubscribeOperation(&SubscribeOperation{
+		Channels:        []string{"a.*"},
+		PresenceEnabled: true,
+	})
+
+	assert.Equal([]string{"a.*"}, m.prepareChannelList(false))
+	assert.ElementsMatch([]string{"a.*", "a.*-pnpres"}, m.prepareChannelList(true))
+
+	m.adaptUnsubscribeOperation(&UnsubscribeOperation{
+		Channels: []string{"a.*-pnpres"},
+	})
+
+	assert.Equal([]string{"a.*"}, m.prepareChannelList(true))
+}
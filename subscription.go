@@ -0,0 +1,364 @@
+package pubnub
+
+import (
+	"sync"
+
+	"github.com/pubnub/go/pnerr"
+	"github.com/pubnub/go/utils"
+)
+
+// Subscription is a handle on a set of channels and channel groups with its
+// own listener and filter. All the subscriptions of a PubNub instance share
+// the same subscribe loop: when their filter expressions differ the loop
+// requests the messages matching any of them and each subscription evaluates
+// its own filter expression on the messages of its channels.
+type Subscription struct {
+	sync.RWMutex
+
+	pubnub *PubNub
+
+	channels         []string
+	channelGroups    []string
+	presenceEnabled  bool
+	filterExpression string
+	parsedFilter     *utils.FilterExpression
+	filter           func(*PNMessage) bool
+	listener         *Listener
+	signalListener   *SignalListener
+	objectsListener  *ObjectsListener
+	actionsListener  *MessageActionsListener
+	queryParam       map[string]string
+
+	active bool
+}
+
+type subscriptionBuilder struct {
+	subscription *Subscription
+	operation    *SubscribeOperation
+}
+
+func newSubscriptionBuilder(pubnub *PubNub) *subscriptionBuilder {
+	builder := subscriptionBuilder{
+		subscription: &Subscription{
+			pubnub: pubnub,
+		},
+		operation: &SubscribeOperation{},
+	}
+
+	return &builder
+}
+
+// Channels sets the channels of the subscription.
+func (b *subscriptionBuilder) Channels(channels []string) *subscriptionBuilder {
+	b.operation.Channels = channels
+
+	return b
+}
+
+// ChannelGroups sets the channel groups of the subscription.
+func (b *subscriptionBuilder) ChannelGroups(groups []string) *subscriptionBuilder {
+	b.operation.ChannelGroups = groups
+
+	return b
+}
+
+// Timetoken sets the timetoken to subscribe. Subscribe will start to fetch the messages from this timetoken onwards.
+func (b *subscriptionBuilder) Timetoken(tt int64) *subscriptionBuilder {
+	b.operation.Timetoken = tt
+
+	return b
+}
+
+// WithPresence as true subscribes to the presence channels as well.
+func (b *subscriptionBuilder) WithPresence(pres bool) *subscriptionBuilder {
+	b.operation.PresenceEnabled = pres
+
+	return b
+}
+
+// State sets the state of the channels while subscribing.
+func (b *subscriptionBuilder) State(state map[string]interface{}) *subscriptionBuilder {
+	b.operation.State = state
+
+	return b
+}
+
+// QueryParam accepts a map, the keys and values of the map are passed as the query string parameters of the URL called by the API.
+func (b *subscriptionBuilder) QueryParam(queryParam map[string]string) *subscriptionBuilder {
+	b.operation.QueryParam = queryParam
+
+	return b
+}
+
+// FilterExpression sets the filter expression of the subscription.
+func (b *subscriptionBuilder) FilterExpression(expr string) *subscriptionBuilder {
+	b.subscription.filterExpression = expr
+
+	return b
+}
+
+// Filter sets a function evaluated on every message of the subscription,
+// only the messages for which it returns true are announced.
+func (b *subscriptionBuilder) Filter(filter func(*PNMessage) bool) *subscriptionBuilder {
+	b.subscription.filter = filter
+
+	return b
+}
+
+// Listener sets the listener which receives the messages and presence events of the subscription.
+// A new listener is created when none is set.
+func (b *subscriptionBuilder) Listener(listener *Listener) *subscriptionBuilder {
+	b.subscription.listener = listener
+
+	return b
+}
+
+// SignalListener sets the listener which receives the signals of the
+// subscription, the signals are not announced when none is set.
+func (b *subscriptionBuilder) SignalListener(listener *SignalListener) *subscriptionBuilder {
+	b.subscription.signalListener = listener
+
+	return b
+}
+
+// ObjectsListener sets the listener which receives the objects events of the
+// subscription, the events are not announced when none is set.
+func (b *subscriptionBuilder) ObjectsListener(listener *ObjectsListener) *subscriptionBuilder {
+	b.subscription.objectsListener = listener
+
+	return b
+}
+
+// MessageActionsListener sets the listener which receives the message actions
+// of the subscription, the actions are not announced when none is set.
+func (b *subscriptionBuilder) MessageActionsListener(listener *MessageActionsListener) *subscriptionBuilder {
+	b.subscription.actionsListener = listener
+
+	return b
+}
+
+// Execute validates the subscription and adds its channels to the subscribe loop.
+func (b *subscriptionBuilder) Execute() (*Subscription, error) {
+	if len(b.operation.Channels) == 0 && len(b.operation.ChannelGroups) == 0 {
+		return nil, pnerr.NewValidationError(PNSubscribeOperation.String(), StrMissingChannel)
+	}
+
+	if err := validateSubscribeChannels(b.operation.Channels, b.operation.ChannelGroups); err != nil {
+		return nil, err
+	}
+
+	s := b.subscription
+	if s.filterExpression != "" {
+		parsedFilter, err := utils.ParseFilterExpression(s.filterExpression)
+		if err != nil {
+			return nil, pnerr.NewValidationError(PNSubscribeOperation.String(), err.Error())
+		}
+		s.parsedFilter = parsedFilter
+	}
+
+	s.channels = b.operation.Channels
+	s.channelGroups = b.operation.ChannelGroups
+	s.presenceEnabled = b.operation.PresenceEnabled
+	s.queryParam = b.operation.QueryParam
+	s.active = true
+	if s.listener == nil {
+		s.listener = NewListener()
+	}
+
+	s.pubnub.subscriptionManager.addSubscription(s)
+	s.pubnub.subscriptionManager.adaptSubscribe(b.operation)
+
+	return s, nil
+}
+
+// Listener returns the listener of the subscription.
+func (s *Subscription) Listener() *Listener {
+	return s.listener
+}
+
+// SignalListener returns the signal listener of the subscription.
+func (s *Subscription) SignalListener() *SignalListener {
+	return s.signalListener
+}
+
+// ObjectsListener returns the objects listener of the subscription.
+func (s *Subscription) ObjectsListener() *ObjectsListener {
+	return s.objectsListener
+}
+
+// MessageActionsListener returns the message actions listener of the subscription.
+func (s *Subscription) MessageActionsListener() *MessageActionsListener {
+	return s.actionsListener
+}
+
+// Channels returns the channels of the subscription.
+func (s *Subscription) Channels() []string {
+	return s.channels
+}
+
+// ChannelGroups returns the channel groups of the subscription.
+func (s *Subscription) ChannelGroups() []string {
+	return s.channelGroups
+}
+
+// FilterExpression returns the filter expression of the subscription.
+func (s *Subscription) FilterExpression() string {
+	return s.filterExpression
+}
+
+// IsActive returns true until the subscription is unsubscribed.
+func (s *Subscription) IsActive() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.active
+}
+
+// Unsubscribe removes the subscription. Its channels and channel groups are
+// unsubscribed unless another subscription still uses them.
+func (s *Subscription) Unsubscribe() {
+	s.Lock()
+	if !s.active {
+		s.Unlock()
+		return
+	}
+	s.active = false
+	s.Unlock()
+
+	s.pubnub.subscriptionManager.removeSubscription(s)
+}
+
+func (s *Subscription) subscribedChannels() []string {
+	response := []string{}
+
+	for _, ch := range s.channels {
+		response = append(response, ch)
+		if s.presenceEnabled && !utils.IsPresenceChannel(ch) {
+			response = append(response, utils.PresenceChannel(ch))
+		}
+	}
+	return response
+}
+
+func (s *Subscription) subscribedGroups() []string {
+	response := []string{}
+
+	for _, cg := range s.channelGroups {
+		response = append(response, cg)
+		if s.presenceEnabled && !utils.IsPresenceChannel(cg) {
+			response = append(response, utils.PresenceChannel(cg))
+		}
+	}
+	return response
+}
+
+// matches returns true if the channel, received with the subscription match,
+// belongs to the subscription. Both are the presence channels for presence events.
+func (s *Subscription) matches(channel string, subscriptionMatch string) bool {
+	for _, ch := range s.subscribedChannels() {
+		if ch == channel || ch == subscriptionMatch || utils.MatchChannel(ch, channel) {
+			return true
+		}
+	}
+
+	if subscriptionMatch == "" {
+		return false
+	}
+
+	for _, cg := range s.subscribedGroups() {
+		if cg == subscriptionMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// accepts returns true if the message belongs to the subscription and passes
+// its filters. The filter expression is only evaluated when the subscribe loop
+// used a different one.
+func (s *Subscription) accepts(message *PNMessage, loopFilterExpression string) bool {
+	if !s.matches(message.Channel, message.Subscription) {
+		return false
+	}
+
+	if s.parsedFilter != nil && s.filterExpression != loopFilterExpression &&
+		!s.parsedFilter.Evaluate(message.UserMetadata, message.Publisher) {
+		return false
+	}
+
+	if s.filter != nil && !s.filter(message) {
+		return false
+	}
+
+	return true
+}
+
+func (s *Subscription) announceMessage(message *PNMessage, exit chan bool) {
+	go func() {
+		select {
+		case <-exit:
+		case s.listener.Message <- message:
+		}
+	}()
+}
+
+func (s *Subscription) announceSignal(message *PNMessage, exit chan bool) {
+	if s.signalListener == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-exit:
+		case s.signalListener.Signal <- message:
+		}
+	}()
+}
+
+func (s *Subscription) announceObjects(event *PNObjectsEvent, exit chan bool) {
+	if s.objectsListener == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-exit:
+		case s.objectsListener.Objects <- event:
+		}
+	}()
+}
+
+func (s *Subscription) announceMessageActionsEvent(event *PNMessageActionsEvent, exit chan bool) {
+	if s.actionsListener == nil {
+		return
+	}
+
+	go func() {
+		select {
+		case <-exit:
+		case s.actionsListener.MessageActionsEvent <- event:
+		}
+	}()
+}
+
+func (s *Subscription) announcePresence(presence *PNPresence, exit chan bool) {
+	go func() {
+		select {
+		case <-exit:
+		case s.listener.Presence <- presence:
+		}
+	}()
+}
+
+// MatchFilterExpression returns true if the meta and the publisher of the
+// message match the filter expression, for ex. to filter the messages
+// returned by History.
+func MatchFilterExpression(expr string, message *PNMessage) (bool, error) {
+	filter, err := utils.ParseFilterExpression(expr)
+	if err != nil {
+		return false, err
+	}
+
+	return filter.Evaluate(message.UserMetadata, message.Publisher), nil
+}
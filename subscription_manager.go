@@ -71,6 +71,21 @@ type SubscriptionManager struct {
 	queryParam                   map[string]string
 	channelsOpen                 bool
 	requestSentAt                int64
+
+	// Filter expression set with the Subscribe builder, when unset the
+	// FilterExpression of the config is used.
+	filterExpression string
+
+	subscriptionsMutex sync.RWMutex
+	subscriptions      map[*Subscription]bool
+	// channels and channel groups subscribed with the Subscribe builder,
+	// with their presence channels
+	directChannels map[string]bool
+	directGroups   map[string]bool
+
+	// base filter expression parsed to evaluate the messages announced to
+	// the listeners when the loop uses a different one
+	parsedBaseFilter *utils.FilterExpression
 }
 
 // SubscribeOperation
@@ -94,12 +109,16 @@ type StateOperation struct {
 	channels      []string
 	channelGroups []string
 	state         map[string]interface{}
+	merge         bool
 }
 
 func newSubscriptionManager(pubnub *PubNub, ctx Context) *SubscriptionManager {
 	manager := &SubscriptionManager{}
 
 	manager.pubnub = pubnub
+	manager.subscriptions = make(map[*Subscription]bool)
+	manager.directChannels = make(map[string]bool)
+	manager.directGroups = make(map[string]bool)
 
 	manager.listenerManager = newListenerManager(ctx, pubnub)
 	manager.stateManager = newStateManager()
@@ -118,6 +137,9 @@ func newSubscriptionManager(pubnub *PubNub, ctx Context) *SubscriptionManager {
 
 		manager.reconnectionManager.HandleReconnection(func() {
 			go manager.reconnect()
+			if outbox := pubnub.GetOutbox(); outbox != nil {
+				go outbox.Replay()
+			}
 
 			manager.Lock()
 			manager.subscriptionStateAnnounced = true
@@ -184,8 +206,14 @@ func (m *SubscriptionManager) Destroy() {
 	}
 }
 
-func (m *SubscriptionManager) adaptState(stateOperation StateOperation) {
-	m.stateManager.adaptStateOperation(stateOperation)
+func (m *SubscriptionManager) mergedState(stateOperation StateOperation) (
+	map[string]map[string]interface{}, map[string]map[string]interface{}) {
+	return m.stateManager.mergedStateOperation(stateOperation)
+}
+
+func (m *SubscriptionManager) setStates(channelStates map[string]map[string]interface{},
+	groupStates map[string]map[string]interface{}) {
+	m.stateManager.setStates(channelStates, groupStates)
 }
 
 func (m *SubscriptionManager) adaptSubscribe(
@@ -198,6 +226,9 @@ func (m *SubscriptionManager) adaptSubscribe(
 
 	m.subscriptionStateAnnounced = false
 	m.queryParam = subscribeOperation.QueryParam
+	if subscribeOperation.FilterExpression != "" {
+		m.filterExpression = subscribeOperation.FilterExpression
+	}
 
 	if subscribeOperation.Timetoken != 0 {
 		m.timetoken = subscribeOperation.Timetoken
@@ -311,7 +342,7 @@ func (m *SubscriptionManager) startSubscribeLoop() {
 			ChannelGroups:    combinedGroups,
 			Timetoken:        tt,
 			Heartbeat:        m.pubnub.Config.PresenceTimeout,
-			FilterExpression: m.pubnub.Config.FilterExpression,
+			FilterExpression: m.loopFilterExpression(),
 			ctx:              ctx,
 			QueryParam:       m.queryParam,
 		}
@@ -471,12 +502,25 @@ type subscribeMessage struct {
 	IssuingClientID   string      `json:"i"`
 	SubscribeKey      string      `json:"k"`
 	Flags             int         `json:"f"`
+	MessageType       int         `json:"e"`
 	Payload           interface{} `json:"d"`
 	UserMetadata      interface{} `json:"u"`
+	SequenceNumber    int         `json:"s"`
 
 	PublishMetaData publishMetadata `json:"p"`
 }
 
+// subscribeMessageTypeMessageActions is the type of the subscribe messages
+// announcing a message action added or removed.
+const subscribeMessageTypeMessageActions = 3
+
+// subscribeMessageTypeSignal is the type of the subscribe messages sent with Signal.
+const subscribeMessageTypeSignal = 1
+
+// subscribeMessageTypeObjects is the type of the subscribe messages
+// announcing a change of metadata or of membership.
+const subscribeMessageTypeObjects = 2
+
 type presenceEnvelope struct {
 	Action    string
 	UUID      string
@@ -546,7 +590,7 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 		subscriptionMatch = ""
 	}
 
-	if strings.Contains(payload.Channel, "-pnpres") {
+	if utils.IsPresenceChannel(payload.Channel) {
 		var presencePayload map[string]interface{}
 		var action, uuid, actualChannel, subscribedChannel string
 		var occupancy int
@@ -593,13 +637,13 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 		strippedPresenceSubscription := ""
 
 		if channel != "" {
-			strippedPresenceChannel = strings.Replace(channel, "-pnpres", "", -1)
+			strippedPresenceChannel = utils.ChannelFromPresence(channel)
 		}
 
 		if subscriptionMatch != "" {
 			actualChannel = channel
 			subscribedChannel = subscriptionMatch
-			strippedPresenceSubscription = strings.Replace(subscriptionMatch, "-pnpres", "", -1)
+			strippedPresenceSubscription = utils.ChannelFromPresence(subscriptionMatch)
 		} else {
 			subscribedChannel = channel
 		}
@@ -618,6 +662,33 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 			HereNowRefresh:    hereNowRefresh,
 		}
 		m.listenerManager.announcePresence(pnPresenceResult)
+		for _, subscription := range m.getSubscriptions() {
+			if subscription.matches(channel, subscriptionMatch) {
+				subscription.announcePresence(pnPresenceResult, m.listenerManager.exitListener)
+			}
+		}
+
+		if action == "state-change" {
+			state, _ := data.(map[string]interface{})
+			if uuid == m.pubnub.Config.UUID {
+				m.stateManager.updateChannelState(strippedPresenceChannel, state)
+			}
+
+			m.listenerManager.announceStateChange(&PNStateChange{
+				UUID:              uuid,
+				Channel:           strippedPresenceChannel,
+				SubscribedChannel: subscribedChannel,
+				State:             state,
+				Timetoken:         timetoken,
+				Timestamp:         timestamp,
+			})
+		}
+	} else if payload.MessageType == subscribeMessageTypeMessageActions {
+		processMessageActionsPayload(m, payload, subscriptionMatch)
+	} else if payload.MessageType == subscribeMessageTypeSignal {
+		processSignalPayload(m, payload, subscriptionMatch)
+	} else if payload.MessageType == subscribeMessageTypeObjects {
+		processObjectsPayload(m, payload, subscriptionMatch)
 	} else {
 		actualCh := ""
 		subscribedCh := channel
@@ -627,7 +698,7 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 			actualCh = channel
 			subscribedCh = subscriptionMatch
 		}
-		messagePayload, err := parseCipherInterface(payload.Payload, m.pubnub.Config)
+		messagePayload, err := parseChannelCipherInterface(payload.Payload, m.pubnub.Config, channel)
 
 		if err != nil {
 			pnStatus := &PNStatus{
@@ -650,14 +721,140 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 			Timetoken:         timetoken,
 			Publisher:         payload.IssuingClientID,
 			UserMetadata:      payload.UserMetadata,
+			SequenceNumber:    payload.SequenceNumber,
+		}
+		if payload.IssuingClientID == m.pubnub.Config.UUID {
+			m.pubnub.deliveries.confirm(channel, payload.SequenceNumber, timetoken)
+		}
+		subscriptions := m.getSubscriptions()
+		loopFilterExpression := m.loopFilterExpression()
+		if m.acceptsBaseFilter(pnMessageResult, loopFilterExpression) {
+			m.pubnub.Config.Log.Println("announceMessage,", pnMessageResult)
+			m.listenerManager.announceMessage(pnMessageResult)
+		}
+		for _, subscription := range subscriptions {
+			if subscription.accepts(pnMessageResult, loopFilterExpression) {
+				subscription.announceMessage(pnMessageResult, m.listenerManager.exitListener)
+			}
 		}
-		m.pubnub.Config.Log.Println("announceMessage,", pnMessageResult)
-		m.listenerManager.announceMessage(pnMessageResult)
 		m.pubnub.Config.Log.Println("after announceMessage")
 	}
 }
 
-// parseCipherInterface handles the decryption in case a cipher key is used
+func processSignalPayload(m *SubscriptionManager, payload subscribeMessage, subscriptionMatch string) {
+	channel := payload.Channel
+	actualCh := ""
+	subscribedCh := channel
+	timetoken, _ := strconv.ParseInt(payload.PublishMetaData.PublishTimetoken, 10, 64)
+
+	if subscriptionMatch != "" {
+		actualCh = channel
+		subscribedCh = subscriptionMatch
+	}
+
+	pnSignalResult := &PNMessage{
+		Message:           payload.Payload,
+		ActualChannel:     actualCh,
+		SubscribedChannel: subscribedCh,
+		Channel:           channel,
+		Subscription:      subscriptionMatch,
+		Timetoken:         timetoken,
+		Publisher:         payload.IssuingClientID,
+		UserMetadata:      payload.UserMetadata,
+	}
+
+	m.pubnub.Config.Log.Println("announceSignal,", pnSignalResult)
+	m.listenerManager.announceSignal(pnSignalResult)
+	for _, subscription := range m.getSubscriptions() {
+		if subscription.matches(channel, subscriptionMatch) {
+			subscription.announceSignal(pnSignalResult, m.listenerManager.exitListener)
+		}
+	}
+}
+
+func processObjectsPayload(m *SubscriptionManager, payload subscribeMessage, subscriptionMatch string) {
+	channel := payload.Channel
+	actualCh := ""
+	subscribedCh := channel
+
+	if subscriptionMatch != "" {
+		actualCh = channel
+		subscribedCh = subscriptionMatch
+	}
+
+	objectsPayload, _ := payload.Payload.(map[string]interface{})
+	event, err := newObjectsEvent(objectsPayload)
+	if err != nil {
+		m.listenerManager.announceStatus(&PNStatus{
+			Category:         PNUnknownCategory,
+			ErrorData:        err,
+			Error:            true,
+			Operation:        PNSubscribeOperation,
+			AffectedChannels: []string{channel},
+		})
+		return
+	}
+
+	event.SubscribedChannel = subscribedCh
+	event.ActualChannel = actualCh
+	event.Channel = channel
+	event.Subscription = subscriptionMatch
+
+	m.pubnub.Config.Log.Println("announceObjects,", event)
+	m.listenerManager.announceObjects(event)
+	for _, subscription := range m.getSubscriptions() {
+		if subscription.matches(channel, subscriptionMatch) {
+			subscription.announceObjects(event, m.listenerManager.exitListener)
+		}
+	}
+}
+
+func processMessageActionsPayload(m *SubscriptionManager, payload subscribeMessage, subscriptionMatch string) {
+	channel := payload.Channel
+	actualCh := ""
+	subscribedCh := channel
+
+	if subscriptionMatch != "" {
+		actualCh = channel
+		subscribedCh = subscriptionMatch
+	}
+
+	actionsPayload, _ := payload.Payload.(map[string]interface{})
+	data, _ := actionsPayload["data"].(map[string]interface{})
+
+	event := &PNMessageActionsEvent{
+		Data:              newMessageAction(data),
+		SubscribedChannel: subscribedCh,
+		ActualChannel:     actualCh,
+		Channel:           channel,
+		Subscription:      subscriptionMatch,
+	}
+	event.Event, _ = actionsPayload["event"].(string)
+	if event.Data.UUID == "" {
+		event.Data.UUID = payload.IssuingClientID
+	}
+
+	m.pubnub.Config.Log.Println("announceMessageActionsEvent,", event)
+	m.listenerManager.announceMessageActionsEvent(event)
+	for _, subscription := range m.getSubscriptions() {
+		if subscription.matches(channel, subscriptionMatch) {
+			subscription.announceMessageActionsEvent(event, m.listenerManager.exitListener)
+		}
+	}
+}
+
+// parseCipherInterface decrypts the data with the CipherKey.
+func parseCipherInterface(data interface{}, pnConf *Config) (interface{}, error) {
+	return parseCipherInterfaceWithKey(data, pnConf, pnConf.CipherKey)
+}
+
+// parseChannelCipherInterface decrypts the data with the key of the channel
+// in CipherKeys or the CipherKey.
+func parseChannelCipherInterface(data interface{}, pnConf *Config, channel string) (interface{}, error) {
+	return parseCipherInterfaceWithKey(data, pnConf, pnConf.cipherKeyForChannel(channel))
+}
+
+// parseCipherInterfaceWithKey handles the decryption in case a cipher key is used
 // in case of error it returns data as is.
 //
 // parameters
@@ -665,8 +862,8 @@ func processSubscribePayload(m *SubscriptionManager, payload subscribeMessage) {
 // cipherKey: cipher key to use to decrypt.
 //
 // returns the decrypted data as interface and error.
-func parseCipherInterface(data interface{}, pnConf *Config) (interface{}, error) {
-	if pnConf.CipherKey != "" {
+func parseCipherInterfaceWithKey(data interface{}, pnConf *Config, cipherKey string) (interface{}, error) {
+	if cipherKey != "" {
 		pnConf.Log.Println("reflect.TypeOf(data).Kind()", reflect.TypeOf(data).Kind(), data)
 		switch v := data.(type) {
 		case map[string]interface{}:
@@ -676,7 +873,7 @@ func parseCipherInterface(data interface{}, pnConf *Config) (interface{}, error)
 				msg, ok := v["pn_other"].(string)
 				if ok {
 					pnConf.Log.Println("v[pn_other]", v["pn_other"], v, msg)
-					decrypted, errDecryption := utils.DecryptString(pnConf.CipherKey, msg)
+					decrypted, errDecryption := utils.DecryptString(cipherKey, msg)
 					if errDecryption != nil {
 						pnConf.Log.Println(errDecryption, msg)
 						return v, errDecryption
@@ -699,7 +896,7 @@ func parseCipherInterface(data interface{}, pnConf *Config) (interface{}, error)
 			return v, nil
 		case string:
 			var intf interface{}
-			decrypted, errDecryption := utils.DecryptString(pnConf.CipherKey, data.(string))
+			decrypted, errDecryption := utils.DecryptString(cipherKey, data.(string))
 			if errDecryption != nil {
 				pnConf.Log.Println(errDecryption, intf)
 				intf = data
@@ -743,6 +940,196 @@ func (m *SubscriptionManager) GetListeners() map[*Listener]bool {
 	return listn
 }
 
+func (m *SubscriptionManager) addSubscription(subscription *Subscription) {
+	m.subscriptionsMutex.Lock()
+	m.subscriptions[subscription] = true
+	m.subscriptionsMutex.Unlock()
+}
+
+// removeSubscription unsubscribes the channels and channel groups of the
+// subscription which are not used by the other subscriptions or by Subscribe.
+func (m *SubscriptionManager) removeSubscription(subscription *Subscription) {
+	operation := m.releaseSubscription(subscription)
+
+	if len(operation.Channels) == 0 && len(operation.ChannelGroups) == 0 {
+		// The filter expression of the loop may have changed.
+		m.reconnect()
+		return
+	}
+	m.adaptUnsubscribe(operation)
+}
+
+// releaseSubscription removes the subscription and returns the operation
+// unsubscribing the channels and channel groups no longer in use.
+func (m *SubscriptionManager) releaseSubscription(subscription *Subscription) *UnsubscribeOperation {
+	m.subscriptionsMutex.Lock()
+	delete(m.subscriptions, subscription)
+	inUse := make(map[string]bool)
+	groupsInUse := make(map[string]bool)
+	for ch := range m.directChannels {
+		inUse[ch] = true
+	}
+	for cg := range m.directGroups {
+		groupsInUse[cg] = true
+	}
+	for s := range m.subscriptions {
+		for _, ch := range s.subscribedChannels() {
+			inUse[ch] = true
+		}
+		for _, cg := range s.subscribedGroups() {
+			groupsInUse[cg] = true
+		}
+	}
+	m.subscriptionsMutex.Unlock()
+
+	operation := &UnsubscribeOperation{
+		QueryParam: subscription.queryParam,
+	}
+	for _, ch := range subscription.subscribedChannels() {
+		if !inUse[ch] {
+			operation.Channels = append(operation.Channels, ch)
+		}
+	}
+	for _, cg := range subscription.subscribedGroups() {
+		if !groupsInUse[cg] {
+			operation.ChannelGroups = append(operation.ChannelGroups, cg)
+		}
+	}
+
+	return operation
+}
+
+func (m *SubscriptionManager) getSubscriptions() []*Subscription {
+	m.subscriptionsMutex.RLock()
+	defer m.subscriptionsMutex.RUnlock()
+
+	subscriptions := []*Subscription{}
+	for s := range m.subscriptions {
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions
+}
+
+// addDirectSubscribe records the channels and channel groups subscribed with
+// the Subscribe builder, a Subscription doesn't unsubscribe them.
+func (m *SubscriptionManager) addDirectSubscribe(operation *SubscribeOperation) {
+	m.subscriptionsMutex.Lock()
+	defer m.subscriptionsMutex.Unlock()
+
+	for _, ch := range operation.Channels {
+		m.directChannels[ch] = true
+		if operation.PresenceEnabled && !utils.IsPresenceChannel(ch) {
+			m.directChannels[utils.PresenceChannel(ch)] = true
+		}
+	}
+	for _, cg := range operation.ChannelGroups {
+		m.directGroups[cg] = true
+		if operation.PresenceEnabled && !utils.IsPresenceChannel(cg) {
+			m.directGroups[utils.PresenceChannel(cg)] = true
+		}
+	}
+}
+
+// removeDirectSubscribe forgets the channels and channel groups unsubscribed
+// with the Unsubscribe builder.
+func (m *SubscriptionManager) removeDirectSubscribe(operation *UnsubscribeOperation) {
+	m.subscriptionsMutex.Lock()
+	defer m.subscriptionsMutex.Unlock()
+
+	for _, ch := range operation.Channels {
+		delete(m.directChannels, ch)
+	}
+	for _, cg := range operation.ChannelGroups {
+		delete(m.directGroups, cg)
+	}
+}
+
+// baseFilterExpression returns the filter expression set with the Subscribe
+// builder or the FilterExpression of the config.
+func (m *SubscriptionManager) baseFilterExpression() string {
+	m.RLock()
+	baseFilter := m.filterExpression
+	m.RUnlock()
+	if baseFilter == "" {
+		baseFilter = m.pubnub.Config.FilterExpression
+	}
+
+	return baseFilter
+}
+
+// acceptsBaseFilter returns true if the message is announced to the listeners
+// added with AddListener. The base filter expression is only evaluated when
+// the subscribe loop used a different one.
+func (m *SubscriptionManager) acceptsBaseFilter(message *PNMessage, loopFilterExpression string) bool {
+	baseFilter := m.baseFilterExpression()
+	if baseFilter == "" || baseFilter == loopFilterExpression {
+		return true
+	}
+
+	m.Lock()
+	parsedFilter := m.parsedBaseFilter
+	if parsedFilter == nil || parsedFilter.String() != baseFilter {
+		var err error
+		parsedFilter, err = utils.ParseFilterExpression(baseFilter)
+		if err != nil {
+			m.Unlock()
+			// the invalid expressions are rejected by Subscribe
+			return true
+		}
+		m.parsedBaseFilter = parsedFilter
+	}
+	m.Unlock()
+
+	return parsedFilter.Evaluate(message.UserMetadata, message.Publisher)
+}
+
+// loopFilterExpression returns the filter expression of the subscribe loop.
+// When the subscriptions use different filter expressions the loop requests
+// the messages matching any of them, an empty filter of any subscription
+// disables the server side filtering.
+func (m *SubscriptionManager) loopFilterExpression() string {
+	baseFilter := m.baseFilterExpression()
+
+	subscriptions := m.getSubscriptions()
+	if len(subscriptions) == 0 {
+		return baseFilter
+	}
+
+	expressions := []string{}
+	seen := make(map[string]bool)
+	addExpression := func(expr string) {
+		if !seen[expr] {
+			seen[expr] = true
+			expressions = append(expressions, expr)
+		}
+	}
+
+	if m.hasSubscriptionsWithoutHandle() {
+		addExpression(baseFilter)
+	}
+	for _, s := range subscriptions {
+		addExpression(s.filterExpression)
+	}
+
+	if seen[""] {
+		return ""
+	}
+	if len(expressions) == 1 {
+		return expressions[0]
+	}
+
+	return "(" + strings.Join(expressions, ") || (") + ")"
+}
+
+// hasSubscriptionsWithoutHandle returns true if a channel or channel group
+// was subscribed with the Subscribe builder instead of a Subscription.
+func (m *SubscriptionManager) hasSubscriptionsWithoutHandle() bool {
+	m.subscriptionsMutex.RLock()
+	defer m.subscriptionsMutex.RUnlock()
+
+	return len(m.directChannels) > 0 || len(m.directGroups) > 0
+}
+
 func (m *SubscriptionManager) reconnect() {
 	m.pubnub.Config.Log.Println("reconnect")
 	m.reconnectionManager.stopHeartbeatTimer()
@@ -794,6 +1181,11 @@ func (m *SubscriptionManager) getSubscribedGroups() []string {
 }
 
 func (m *SubscriptionManager) unsubscribeAll() {
+	m.subscriptionsMutex.Lock()
+	m.directChannels = make(map[string]bool)
+	m.directGroups = make(map[string]bool)
+	m.subscriptionsMutex.Unlock()
+
 	m.adaptUnsubscribe(&UnsubscribeOperation{
 		Channels:      m.stateManager.prepareChannelList(true),
 		ChannelGroups: m.stateManager.prepareGroupList(true),
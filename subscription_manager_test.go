@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type customStruct struct {
@@ -559,3 +560,418 @@ func TestProcessSubscribePayloadCipherErr(t *testing.T) {
 	<-done
 	//pn.Destroy()
 }
+
+func TestProcessSubscribePayloadStateChange(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.UUID = "state-uuid"
+	listener := NewListener()
+	stateListener := NewStateChangeListener()
+
+	go func() {
+		for {
+			select {
+			case <-listener.Status:
+			case <-listener.Message:
+			case <-listener.Presence:
+			}
+		}
+	}()
+
+	pn.AddListener(listener)
+	pn.AddStateChangeListener(stateListener)
+
+	sm := &subscribeMessage{
+		Shard:             "1",
+		SubscriptionMatch: "channel-pnpres",
+		Channel:           "channel-pnpres",
+		Payload: map[string]interface{}{
+			"action":    "state-change",
+			"timestamp": float64(1535709775),
+			"uuid":      "state-uuid",
+			"occupancy": 1,
+			"data":      map[string]interface{}{"mood": "happy"},
+		},
+		PublishMetaData: publishMetadata{
+			PublishTimetoken: "15357097750000000",
+		},
+	}
+
+	processSubscribePayload(pn.subscriptionManager, *sm)
+
+	select {
+	case stateChange := <-stateListener.StateChange:
+		assert.Equal("state-uuid", stateChange.UUID)
+		assert.Equal("channel", stateChange.Channel)
+		assert.Equal(map[string]interface{}{"mood": "happy"}, stateChange.State)
+		assert.Equal(int64(15357097750000000), stateChange.Timetoken)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no state change")
+	}
+
+	state, ok := pn.GetCachedState("channel")
+	assert.True(ok)
+	assert.Equal(map[string]interface{}{"mood": "happy"}, state)
+}
+
+func TestProcessSubscribePayloadWildcardPresence(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	sm := &subscribeMessage{
+		Shard:             "1",
+		SubscriptionMatch: "a.*-pnpres",
+		Channel:           "a.b-pnpres",
+		Payload: map[string]interface{}{
+			"action":    "join",
+			"timestamp": float64(1535709775),
+			"uuid":      "uuid",
+		},
+	}
+
+	go processSubscribePayload(pn.subscriptionManager, *sm)
+
+	select {
+	case presence := <-listener.Presence:
+		assert.Equal("a.b", presence.Channel)
+		assert.Equal("a.*", presence.Subscription)
+		assert.Equal("a.b-pnpres", presence.ActualChannel)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no presence")
+	}
+}
+
+func TestProcessSubscribePayloadMessageActions(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	actionsListener := NewMessageActionsListener()
+
+	pn.AddListener(listener)
+	pn.AddMessageActionsListener(actionsListener)
+
+	sm := &subscribeMessage{
+		Shard:           "1",
+		Channel:         "channel",
+		IssuingClientID: "reacting-uuid",
+		MessageType:     subscribeMessageTypeMessageActions,
+		Payload: map[string]interface{}{
+			"source":  "actions",
+			"version": "1.0",
+			"event":   "added",
+			"data": map[string]interface{}{
+				"messageTimetoken": "15610547826970040",
+				"type":             "reaction",
+				"value":            "smiley_face",
+				"actionTimetoken":  "15610547826970050",
+			},
+		},
+		PublishMetaData: publishMetadata{
+			PublishTimetoken: "15610547826970050",
+		},
+	}
+
+	processSubscribePayload(pn.subscriptionManager, *sm)
+
+	select {
+	case event := <-actionsListener.MessageActionsEvent:
+		assert.Equal("added", event.Event)
+		assert.Equal("channel", event.Channel)
+		assert.Equal("channel", event.SubscribedChannel)
+		assert.Equal("reaction", event.Data.ActionType)
+		assert.Equal("smiley_face", event.Data.ActionValue)
+		assert.Equal("reacting-uuid", event.Data.UUID)
+		assert.Equal(int64(15610547826970040), event.Data.MessageTimetoken)
+		assert.Equal(int64(15610547826970050), event.Data.ActionTimetoken)
+	case <-listener.Message:
+		assert.Fail("the action was announced as a message")
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message actions event")
+	}
+
+	pn.RemoveMessageActionsListener(actionsListener)
+}
+
+func TestProcessSubscribePayloadSignal(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "enigma"
+	listener := NewListener()
+	signalListener := NewSignalListener()
+	pn.AddListener(listener)
+	pn.AddSignalListener(signalListener)
+
+	sm := &subscribeMessage{
+		Shard:           "1",
+		Channel:         "channel",
+		IssuingClientID: "typing-uuid",
+		MessageType:     subscribeMessageTypeSignal,
+		Payload:         map[string]interface{}{"typing": true},
+		PublishMetaData: publishMetadata{
+			PublishTimetoken: "15610547826970050",
+		},
+	}
+
+	processSubscribePayload(pn.subscriptionManager, *sm)
+
+	select {
+	case signal := <-signalListener.Signal:
+		assert.Equal(map[string]interface{}{"typing": true}, signal.Message)
+		assert.Equal("channel", signal.Channel)
+		assert.Equal("channel", signal.SubscribedChannel)
+		assert.Equal("typing-uuid", signal.Publisher)
+		assert.Equal(int64(15610547826970050), signal.Timetoken)
+	case <-listener.Message:
+		assert.Fail("the signal was announced as a message")
+	case <-time.After(5 * time.Second):
+		assert.Fail("no signal")
+	}
+
+	pn.RemoveListener(listener)
+	pn.RemoveSignalListener(signalListener)
+}
+
+func TestProcessSubscribePayloadSignalWithoutSignalListener(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	// the listener only reads the messages
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel:     "channel",
+		MessageType: subscribeMessageTypeSignal,
+		Payload:     "typing",
+	})
+
+	other := NewListener()
+	added := make(chan bool)
+	go func() {
+		pn.AddListener(other)
+		pn.RemoveListener(other)
+		added <- true
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		assert.Fail("AddListener is blocked by the signal")
+	}
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel: "channel",
+		Payload: "hello",
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("hello", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	pn.RemoveListener(listener)
+}
+
+func TestProcessSubscribePayloadObjects(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	objectsListener := NewObjectsListener()
+	pn.AddListener(listener)
+	pn.AddObjectsListener(objectsListener)
+
+	sm := &subscribeMessage{
+		Shard:       "1",
+		Channel:     "ch",
+		MessageType: subscribeMessageTypeObjects,
+		Payload: map[string]interface{}{
+			"source":  "objects",
+			"version": "2.0",
+			"event":   "set",
+			"type":    "channel",
+			"data": map[string]interface{}{
+				"id":          "ch",
+				"name":        "name",
+				"description": "description",
+			},
+		},
+	}
+
+	processSubscribePayload(pn.subscriptionManager, *sm)
+
+	select {
+	case event := <-objectsListener.Objects:
+		assert.Equal(PNObjectsEventSet, event.Event)
+		assert.Equal(PNObjectsTypeChannel, event.Type)
+		assert.Equal("ch", event.Channel)
+		assert.Equal("ch", event.SubscribedChannel)
+		assert.Equal("description", event.ChannelMetadata.Description)
+	case <-listener.Message:
+		assert.Fail("the objects event was announced as a message")
+	case <-time.After(5 * time.Second):
+		assert.Fail("no objects event")
+	}
+
+	pn.RemoveListener(listener)
+	pn.RemoveObjectsListener(objectsListener)
+}
+
+func TestProcessSubscribePayloadObjectsWithoutObjectsListener(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	// the listener only reads the messages
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel:     "ch",
+		MessageType: subscribeMessageTypeObjects,
+		Payload: map[string]interface{}{
+			"event": "delete",
+			"type":  "uuid",
+			"data":  map[string]interface{}{"id": "uuid"},
+		},
+	})
+
+	other := NewListener()
+	added := make(chan bool)
+	go func() {
+		pn.AddListener(other)
+		pn.RemoveListener(other)
+		added <- true
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		assert.Fail("AddListener is blocked by the objects event")
+	}
+
+	pn.RemoveListener(listener)
+}
+
+func TestProcessSubscribePayloadSequenceNumber(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:           "1",
+		Channel:         "ch",
+		IssuingClientID: "publisher",
+		SequenceNumber:  42,
+		Payload:         "hey",
+		PublishMetaData: publishMetadata{PublishTimetoken: "15191382475826880"},
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal(42, message.SequenceNumber)
+		assert.Equal("publisher", message.Publisher)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	pn.RemoveListener(listener)
+}
+
+func TestParseChannelCipherInterface(t *testing.T) {
+	assert := assert.New(t)
+	s := "Wi24KS4pcTzvyuGOHubiXg=="
+
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKey = "test"
+	pn.Config.CipherKeys = map[string]string{
+		"secret": "enigma",
+		"public": "",
+	}
+
+	intf, err := parseChannelCipherInterface(s, pn.Config, "secret")
+	assert.Nil(err)
+	assert.Equal("yay!", intf.(string))
+
+	intf, err = parseChannelCipherInterface("yay!", pn.Config, "public")
+	assert.Nil(err)
+	assert.Equal("yay!", intf.(string))
+
+	intf, _ = parseChannelCipherInterface(s, pn.Config, "other")
+	assert.Equal(s, intf.(string))
+}
+
+func TestProcessSubscribePayloadChannelCipherKey(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.CipherKeys = map[string]string{"secret": "enigma"}
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:           "1",
+		Channel:         "secret",
+		Payload:         "Wi24KS4pcTzvyuGOHubiXg==",
+		PublishMetaData: publishMetadata{PublishTimetoken: "15191382475826880"},
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("yay!", message.Message)
+	case status := <-listener.Status:
+		assert.Fail("unexpected status", status.ErrorData)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	pn.RemoveListener(listener)
+}
+
+func TestAnnounceStateChangeReleasesLockOnExit(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	m := newListenerManager(pn.ctx, pn)
+
+	// the listener is never read
+	m.addStateChangeListener(NewStateChangeListener())
+	m.announceStateChange(&PNStateChange{UUID: "uuid"})
+	time.Sleep(10 * time.Millisecond)
+	close(m.exitListener)
+
+	added := make(chan bool)
+	go func() {
+		m.addStateChangeListener(NewStateChangeListener())
+		added <- true
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		assert.Fail("addStateChangeListener is blocked after exitListener")
+	}
+}
+
+func TestAnnounceMessageActionsEventReleasesLockOnExit(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	m := newListenerManager(pn.ctx, pn)
+
+	// the listener is never read
+	m.addMessageActionsListener(NewMessageActionsListener())
+	m.announceMessageActionsEvent(&PNMessageActionsEvent{Event: "added"})
+	time.Sleep(10 * time.Millisecond)
+	close(m.exitListener)
+
+	added := make(chan bool)
+	go func() {
+		m.addMessageActionsListener(NewMessageActionsListener())
+		added <- true
+	}()
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		assert.Fail("addMessageActionsListener is blocked after exitListener")
+	}
+}
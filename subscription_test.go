@@ -0,0 +1,353 @@
+package pubnub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pubnub/go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSubscription(pn *PubNub, channels []string, filterExpression string) *Subscription {
+	s := &Subscription{
+		pubnub:           pn,
+		channels:         channels,
+		filterExpression: filterExpression,
+		listener:         NewListener(),
+		active:           true,
+	}
+	pn.subscriptionManager.addSubscription(s)
+
+	return s
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	s, err := newSubscriptionBuilder(pubnub).Execute()
+	assert.Nil(s)
+	assert.Contains(err.Error(), "Missing Channel")
+
+	s, err = newSubscriptionBuilder(pubnub).Channels([]string{"a.*.b"}).Execute()
+	assert.Nil(s)
+	assert.Contains(err.Error(), "Invalid wildcard channel")
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	assert := assert.New(t)
+	s := &Subscription{
+		channels:        []string{"news.*", "chat"},
+		channelGroups:   []string{"cg"},
+		presenceEnabled: true,
+	}
+
+	assert.True(s.matches("chat", ""))
+	assert.True(s.matches("news.sport", "news.*"))
+	assert.True(s.matches("chat-pnpres", "chat-pnpres"))
+	assert.True(s.matches("other", "cg"))
+	assert.False(s.matches("other", ""))
+	assert.False(s.matches("other", "cg2"))
+}
+
+func TestSubscriptionLoopFilterExpression(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.FilterExpression = "global"
+
+	assert.Equal("global", pn.subscriptionManager.loopFilterExpression())
+
+	newTestSubscription(pn, []string{"ch1"}, "a == 1")
+	assert.Equal("a == 1", pn.subscriptionManager.loopFilterExpression())
+
+	newTestSubscription(pn, []string{"ch2"}, "b == 2")
+	newTestSubscription(pn, []string{"ch3"}, "a == 1")
+	assert.Equal(3, len(pn.GetSubscriptions()))
+	assert.Contains([]string{"(a == 1) || (b == 2)", "(b == 2) || (a == 1)"},
+		pn.subscriptionManager.loopFilterExpression())
+
+	newTestSubscription(pn, []string{"ch4"}, "")
+	assert.Equal("", pn.subscriptionManager.loopFilterExpression())
+}
+
+func TestSubscriptionLoopFilterExpressionWithoutHandle(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.subscriptionManager.filterExpression = "base"
+	pn.subscriptionManager.addDirectSubscribe(&SubscribeOperation{
+		Channels: []string{"plain"},
+	})
+
+	newTestSubscription(pn, []string{"ch1"}, "a == 1")
+	assert.Equal("(base) || (a == 1)", pn.subscriptionManager.loopFilterExpression())
+
+	// the plain channel is also used by a subscription
+	pn.subscriptionManager.removeDirectSubscribe(&UnsubscribeOperation{
+		Channels: []string{"plain"},
+	})
+	assert.Equal("a == 1", pn.subscriptionManager.loopFilterExpression())
+}
+
+func TestSubscriptionReceivesOwnMessages(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	s1 := newTestSubscription(pn, []string{"news.*"}, "")
+	s2 := newTestSubscription(pn, []string{"chat"}, "")
+	s2.filter = func(message *PNMessage) bool {
+		return message.Message == "keep"
+	}
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:             "1",
+		SubscriptionMatch: "news.*",
+		Channel:           "news.sport",
+		Payload:           "goal",
+		PublishMetaData: publishMetadata{
+			PublishTimetoken: "15357097750000000",
+		},
+	})
+
+	select {
+	case message := <-s1.Listener().Message:
+		assert.Equal("news.sport", message.Channel)
+		assert.Equal("goal", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:   "1",
+		Channel: "chat",
+		Payload: "drop",
+	})
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:   "1",
+		Channel: "chat",
+		Payload: "keep",
+	})
+
+	select {
+	case message := <-s2.Listener().Message:
+		assert.Equal("keep", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	select {
+	case message := <-s1.Listener().Message:
+		assert.Fail("unexpected message", "%v", message)
+	case message := <-s2.Listener().Message:
+		assert.Fail("unexpected message", "%v", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubscriptionEvaluatesFilterExpression(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	s1 := newTestSubscription(pn, []string{"ch"}, "meta.lang == 'en'")
+	s1.parsedFilter, _ = utils.ParseFilterExpression(s1.filterExpression)
+	s2 := newTestSubscription(pn, []string{"ch"}, "meta.lang == 'fr'")
+	s2.parsedFilter, _ = utils.ParseFilterExpression(s2.filterExpression)
+
+	message := &PNMessage{
+		Channel:      "ch",
+		UserMetadata: map[string]interface{}{"lang": "en"},
+	}
+	loopFilterExpression := pn.subscriptionManager.loopFilterExpression()
+
+	assert.True(s1.accepts(message, loopFilterExpression))
+	assert.False(s2.accepts(message, loopFilterExpression))
+	// Already filtered by the server
+	assert.True(s2.accepts(message, s2.filterExpression))
+
+	_, err := newSubscriptionBuilder(pn).Channels([]string{"ch"}).FilterExpression("meta.lang ==").Execute()
+	assert.Contains(err.Error(), "Invalid filter expression")
+}
+
+func TestMatchFilterExpression(t *testing.T) {
+	assert := assert.New(t)
+	message := &PNMessage{
+		Publisher:    "bot-1",
+		UserMetadata: map[string]interface{}{"priority": float64(3)},
+	}
+
+	match, err := MatchFilterExpression("meta.priority > 2 && uuid LIKE 'bot*'", message)
+	assert.Nil(err)
+	assert.True(match)
+
+	_, err = MatchFilterExpression("meta.priority >", message)
+	assert.NotNil(err)
+}
+
+func TestBuildURLWithoutFilterExpression(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.FilterExpression = "a == 1"
+
+	opts := &timeOpts{
+		pubnub: pn,
+	}
+
+	u, err := buildURL(opts)
+	assert.Nil(err)
+	assert.Empty(u.Query().Get("filter-expr"))
+}
+
+func TestSubscriptionListenersUseBaseFilter(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.FilterExpression = "meta.lang == 'en'"
+	pn.subscriptionManager.addDirectSubscribe(&SubscribeOperation{
+		Channels: []string{"ch"},
+	})
+	s := newTestSubscription(pn, []string{"ch"}, "meta.lang == 'fr'")
+	s.parsedFilter, _ = utils.ParseFilterExpression(s.filterExpression)
+
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:        "1",
+		Channel:      "ch",
+		Payload:      "bonjour",
+		UserMetadata: map[string]interface{}{"lang": "fr"},
+	})
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:        "1",
+		Channel:      "ch",
+		Payload:      "hello",
+		UserMetadata: map[string]interface{}{"lang": "en"},
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("hello", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	select {
+	case message := <-s.Listener().Message:
+		assert.Equal("bonjour", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+
+	select {
+	case message := <-listener.Message:
+		assert.Fail("unexpected message", "%v", message)
+	case message := <-s.Listener().Message:
+		assert.Fail("unexpected message", "%v", message)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	pn.RemoveListener(listener)
+}
+
+func TestListenersWithoutSubscriptionsUseServerFilter(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.Config.FilterExpression = "meta.lang == 'en'"
+
+	listener := NewListener()
+	pn.AddListener(listener)
+
+	// the loop already used the base filter, it isn't evaluated again
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Shard:        "1",
+		Channel:      "ch",
+		Payload:      "bonjour",
+		UserMetadata: map[string]interface{}{"lang": "fr"},
+	})
+
+	select {
+	case message := <-listener.Message:
+		assert.Equal("bonjour", message.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message")
+	}
+	assert.Nil(pn.subscriptionManager.parsedBaseFilter)
+
+	pn.RemoveListener(listener)
+}
+
+func TestSubscriptionUnsubscribeKeepsDirectChannels(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+	pn.subscriptionManager.addDirectSubscribe(&SubscribeOperation{
+		Channels:      []string{"shared"},
+		ChannelGroups: []string{"cg"},
+	})
+
+	s := newTestSubscription(pn, []string{"shared", "own"}, "")
+	s.channelGroups = []string{"cg", "own-cg"}
+	other := newTestSubscription(pn, []string{"other"}, "")
+
+	operation := pn.subscriptionManager.releaseSubscription(s)
+	assert.Equal([]string{"own"}, operation.Channels)
+	assert.Equal([]string{"own-cg"}, operation.ChannelGroups)
+	assert.Equal([]*Subscription{other}, pn.GetSubscriptions())
+}
+
+func TestSubscriptionSignalListener(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	s := newTestSubscription(pn, []string{"ch"}, "")
+	assert.Nil(s.SignalListener())
+
+	// without signal listener the signal is dropped
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel:     "ch",
+		MessageType: subscribeMessageTypeSignal,
+		Payload:     "dropped",
+	})
+
+	s.signalListener = NewSignalListener()
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel:     "ch",
+		MessageType: subscribeMessageTypeSignal,
+		Payload:     "typing",
+	})
+
+	select {
+	case signal := <-s.SignalListener().Signal:
+		assert.Equal("typing", signal.Message)
+	case <-time.After(5 * time.Second):
+		assert.Fail("no signal")
+	}
+}
+
+func TestSubscriptionMessageActionsListener(t *testing.T) {
+	assert := assert.New(t)
+	pn := NewPubNub(NewDemoConfig())
+
+	s := newTestSubscription(pn, []string{"ch"}, "")
+	s.actionsListener = NewMessageActionsListener()
+	other := newTestSubscription(pn, []string{"other"}, "")
+	other.actionsListener = NewMessageActionsListener()
+
+	processSubscribePayload(pn.subscriptionManager, subscribeMessage{
+		Channel:     "ch",
+		MessageType: subscribeMessageTypeMessageActions,
+		Payload: map[string]interface{}{
+			"event": "added",
+			"data": map[string]interface{}{
+				"type":  "reaction",
+				"value": "smiley_face",
+			},
+		},
+	})
+
+	select {
+	case event := <-s.MessageActionsListener().MessageActionsEvent:
+		assert.Equal("added", event.Event)
+		assert.Equal("smiley_face", event.Data.ActionValue)
+	case <-other.MessageActionsListener().MessageActionsEvent:
+		assert.Fail("the action was announced to another subscription")
+	case <-time.After(5 * time.Second):
+		assert.Fail("no message actions event")
+	}
+}
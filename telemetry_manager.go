@@ -183,11 +183,54 @@ func telemetryEndpointNameForOperation(t OperationType) string {
 	case PNRemoveGroupOperation:
 		endpoint = "cg"
 		break
+	case PNAccessManagerGrantToken:
+		fallthrough
+	case PNAccessManagerAudit:
+		fallthrough
 	case PNAccessManagerRevoke:
 		fallthrough
 	case PNAccessManagerGrant:
 		endpoint = "pam"
 		break
+	case PNAddMessageActionOperation:
+		fallthrough
+	case PNGetMessageActionsOperation:
+		fallthrough
+	case PNRemoveMessageActionOperation:
+		endpoint = "msga"
+		break
+	case PNSignalOperation:
+		endpoint = "sig"
+		break
+	case PNSetUUIDMetadataOperation:
+		fallthrough
+	case PNGetUUIDMetadataOperation:
+		fallthrough
+	case PNRemoveUUIDMetadataOperation:
+		fallthrough
+	case PNGetAllUUIDMetadataOperation:
+		fallthrough
+	case PNSetChannelMetadataOperation:
+		fallthrough
+	case PNGetChannelMetadataOperation:
+		fallthrough
+	case PNRemoveChannelMetadataOperation:
+		fallthrough
+	case PNGetAllChannelMetadataOperation:
+		fallthrough
+	case PNGetMembershipsOperation:
+		fallthrough
+	case PNSetMembershipsOperation:
+		fallthrough
+	case PNRemoveMembershipsOperation:
+		fallthrough
+	case PNGetChannelMembersOperation:
+		fallthrough
+	case PNSetChannelMembersOperation:
+		fallthrough
+	case PNRemoveChannelMembersOperation:
+		endpoint = "obj"
+		break
 	default:
 		endpoint = "time"
 		break
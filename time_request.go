@@ -62,6 +62,14 @@ func (b *timeBuilder) Execute() (*TimeResponse, StatusResponse, error) {
 	return newTimeResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the Time request asynchronously, the result of the Future is a *TimeResponse.
+func (b *timeBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type timeOpts struct {
 	pubnub     *PubNub
 	QueryParam map[string]string
@@ -37,5 +37,6 @@ func (b *unsubscribeBuilder) QueryParam(queryParam map[string]string) *unsubscri
 
 // Execute runs the Unsubscribe request and unsubscribes from the specified channels.
 func (b *unsubscribeBuilder) Execute() {
+	b.pubnub.subscriptionManager.removeDirectSubscribe(b.operation)
 	b.pubnub.subscriptionManager.adaptUnsubscribe(b.operation)
 }
@@ -0,0 +1,240 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DecodeCBOR decodes a single CBOR data item (RFC 7049), as used by the
+// Access Manager tokens. Maps are decoded as map[string]interface{},
+// arrays as []interface{}, integers as int64, floats as float64,
+// byte strings as []byte and text strings as string. Tags are skipped.
+func DecodeCBOR(data []byte) (interface{}, error) {
+	d := &cborDecoder{data: data}
+
+	value, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("cbor: %d trailing bytes", len(d.data)-d.pos)
+	}
+	return value, nil
+}
+
+var errCBORShortData = errors.New("cbor: unexpected end of data")
+
+// cborBreak marks the end of an indefinite length item.
+type cborBreak struct{}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errCBORShortData
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// readHead returns the major type, the additional information and the argument of an item.
+func (d *cborDecoder) readHead() (byte, byte, uint64, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		b, err = d.read(1)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, uint64(b[0]), nil
+	case info == 25:
+		b, err = d.read(2)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, uint64(binary.BigEndian.Uint16(b)), nil
+	case info == 26:
+		b, err = d.read(4)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, uint64(binary.BigEndian.Uint32(b)), nil
+	case info == 27:
+		b, err = d.read(8)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, binary.BigEndian.Uint64(b), nil
+	case info == 31:
+		return major, info, 0, nil
+	}
+
+	return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+}
+
+func (d *cborDecoder) decode() (interface{}, error) {
+	major, info, arg, err := d.readHead()
+	if err != nil {
+		return nil, err
+	}
+	indefinite := info == 31
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: integer %d overflows int64", arg)
+		}
+		return int64(arg), nil
+
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: integer -1-%d overflows int64", arg)
+		}
+		return -1 - int64(arg), nil
+
+	case 2, 3:
+		var b []byte
+		if indefinite {
+			b, err = d.decodeChunks(major)
+		} else {
+			b, err = d.read(arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(b), nil
+		}
+		return append([]byte{}, b...), nil
+
+	case 4:
+		array := []interface{}{}
+		for i := uint64(0); indefinite || i < arg; i++ {
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := value.(cborBreak); ok {
+				if indefinite {
+					break
+				}
+				return nil, errors.New("cbor: unexpected break")
+			}
+			array = append(array, value)
+		}
+		return array, nil
+
+	case 5:
+		m := make(map[string]interface{})
+		for i := uint64(0); indefinite || i < arg; i++ {
+			key, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := key.(cborBreak); ok {
+				if indefinite {
+					break
+				}
+				return nil, errors.New("cbor: unexpected break")
+			}
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := value.(cborBreak); ok {
+				return nil, errors.New("cbor: unexpected break")
+			}
+			switch k := key.(type) {
+			case string:
+				m[k] = value
+			case []byte:
+				m[string(k)] = value
+			default:
+				m[fmt.Sprintf("%v", k)] = value
+			}
+		}
+		return m, nil
+
+	case 6:
+		return d.decode()
+
+	case 7:
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25:
+			return float64(halfToFloat32(uint16(arg))), nil
+		case 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case 27:
+			return math.Float64frombits(arg), nil
+		case 31:
+			return cborBreak{}, nil
+		}
+		return int64(arg), nil
+	}
+
+	return nil, fmt.Errorf("cbor: invalid major type %d", major)
+}
+
+// decodeChunks concatenates the chunks of an indefinite length string.
+func (d *cborDecoder) decodeChunks(major byte) ([]byte, error) {
+	b := []byte{}
+
+	for {
+		chunkMajor, info, arg, err := d.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if chunkMajor == 7 && info == 31 {
+			return b, nil
+		}
+		if chunkMajor != major || info == 31 {
+			return nil, errors.New("cbor: invalid chunk in indefinite length string")
+		}
+		chunk, err := d.read(arg)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, chunk...)
+	}
+}
+
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+
+	switch exp {
+	case 0:
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			return -f
+		}
+		return f
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	}
+
+	return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+}
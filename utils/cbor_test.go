@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCBORScalars(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		data     []byte
+		expected interface{}
+	}{
+		{[]byte{0x00}, int64(0)},
+		{[]byte{0x17}, int64(23)},
+		{[]byte{0x18, 0x64}, int64(100)},
+		{[]byte{0x19, 0x03, 0xe8}, int64(1000)},
+		{[]byte{0x1a, 0x00, 0x0f, 0x42, 0x40}, int64(1000000)},
+		{[]byte{0x20}, int64(-1)},
+		{[]byte{0x38, 0x63}, int64(-100)},
+		{[]byte{0xf4}, false},
+		{[]byte{0xf5}, true},
+		{[]byte{0xf6}, nil},
+		{[]byte{0xf9, 0x3c, 0x00}, float64(1)},
+		{[]byte{0xfb, 0x3f, 0xf1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}, 1.1},
+		{[]byte{0x64, 0x49, 0x45, 0x54, 0x46}, "IETF"},
+		{[]byte{0x44, 0x01, 0x02, 0x03, 0x04}, []byte{1, 2, 3, 4}},
+		{[]byte{0xc1, 0x1a, 0x51, 0x4b, 0x67, 0xb0}, int64(1363896240)},
+	}
+
+	for _, c := range cases {
+		value, err := DecodeCBOR(c.data)
+		assert.Nil(err, "%x", c.data)
+		assert.Equal(c.expected, value, "%x", c.data)
+	}
+}
+
+func TestDecodeCBORContainers(t *testing.T) {
+	assert := assert.New(t)
+
+	// {"a": 1, "b": [2, 3]}
+	value, err := DecodeCBOR([]byte{0xa2, 0x61, 0x61, 0x01, 0x61, 0x62, 0x82, 0x02, 0x03})
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{
+		"a": int64(1),
+		"b": []interface{}{int64(2), int64(3)},
+	}, value)
+
+	// Byte string keys: {h'6b': 2}
+	value, err = DecodeCBOR([]byte{0xa1, 0x41, 0x6b, 0x02})
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"k": int64(2)}, value)
+
+	// Indefinite length: [_ 1, [2, 3], (_ "a", "b")]
+	value, err = DecodeCBOR([]byte{0x9f, 0x01, 0x82, 0x02, 0x03, 0x7f, 0x61, 0x61, 0x61, 0x62, 0xff, 0xff})
+	assert.Nil(err)
+	assert.Equal([]interface{}{int64(1), []interface{}{int64(2), int64(3)}, "ab"}, value)
+
+	// {_ "a": 1}
+	value, err = DecodeCBOR([]byte{0xbf, 0x61, 0x61, 0x01, 0xff})
+	assert.Nil(err)
+	assert.Equal(map[string]interface{}{"a": int64(1)}, value)
+}
+
+func TestDecodeCBORErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := DecodeCBOR([]byte{})
+	assert.Equal(errCBORShortData, err)
+
+	_, err = DecodeCBOR([]byte{0x64, 0x49})
+	assert.Equal(errCBORShortData, err)
+
+	_, err = DecodeCBOR([]byte{0x01, 0x02})
+	assert.Contains(err.Error(), "1 trailing bytes")
+
+	_, err = DecodeCBOR([]byte{0x1c})
+	assert.Contains(err.Error(), "invalid additional information")
+
+	_, err = DecodeCBOR([]byte{0x82, 0x01, 0xff})
+	assert.Contains(err.Error(), "unexpected break")
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PresenceSuffix is appended to a channel name to get its presence channel.
+	PresenceSuffix = "-pnpres"
+	// ChannelLevelSeparator separates the levels of a channel hierarchy, for ex. a.b.c
+	ChannelLevelSeparator = "."
+	// Wildcard is the last level of a wildcard channel, for ex. a.*
+	Wildcard = "*"
+	// MaxWildcardLevels is the number of levels allowed in a wildcard channel, including the wildcard.
+	MaxWildcardLevels = 3
+)
+
+// IsPresenceChannel returns true if the channel is a presence channel.
+func IsPresenceChannel(channel string) bool {
+	return strings.HasSuffix(channel, PresenceSuffix)
+}
+
+// PresenceChannel returns the presence channel of a channel or a wildcard channel.
+func PresenceChannel(channel string) string {
+	if IsPresenceChannel(channel) {
+		return channel
+	}
+
+	return channel + PresenceSuffix
+}
+
+// ChannelFromPresence returns the channel of a presence channel,
+// other channels are returned as is.
+func ChannelFromPresence(channel string) string {
+	return strings.TrimSuffix(channel, PresenceSuffix)
+}
+
+// ChannelLevels splits a channel name into the levels of its hierarchy.
+func ChannelLevels(channel string) []string {
+	return strings.Split(ChannelFromPresence(channel), ChannelLevelSeparator)
+}
+
+// IsWildcardChannel returns true if the channel, or the channel of a presence channel,
+// contains a wildcard.
+func IsWildcardChannel(channel string) bool {
+	return strings.Contains(ChannelFromPresence(channel), Wildcard)
+}
+
+// ValidateWildcardChannel checks the wildcard rules of a channel:
+// the wildcard must be the whole last level and preceded by at least one
+// and at most MaxWildcardLevels-1 levels, for ex. a.* or a.b.*
+// Channels without a wildcard are always valid.
+func ValidateWildcardChannel(channel string) error {
+	if !IsWildcardChannel(channel) {
+		return nil
+	}
+
+	levels := ChannelLevels(channel)
+
+	if len(levels) < 2 {
+		return fmt.Errorf("Invalid wildcard channel %s: the wildcard must follow a channel level, for ex. a.*", channel)
+	}
+
+	if len(levels) > MaxWildcardLevels {
+		return fmt.Errorf("Invalid wildcard channel %s: more than %d levels", channel, MaxWildcardLevels)
+	}
+
+	for i, level := range levels {
+		last := i == len(levels)-1
+
+		if last && level != Wildcard {
+			return fmt.Errorf("Invalid wildcard channel %s: the wildcard must be the whole last level", channel)
+		}
+
+		if !last && level == "" {
+			return fmt.Errorf("Invalid wildcard channel %s: empty channel level", channel)
+		}
+
+		if !last && strings.Contains(level, Wildcard) {
+			return fmt.Errorf("Invalid wildcard channel %s: the wildcard must be the last level", channel)
+		}
+	}
+
+	return nil
+}
+
+// MatchChannel returns true if the channel is matched by the pattern. The pattern
+// is either a channel name or a wildcard channel, for ex. a.* matches a.b and a.b.c
+// Presence channels only match presence patterns.
+func MatchChannel(pattern, channel string) bool {
+	if IsPresenceChannel(pattern) != IsPresenceChannel(channel) {
+		return false
+	}
+
+	pattern = ChannelFromPresence(pattern)
+	channel = ChannelFromPresence(channel)
+
+	if !IsWildcardChannel(pattern) {
+		return pattern == channel
+	}
+
+	prefix := strings.TrimSuffix(pattern, Wildcard)
+
+	return strings.HasPrefix(channel, prefix) && len(channel) > len(prefix)
+}
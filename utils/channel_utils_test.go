@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresenceChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("ch-pnpres", PresenceChannel("ch"))
+	assert.Equal("ch-pnpres", PresenceChannel("ch-pnpres"))
+	assert.Equal("a.*-pnpres", PresenceChannel("a.*"))
+	assert.Equal("ch", ChannelFromPresence("ch-pnpres"))
+	assert.Equal("ch-pnpresence", ChannelFromPresence("ch-pnpresence"))
+	assert.True(IsPresenceChannel("a.*-pnpres"))
+	assert.False(IsPresenceChannel("ch-pnpresence"))
+}
+
+func TestChannelLevels(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"a", "b", "*"}, ChannelLevels("a.b.*-pnpres"))
+	assert.Equal([]string{"ch"}, ChannelLevels("ch"))
+}
+
+func TestValidateWildcardChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(ValidateWildcardChannel("ch"))
+	assert.Nil(ValidateWildcardChannel("a.b.c.d"))
+	assert.Nil(ValidateWildcardChannel("a.*"))
+	assert.Nil(ValidateWildcardChannel("a.b.*"))
+	assert.Nil(ValidateWildcardChannel("a.b.*-pnpres"))
+
+	assert.NotNil(ValidateWildcardChannel("*"))
+	assert.NotNil(ValidateWildcardChannel("a*"))
+	assert.NotNil(ValidateWildcardChannel(".*"))
+	assert.NotNil(ValidateWildcardChannel("a.b*"))
+	assert.NotNil(ValidateWildcardChannel("a.*.c"))
+	assert.NotNil(ValidateWildcardChannel("a.b.c.*"))
+	assert.NotNil(ValidateWildcardChannel("a..*"))
+}
+
+func TestMatchChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(MatchChannel("ch", "ch"))
+	assert.False(MatchChannel("ch", "ch2"))
+	assert.True(MatchChannel("a.*", "a.b"))
+	assert.True(MatchChannel("a.*", "a.b.c"))
+	assert.False(MatchChannel("a.*", "a"))
+	assert.False(MatchChannel("a.*", "a."))
+	assert.False(MatchChannel("a.*", "ab.c"))
+	assert.True(MatchChannel("a.b.*", "a.b.c"))
+	assert.False(MatchChannel("a.b.*", "a.c.d"))
+	assert.True(MatchChannel("a.*-pnpres", "a.b-pnpres"))
+	assert.False(MatchChannel("a.*", "a.b-pnpres"))
+	assert.False(MatchChannel("a.*-pnpres", "a.b"))
+}
@@ -0,0 +1,644 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterExpression is a parsed subscribe filter expression. The expression
+// compares the fields of the message meta, for ex. meta.language == 'en' or
+// language == 'en', or the publisher, for ex. uuid != 'bot', and supports:
+//   - the comparison operators ==, !=, <, >, <=, >=, LIKE and CONTAINS,
+//   - the arithmetic operators +, -, *, / and %,
+//   - the boolean operators &&, || and ! with parentheses.
+//
+// LIKE matches strings case insensitively, * matches any sequence of characters.
+// CONTAINS matches a substring of a string or an element of a list.
+type FilterExpression struct {
+	expression string
+	root       filterNode
+}
+
+// ParseFilterExpression parses a filter expression, the error reports the
+// position of the first invalid token.
+func ParseFilterExpression(expression string) (*FilterExpression, error) {
+	tokens, err := tokenizeFilterExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{expression: expression, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != filterTokenEOF {
+		return nil, p.errorAt(t, fmt.Sprintf("unexpected %s", t.text))
+	}
+
+	return &FilterExpression{expression: expression, root: root}, nil
+}
+
+// ValidateFilterExpression returns an error if the expression can't be parsed.
+func ValidateFilterExpression(expression string) error {
+	_, err := ParseFilterExpression(expression)
+
+	return err
+}
+
+// String returns the expression as it was parsed.
+func (f *FilterExpression) String() string {
+	return f.expression
+}
+
+// Evaluate returns true if the meta and the publisher of a message match the expression.
+func (f *FilterExpression) Evaluate(meta interface{}, publisher string) bool {
+	return isTruthy(f.root.eval(&filterScope{meta: meta, publisher: publisher}))
+}
+
+type filterScope struct {
+	meta      interface{}
+	publisher string
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenIdent
+	filterTokenString
+	filterTokenNumber
+	filterTokenOperator
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+var filterOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ".",
+}
+
+func tokenizeFilterExpression(expression string) ([]filterToken, error) {
+	tokens := []filterToken{}
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '\'' || r == '"':
+			start := i
+			var sb bytes.Buffer
+			i++
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("Invalid filter expression at %d: unterminated string", start)
+			}
+			i++
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: sb.String(), pos: start})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return nil, fmt.Errorf("Invalid filter expression at %d: invalid number %s", start, text)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenNumber, text: text, pos: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenIdent, text: string(runes[start:i]), pos: start})
+
+		default:
+			matched := false
+			for _, op := range filterOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, filterToken{kind: filterTokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("Invalid filter expression at %d: unexpected character %c", i, r)
+			}
+		}
+	}
+
+	return append(tokens, filterToken{kind: filterTokenEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+type filterParser struct {
+	expression string
+	tokens     []filterToken
+	pos        int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.tokens[p.pos]
+	if t.kind != filterTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) isOperator(op string) bool {
+	t := p.peek()
+	return t.kind == filterTokenOperator && t.text == op
+}
+
+func (p *filterParser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == filterTokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (p *filterParser) expect(op string) error {
+	if !p.isOperator(op) {
+		t := p.peek()
+		return p.errorAt(t, fmt.Sprintf("expected %s, found %s", op, t.text))
+	}
+	p.next()
+	return nil
+}
+
+func (p *filterParser) errorAt(t filterToken, msg string) error {
+	return fmt.Errorf("Invalid filter expression at %d: %s", t.pos, msg)
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filterNode, error) {
+	if p.isOperator("!") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{operand: operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	var op string
+	switch {
+	case p.isOperator("==") || p.isOperator("!=") || p.isOperator("<") ||
+		p.isOperator(">") || p.isOperator("<=") || p.isOperator(">="):
+		op = p.next().text
+	case p.isKeyword("like"):
+		p.next()
+		op = "like"
+	case p.isKeyword("contains"):
+		p.next()
+		op = "contains"
+	default:
+		return left, nil
+	}
+
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return &filterBinary{op: op, left: left, right: right}, nil
+}
+
+func (p *filterParser) parseAdditive() (filterNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("+") || p.isOperator("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseMultiplicative() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("*") || p.isOperator("/") || p.isOperator("%") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.isOperator("-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterBinary{op: "-", left: &filterLiteral{value: float64(0)}, right: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case filterTokenNumber:
+		f, _ := strconv.ParseFloat(t.text, 64)
+		return &filterLiteral{value: f}, nil
+
+	case filterTokenString:
+		return &filterLiteral{value: t.text}, nil
+
+	case filterTokenIdent:
+		switch {
+		case strings.EqualFold(t.text, "uuid"):
+			return &filterPublisher{}, nil
+		case t.text == "meta":
+			return p.parseMetaPath([]string{})
+		case strings.EqualFold(t.text, "like") || strings.EqualFold(t.text, "contains"):
+			return nil, p.errorAt(t, fmt.Sprintf("missing operand before %s", t.text))
+		}
+		// Fields without the meta prefix, for ex. language != 'spanish'
+		return p.parseMetaPath([]string{t.text})
+
+	case filterTokenOperator:
+		if t.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+
+	return nil, p.errorAt(t, fmt.Sprintf("unexpected %s", t.text))
+}
+
+func (p *filterParser) parseMetaPath(path []string) (filterNode, error) {
+
+	for {
+		switch {
+		case p.isOperator("."):
+			p.next()
+			t := p.next()
+			if t.kind != filterTokenIdent {
+				return nil, p.errorAt(t, fmt.Sprintf("expected a field name, found %s", t.text))
+			}
+			path = append(path, t.text)
+
+		case p.isOperator("["):
+			p.next()
+			t := p.next()
+			if t.kind != filterTokenString && t.kind != filterTokenNumber {
+				return nil, p.errorAt(t, fmt.Sprintf("expected a field name, found %s", t.text))
+			}
+			path = append(path, t.text)
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+
+		default:
+			if len(path) == 0 {
+				return nil, p.errorAt(p.peek(), "expected a field of meta")
+			}
+			return &filterField{path: path}, nil
+		}
+	}
+}
+
+type filterNode interface {
+	eval(scope *filterScope) interface{}
+}
+
+type filterLiteral struct {
+	value interface{}
+}
+
+func (n *filterLiteral) eval(scope *filterScope) interface{} {
+	return n.value
+}
+
+type filterPublisher struct{}
+
+func (n *filterPublisher) eval(scope *filterScope) interface{} {
+	return scope.publisher
+}
+
+type filterField struct {
+	path []string
+}
+
+func (n *filterField) eval(scope *filterScope) interface{} {
+	value := scope.meta
+
+	for _, key := range n.path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case map[string]string:
+			value = v[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			value = v[i]
+		default:
+			return nil
+		}
+	}
+	return value
+}
+
+type filterNot struct {
+	operand filterNode
+}
+
+func (n *filterNot) eval(scope *filterScope) interface{} {
+	return !isTruthy(n.operand.eval(scope))
+}
+
+type filterBinary struct {
+	op    string
+	left  filterNode
+	right filterNode
+}
+
+func (n *filterBinary) eval(scope *filterScope) interface{} {
+	left := n.left.eval(scope)
+
+	switch n.op {
+	case "&&":
+		return isTruthy(left) && isTruthy(n.right.eval(scope))
+	case "||":
+		return isTruthy(left) || isTruthy(n.right.eval(scope))
+	}
+
+	right := n.right.eval(scope)
+
+	switch n.op {
+	case "==":
+		return filterEquals(left, right)
+	case "!=":
+		return !filterEquals(left, right)
+	case "<", ">", "<=", ">=":
+		return filterCompare(n.op, left, right)
+	case "like":
+		return filterLike(left, right)
+	case "contains":
+		return filterContains(left, right)
+	}
+
+	return filterArithmetic(n.op, left, right)
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+func filterNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func filterString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+func filterEquals(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return false
+	}
+
+	_, leftIsString := left.(string)
+	_, rightIsString := right.(string)
+	if !(leftIsString && rightIsString) {
+		if l, ok := filterNumber(left); ok {
+			if r, ok := filterNumber(right); ok {
+				return l == r
+			}
+		}
+	}
+
+	l, lok := filterString(left)
+	r, rok := filterString(right)
+	return lok && rok && l == r
+}
+
+func filterCompare(op string, left, right interface{}) bool {
+	var cmp int
+
+	l, lok := filterNumber(left)
+	r, rok := filterNumber(right)
+	if lok && rok {
+		switch {
+		case l < r:
+			cmp = -1
+		case l > r:
+			cmp = 1
+		}
+	} else {
+		ls, lok := filterString(left)
+		rs, rok := filterString(right)
+		if !lok || !rok {
+			return false
+		}
+		cmp = strings.Compare(ls, rs)
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	}
+	return cmp >= 0
+}
+
+func filterLike(left, right interface{}) bool {
+	value, lok := filterString(left)
+	pattern, rok := filterString(right)
+	if !lok || !rok {
+		return false
+	}
+
+	return matchWildcardPattern(strings.ToLower(pattern), strings.ToLower(value))
+}
+
+// matchWildcardPattern matches a value against a pattern where * matches any
+// sequence of characters.
+func matchWildcardPattern(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+func filterContains(left, right interface{}) bool {
+	switch v := left.(type) {
+	case []interface{}:
+		for _, element := range v {
+			if filterEquals(element, right) {
+				return true
+			}
+		}
+		return false
+	case string:
+		s, ok := filterString(right)
+		return ok && strings.Contains(v, s)
+	}
+	return false
+}
+
+func filterArithmetic(op string, left, right interface{}) interface{} {
+	l, lok := filterNumber(left)
+	r, rok := filterNumber(right)
+	if !lok || !rok {
+		return nil
+	}
+
+	switch op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		if r == 0 {
+			return nil
+		}
+		return l / r
+	case "%":
+		// the operands are truncated, 0.5 is a zero divisor
+		if int64(r) == 0 {
+			return nil
+		}
+		return float64(int64(l) % int64(r))
+	}
+	return nil
+}
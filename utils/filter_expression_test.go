@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFilterExpression(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(ValidateFilterExpression("meta.language == 'en'"))
+	assert.Nil(ValidateFilterExpression("language!=spanish"))
+	assert.Nil(ValidateFilterExpression(`(meta["price"] * 2 > 10 || meta.tags CONTAINS "sale") && !(uuid like 'bot*')`))
+
+	err := ValidateFilterExpression("meta.language == 'en")
+	assert.Contains(err.Error(), "at 17: unterminated string")
+
+	err = ValidateFilterExpression("meta.language = 'en'")
+	assert.Contains(err.Error(), "unexpected character =")
+
+	err = ValidateFilterExpression("(meta.a == 1")
+	assert.Contains(err.Error(), "expected ), found end of expression")
+
+	err = ValidateFilterExpression("meta.a == 1 meta.b")
+	assert.Contains(err.Error(), "at 12: unexpected meta")
+
+	err = ValidateFilterExpression("meta == 1")
+	assert.Contains(err.Error(), "expected a field of meta")
+
+	err = ValidateFilterExpression("LIKE 'a'")
+	assert.Contains(err.Error(), "missing operand before LIKE")
+}
+
+func TestFilterExpressionEvaluate(t *testing.T) {
+	assert := assert.New(t)
+	meta := map[string]interface{}{
+		"language": "en",
+		"price":    float64(7),
+		"count":    "3",
+		"tags":     []interface{}{"sale", "new"},
+		"user": map[string]interface{}{
+			"name": "Alice",
+		},
+	}
+
+	cases := map[string]bool{
+		"meta.language == 'en'":              true,
+		"language != 'en'":                   false,
+		"meta.price * 2 > 10":                true,
+		"meta.price - 10 < -2":               true,
+		"meta.price % 4 == 3":                true,
+		"meta.count == 3":                    true,
+		"meta.count >= '3'":                  true,
+		"meta.tags CONTAINS 'sale'":          true,
+		"meta.tags contains 'old'":           false,
+		"meta.user.name LIKE 'ali*'":         true,
+		`meta["user"]["name"] like "*CE"`:    true,
+		"meta.user.name contains 'lic'":      true,
+		"meta.missing == 'x'":                false,
+		"meta.missing != 'x'":                true,
+		"uuid == 'publisher'":                true,
+		"!(uuid LIKE 'pub*') || meta.price":  true,
+		"meta.price / 0 == 1":                false,
+		"meta.price % 0.5 == 0":              false,
+		"meta.language == 'en' && uuid == 1": false,
+	}
+
+	for expression, expected := range cases {
+		filter, err := ParseFilterExpression(expression)
+		assert.Nil(err, expression)
+		assert.Equal(expected, filter.Evaluate(meta, "publisher"), expression)
+		assert.Equal(expression, filter.String())
+	}
+}
+
+func TestFilterExpressionEvaluateWithoutMeta(t *testing.T) {
+	assert := assert.New(t)
+
+	filter, err := ParseFilterExpression("meta.language == 'en'")
+	assert.Nil(err)
+	assert.False(filter.Evaluate(nil, ""))
+	assert.False(filter.Evaluate("plain", ""))
+}
@@ -69,6 +69,14 @@ func (b *whereNowBuilder) Execute() (*WhereNowResponse, StatusResponse, error) {
 	return newWhereNowResponse(rawJSON, status)
 }
 
+// ExecuteAsync runs the WhereNow request asynchronously, the result of the Future is a *WhereNowResponse.
+func (b *whereNowBuilder) ExecuteAsync() *Future {
+	return newFuture(b.opts, func() (interface{}, StatusResponse, error) {
+		resp, status, err := b.Execute()
+		return resp, status, err
+	})
+}
+
 type whereNowOpts struct {
 	pubnub *PubNub
 